@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/spf13/pflag"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
+)
+
+// errInvalidDocument is wrapped by canonicalizeDocuments when a document fails --schema validation
+var errInvalidDocument = errors.New("one or more documents are invalid")
+
+// canonicalizeOptions are the flags of "jsonschema-validator canonicalize"
+type canonicalizeOptions struct {
+	write         bool
+	to            string // "" keeps each document's format, "json" always writes JSON
+	forceFiletype string
+	schema        *jsonschema.Schema
+	schemaPath    string
+}
+
+// runCanonicalize implements "jsonschema-validator canonicalize": it rewrites documents
+// with sorted keys and consistent formatting, like "terraform fmt"
+func runCanonicalize(args []string) error {
+	flags := pflag.NewFlagSet("canonicalize", pflag.ContinueOnError)
+
+	var (
+		opts          canonicalizeOptions
+		schemaVersion string
+		showHelp      bool
+	)
+
+	flags.BoolVarP(&opts.write, "write", "w", false, "Rewrite the documents in place instead of printing them; changed files are listed")
+	flags.StringVar(&opts.to, "to", "", "Output format: json (default: the document's own format)")
+	flags.StringVarP(&opts.schemaPath, "schema", "s", "", "Validate each document against this schema first; invalid documents are not rewritten")
+	flags.StringVar(&schemaVersion, "schema-version", "", "JSON Schema version for --schema (draft/2020-12, draft/2019-09, draft-07, draft-06, draft-04)")
+	flags.StringVar(&opts.forceFiletype, "force-filetype", "", "Force file type for documents (json, jsonc, json5, yaml, toml, jsonl, msgpack, cbor). Auto-detected from extension if not set")
+	flags.BoolVarP(&showHelp, "help", "h", false, "Show help and exit")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, `jsonschema-validator canonicalize - Rewrite documents with sorted keys
+
+Usage:
+  jsonschema-validator canonicalize [--write] [--to json] [--schema s.json] documents...
+
+JSON, JSONC and JSON5 documents become key-sorted JSON indented by two spaces; YAML,
+TOML and JSONL documents keep their format. Comments are not kept.
+
+Flags:
+`)
+		flags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Examples:
+  # Print the canonical form of a document
+  jsonschema-validator canonicalize config.json
+
+  # Rewrite every config in place after checking it against its schema
+  jsonschema-validator canonicalize --write --schema config.schema.json config/*.yaml
+
+  # Convert a YAML document to canonical JSON
+  jsonschema-validator canonicalize --to json config.yaml > config.json
+`)
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if showHelp {
+		flags.Usage()
+		return nil
+	}
+
+	if flags.NArg() == 0 {
+		return fmt.Errorf("canonicalize: at least one document is required")
+	}
+	if opts.to != "" && opts.to != "json" {
+		return fmt.Errorf("canonicalize: invalid --to %q: must be json", opts.to)
+	}
+
+	if opts.schemaPath != "" {
+		loaded, err := loadSchema(config.SchemaConfig{Path: opts.schemaPath}, &config.Config{SchemaVersion: schemaVersion}, "")
+		if err != nil {
+			return fmt.Errorf("canonicalize: %w", err)
+		}
+		opts.schema = loaded.schema
+	}
+
+	err := canonicalizeDocuments(flags.Args(), opts, os.Stdout)
+	if errors.Is(err, errInvalidDocument) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitValidationFail)
+	}
+	return err
+}
+
+// canonicalizeDocuments prints the canonical form of each document to stdout or, with
+// write, replaces the files that change and lists them. Every document is processed
+// even when an earlier one fails.
+func canonicalizeDocuments(docPaths []string, opts canonicalizeOptions, stdout io.Writer) error {
+	var errs []error
+	for _, docPath := range docPaths {
+		if opts.write && docPath == stdinPath {
+			errs = append(errs, fmt.Errorf("canonicalize: --write cannot rewrite %s", stdinLabel))
+			continue
+		}
+
+		content, canonical, err := canonicalizeDocument(docPath, opts)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !opts.write {
+			if _, err := stdout.Write(canonical); err != nil {
+				return err
+			}
+			continue
+		}
+		if bytes.Equal(content, canonical) {
+			continue
+		}
+		info, err := os.Stat(docPath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("canonicalize: %w", err))
+			continue
+		}
+		if err := os.WriteFile(docPath, canonical, info.Mode().Perm()); err != nil {
+			errs = append(errs, fmt.Errorf("canonicalize: failed to write %q: %w", docPath, err))
+			continue
+		}
+		fmt.Fprintln(stdout, docPath)
+	}
+	return errors.Join(errs...)
+}
+
+// canonicalizeDocument returns the content of the document at docPath and its canonical form
+func canonicalizeDocument(docPath string, opts canonicalizeOptions) ([]byte, []byte, error) {
+	label := docPath
+	var (
+		content []byte
+		err     error
+	)
+	if docPath == stdinPath {
+		label = stdinLabel
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(docPath)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read document %q: %w", label, err)
+	}
+
+	fileType := validator.FileType(opts.forceFiletype)
+	switch {
+	case fileType != "" && fileType != validator.FileTypeAuto:
+	case docPath == stdinPath:
+		fileType = validator.DetectContentType(content)
+	default:
+		fileType = validator.DetectFileType(docPath)
+	}
+
+	data, err := validator.ParseBytes(content, fileType, validator.ParseOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse document %q: %w", label, err)
+	}
+
+	if opts.schema != nil {
+		// A JSONL document is validated record by record
+		records := []interface{}{data}
+		if fileType == validator.FileTypeJSONL {
+			records, _ = data.([]interface{})
+		}
+		for _, record := range records {
+			if err := opts.schema.Validate(record); err != nil {
+				formatted := validator.FormatDocumentValidationError(err, opts.schemaPath, label, record, "{{.FullMessage}}", validator.ErrorOptions{})
+				return nil, nil, fmt.Errorf("%w: document %q: %w", errInvalidDocument, label, formatted)
+			}
+		}
+	}
+
+	canonical, err := encodeCanonical(data, fileType, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("canonicalize %q: %w", label, err)
+	}
+	return content, canonical, nil
+}
+
+// encodeCanonical writes data in the format of fileType, or as JSON when opts.to is
+// "json" or the format is in the JSON family
+func encodeCanonical(data interface{}, fileType validator.FileType, opts canonicalizeOptions) ([]byte, error) {
+	// With --write the file keeps its name, so JSON only replaces formats that read it
+	if opts.to == "json" && opts.write {
+		switch fileType {
+		case validator.FileTypeJSON, validator.FileTypeJSONC, validator.FileTypeJSON5, validator.FileTypeYAML:
+		default:
+			return nil, fmt.Errorf("--to json cannot rewrite a %s document in place", fileType)
+		}
+	}
+
+	switch {
+	case opts.to == "json", fileType == validator.FileTypeJSON, fileType == validator.FileTypeJSONC, fileType == validator.FileTypeJSON5:
+		out, err := validator.MarshalDeterministicIndent(data)
+		if err != nil {
+			return nil, err
+		}
+		return append(out, '\n'), nil
+	case fileType == validator.FileTypeYAML:
+		return validator.MarshalDeterministicYAML(data)
+	case fileType == validator.FileTypeTOML:
+		return validator.MarshalDeterministicTOML(data)
+	case fileType == validator.FileTypeJSONL:
+		records, _ := data.([]interface{})
+		var out []byte
+		for _, record := range records {
+			line, err := validator.MarshalDeterministic(record)
+			if err != nil {
+				return nil, err
+			}
+			out = append(append(out, line...), '\n')
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%s documents can only be canonicalized with --to json", fileType)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+)
+
+func writeCanonicalizeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCanonicalizeDocuments(t *testing.T) {
+	dir := writeCanonicalizeFiles(t, map[string]string{
+		"config.json5": "{\n  // comment\n  b: [2, 1],\n  a: 'x',\n}\n",
+		"config.yaml":  "port: 8080\nname:   api\n",
+		"config.toml":  "b = 1\na = \"x\"\n",
+		"events.jsonl": "{\"b\": 1, \"a\": 2}\n\n{\"a\": 3}\n",
+	})
+
+	tests := []struct {
+		name string
+		file string
+		to   string
+		want string
+	}{
+		{"JSON5 becomes JSON", "config.json5", "", "{\n  \"a\": \"x\",\n  \"b\": [\n    2,\n    1\n  ]\n}\n"},
+		{"YAML stays YAML", "config.yaml", "", "name: api\nport: 8080\n"},
+		{"YAML to JSON", "config.yaml", "json", "{\n  \"name\": \"api\",\n  \"port\": 8080\n}\n"},
+		{"TOML stays TOML", "config.toml", "", "a = 'x'\nb = 1\n"},
+		{"JSONL stays JSONL", "events.jsonl", "", "{\"a\":2,\"b\":1}\n{\"a\":3}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if err := canonicalizeDocuments([]string{filepath.Join(dir, tt.file)}, canonicalizeOptions{to: tt.to}, &stdout); err != nil {
+				t.Fatalf("canonicalizeDocuments() error = %v", err)
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("output = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeDocuments_Write(t *testing.T) {
+	dir := writeCanonicalizeFiles(t, map[string]string{
+		"a.yaml": "z: 1\na: 2\n",
+		"b.yaml": "a: 2\nz: 1\n",
+		"c.toml": "a = 1\n",
+	})
+	paths := []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")}
+
+	var stdout bytes.Buffer
+	if err := canonicalizeDocuments(paths, canonicalizeOptions{write: true}, &stdout); err != nil {
+		t.Fatalf("canonicalizeDocuments() error = %v", err)
+	}
+	// Only the file that changed is rewritten and listed
+	if got, want := stdout.String(), paths[0]+"\n"; got != want {
+		t.Errorf("listed files = %q, want %q", got, want)
+	}
+	if content, _ := os.ReadFile(paths[0]); string(content) != "a: 2\nz: 1\n" {
+		t.Errorf("a.yaml = %q, want sorted keys", content)
+	}
+
+	err := canonicalizeDocuments([]string{filepath.Join(dir, "c.toml")}, canonicalizeOptions{write: true, to: "json"}, &stdout)
+	if err == nil || !strings.Contains(err.Error(), "--to json cannot rewrite a toml document in place") {
+		t.Errorf("canonicalizeDocuments(--write --to json) error = %v, want in place error", err)
+	}
+}
+
+func TestCanonicalizeDocuments_Schema(t *testing.T) {
+	dir := writeCanonicalizeFiles(t, map[string]string{
+		"schema.json":  `{"properties": {"port": {"type": "integer"}}}`,
+		"valid.json":   `{"port": 1, "a": true}`,
+		"invalid.json": `{"port": "x"}`,
+	})
+	loaded, err := loadSchema(config.SchemaConfig{Path: filepath.Join(dir, "schema.json")}, &config.Config{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := canonicalizeOptions{write: true, schema: loaded.schema, schemaPath: filepath.Join(dir, "schema.json")}
+
+	var stdout bytes.Buffer
+	err = canonicalizeDocuments([]string{filepath.Join(dir, "invalid.json"), filepath.Join(dir, "valid.json")}, opts, &stdout)
+	if !errors.Is(err, errInvalidDocument) || !strings.Contains(err.Error(), "got string, want integer") {
+		t.Fatalf("canonicalizeDocuments() error = %v, want invalid document", err)
+	}
+	// The invalid document is left alone; the valid one after it is still rewritten
+	if content, _ := os.ReadFile(filepath.Join(dir, "invalid.json")); string(content) != `{"port": "x"}` {
+		t.Errorf("invalid.json was rewritten: %q", content)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "valid.json")); string(content) != "{\n  \"a\": true,\n  \"port\": 1\n}\n" {
+		t.Errorf("valid.json = %q, want canonical JSON", content)
+	}
+}
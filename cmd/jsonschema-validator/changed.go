@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+)
+
+// defaultBaseRef is the ref --changed-only compares HEAD against without --base-ref
+const defaultBaseRef = "origin/main"
+
+// errNotGitRepository reports that --changed-only cannot ask git what changed, because
+// git is not installed or the working directory is not in a repository
+var errNotGitRepository = errors.New("not in a git repository")
+
+// changedFiles returns the files changed on HEAD since it diverged from baseRef, as
+// `git diff --name-only baseRef...HEAD` lists them, keyed by their absolute path.
+// Deleted files are left out, since there is nothing left to validate.
+func changedFiles(baseRef string) (map[string]bool, error) {
+	root, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errNotGitRepository, err)
+	}
+	root = resolvePath(strings.TrimSpace(root))
+
+	names, err := gitOutput("diff", "--name-only", "-z", "--diff-filter=d", baseRef+"...HEAD", "--")
+	if err != nil {
+		return nil, fmt.Errorf("listing the files changed since %q: %w", baseRef, err)
+	}
+
+	changed := make(map[string]bool)
+	for _, name := range strings.Split(names, "\x00") {
+		if name != "" {
+			changed[filepath.Join(root, filepath.FromSlash(name))] = true
+		}
+	}
+	return changed, nil
+}
+
+// gitOutput runs git with args in the working directory and returns its output,
+// or an error with what git printed on failure
+func gitOutput(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// filterChangedDocuments keeps the expanded documents of each schema that are in
+// changed, dropping schemas left without documents. A schema whose own file changed
+// keeps all of its documents, since the change can break any of them.
+func filterChangedDocuments(schemas []config.SchemaConfig, changed map[string]bool) []config.SchemaConfig {
+	var filtered []config.SchemaConfig
+	for _, schemaConfig := range schemas {
+		if schemaConfig.Path != stdinPath && changed[resolvePath(schemaConfig.Path)] {
+			filtered = append(filtered, schemaConfig)
+			continue
+		}
+
+		var documents []string
+		for _, document := range schemaConfig.Documents {
+			// Standard input and URLs are not files of the repository
+			if document != stdinPath && changed[resolvePath(document)] {
+				documents = append(documents, document)
+			}
+		}
+		if len(documents) > 0 {
+			schemaConfig.Documents = documents
+			filtered = append(filtered, schemaConfig)
+		}
+	}
+	return filtered
+}
+
+// resolvePath returns the absolute path of path with symlinks resolved, as git
+// reports its top-level directory, or the absolute path when it cannot be resolved
+func resolvePath(path string) string {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if resolved, err := filepath.EvalSymlinks(absolute); err == nil {
+		return resolved
+	}
+	return absolute
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+)
+
+func TestChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	t.Run("outside a repository", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(resolvePath(".")))
+		if _, err := changedFiles(defaultBaseRef); !errors.Is(err, errNotGitRepository) {
+			t.Errorf("changedFiles() error = %v, want errNotGitRepository", err)
+		}
+	})
+
+	t.Chdir(t.TempDir())
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "--quiet", "--initial-branch", "main")
+	writeFile("schema.json", `{"type": "object"}`)
+	writeFile("configs/a.json", `{}`)
+	writeFile("configs/b.json", `{}`)
+	writeFile("configs/old.json", `{}`)
+	git("add", ".")
+	git("commit", "--quiet", "--message", "base")
+
+	git("checkout", "--quiet", "-b", "feature")
+	writeFile("configs/b.json", `{"changed": true}`)
+	writeFile("configs/new.json", `{}`)
+	git("rm", "--quiet", "configs/old.json")
+	git("add", ".")
+	git("commit", "--quiet", "--message", "change")
+
+	changed, err := changedFiles("main")
+	if err != nil {
+		t.Fatalf("changedFiles() error = %v", err)
+	}
+	var got []string
+	for path := range changed {
+		got = append(got, filepath.Base(path))
+	}
+	slices.Sort(got)
+	if want := []string{"b.json", "new.json"}; !slices.Equal(got, want) {
+		t.Errorf("changedFiles() = %v, want %v", got, want)
+	}
+
+	if _, err := changedFiles("missing-branch"); err == nil || errors.Is(err, errNotGitRepository) {
+		t.Errorf("changedFiles() with an unknown base ref error = %v, want a git diff error", err)
+	}
+}
+
+func TestFilterChangedDocuments(t *testing.T) {
+	t.Chdir(t.TempDir())
+	changed := map[string]bool{
+		resolvePath("configs/b.json"):      true,
+		resolvePath("changed.schema.json"): true,
+	}
+
+	schemas := []config.SchemaConfig{
+		{Path: "schema.json", Documents: []string{"configs/a.json", "configs/b.json", stdinPath, "https://example.com/b.json"}},
+		{Path: "other.schema.json", Documents: []string{"configs/a.json"}},
+		{Path: "changed.schema.json", Documents: []string{"configs/a.json", "configs/c.json"}},
+	}
+	filtered := filterChangedDocuments(schemas, changed)
+
+	if len(filtered) != 2 {
+		t.Fatalf("filterChangedDocuments() = %+v, want the first and last schemas", filtered)
+	}
+	if want := []string{"configs/b.json"}; filtered[0].Path != "schema.json" || !slices.Equal(filtered[0].Documents, want) {
+		t.Errorf("filtered[0] = %+v, want schema.json with %v", filtered[0], want)
+	}
+	// A changed schema keeps every document
+	if filtered[1].Path != "changed.schema.json" || !slices.Equal(filtered[1].Documents, schemas[2].Documents) {
+		t.Errorf("filtered[1] = %+v, want changed.schema.json with all its documents", filtered[1])
+	}
+	if len(schemas[0].Documents) != 4 {
+		t.Errorf("filterChangedDocuments() modified its input: %v", schemas[0].Documents)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/spf13/pflag"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
+)
+
+// errInvalidExamples is returned by testExamples when an example fails its subschema
+var errInvalidExamples = errors.New("one or more x-examples are invalid")
+
+// runTestExamples implements "jsonschema-validator test-examples": it validates every
+// x-examples entry of a schema against the subschema that holds it
+func runTestExamples(args []string) error {
+	flags := pflag.NewFlagSet("test-examples", pflag.ContinueOnError)
+
+	var (
+		schemaPath    string
+		schemaVersion string
+		refOverrides  []string
+		noNetwork     bool
+		output        string
+		showHelp      bool
+	)
+
+	flags.StringVarP(&schemaPath, "schema", "s", "", "Path to the JSON Schema file whose x-examples are checked (required)")
+	flags.StringVar(&schemaVersion, "schema-version", "", "JSON Schema version (draft/2020-12, draft/2019-09, draft-07, draft-06, draft-04)")
+	flags.StringArrayVar(&refOverrides, "ref-override", nil, "Override remote $ref (format: url=path, can be repeated)")
+	flags.BoolVar(&noNetwork, "no-network", false, "Fail on remote $refs instead of fetching them; use --ref-override")
+	flags.StringVarP(&output, "output", "o", OutputText, "Output format: text, json, ndjson, sarif or junit")
+	flags.BoolVarP(&showHelp, "help", "h", false, "Show help and exit")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, `jsonschema-validator test-examples - Check that a schema's x-examples are valid
+
+Usage:
+  jsonschema-validator test-examples --schema s.json [--ref-override url=path...]
+
+Every "x-examples" array in the schema, at the root or in any subschema, is a list of
+example documents. Each example is validated against the subschema that holds it and
+reported like a document named after its location, e.g. s.json#/properties/port/x-examples/0.
+
+Flags:
+`)
+		flags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Examples:
+  # Fail the build when a documented example no longer matches the schema
+  jsonschema-validator test-examples --schema config.schema.json
+`)
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if showHelp {
+		flags.Usage()
+		return nil
+	}
+
+	if schemaPath == "" {
+		return fmt.Errorf("test-examples: --schema is required")
+	}
+	if schemaPath == stdinPath {
+		return fmt.Errorf("test-examples: the schema must be a file, not %s", stdinLabel)
+	}
+
+	rep, err := newReporter(output, os.Stdout, os.Stderr)
+	if err != nil {
+		return fmt.Errorf("test-examples: %w", err)
+	}
+	schemaConfig := config.SchemaConfig{
+		Path:         schemaPath,
+		RefOverrides: config.ParseRefOverridesFromSlice(refOverrides),
+	}
+	loaded, err := loadSchema(schemaConfig, &config.Config{SchemaVersion: schemaVersion, NoNetwork: noNetwork}, "")
+	if err != nil {
+		return fmt.Errorf("test-examples: %w", err)
+	}
+
+	err = testExamples(loaded, schemaPath, rep)
+	if finishErr := rep.Finish(); finishErr != nil {
+		return fmt.Errorf("failed to write results: %w", finishErr)
+	}
+	if errors.Is(err, errInvalidExamples) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(ExitValidationFail)
+	}
+	return err
+}
+
+// testExamples reports every x-examples entry of the loaded schema as a document
+// result, validated against the nearest enclosing subschema, and returns
+// errInvalidExamples when any of them fails
+func testExamples(loaded *loadedSchema, schemaPath string, rep reporter) error {
+	found := validator.FindExamples(loaded.data)
+	if len(found) == 0 {
+		fmt.Fprintf(os.Stderr, "Warning: schema %q has no %s\n", schemaPath, validator.ExamplesKeyword)
+	}
+
+	failed := false
+	for _, subschema := range found {
+		schemaLabel := schemaPath + "#" + subschema.Pointer
+		compiled, compileErr := loaded.compiler.Compile(loaded.url + "#" + (&url.URL{Fragment: subschema.Pointer}).EscapedFragment())
+
+		for i, example := range subschema.Examples {
+			result := documentResult{
+				Document: schemaLabel + "/" + validator.ExamplesKeyword + "/" + strconv.Itoa(i),
+				Schema:   schemaLabel,
+				Valid:    true,
+			}
+			err := compileErr
+			if err == nil {
+				err = compiled.Validate(example)
+			}
+			if err != nil {
+				failed = true
+				result.Valid = false
+				result.Errors = validator.ExtractValidationErrors(err, example)
+				if compileErr != nil {
+					result.err = fmt.Errorf("example %q: failed to compile its subschema: %w", result.Document, compileErr)
+				} else {
+					result.err = fmt.Errorf("example %q: %w", result.Document, validator.FormatDocumentValidationError(err, schemaLabel, result.Document, example, "{{.FullMessage}}", validator.ErrorOptions{}))
+				}
+			}
+			if err := rep.Report(result); err != nil {
+				return fmt.Errorf("failed to write results: %w", err)
+			}
+		}
+	}
+
+	if failed {
+		return errInvalidExamples
+	}
+	return nil
+}
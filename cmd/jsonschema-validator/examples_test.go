@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+)
+
+func TestTestExamples(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.json")
+	schema := `{
+		"type": "object",
+		"x-examples": [{"port": 8080}],
+		"properties": {
+			"port": {"type": "integer", "minimum": 1, "x-examples": [443, 0]}
+		}
+	}`
+	if err := os.WriteFile(schemaPath, []byte(schema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadSchema(config.SchemaConfig{Path: schemaPath}, &config.Config{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	rep, err := newReporter(OutputJSON, &stdout, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testExamples(loaded, schemaPath, rep); !errors.Is(err, errInvalidExamples) {
+		t.Errorf("testExamples() error = %v, want errInvalidExamples", err)
+	}
+	if err := rep.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	var results []struct {
+		Document string
+		Valid    bool
+		Errors   []struct{ Message string }
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+	}
+	want := map[string]bool{
+		schemaPath + "#/x-examples/0":                 true,
+		schemaPath + "#/properties/port/x-examples/0": true,
+		schemaPath + "#/properties/port/x-examples/1": false,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("reported %d examples, want %d:\n%s", len(results), len(want), stdout.String())
+	}
+	for _, result := range results {
+		valid, ok := want[result.Document]
+		if !ok || result.Valid != valid {
+			t.Errorf("example %s valid = %v, want %v (known: %v)", result.Document, result.Valid, valid, ok)
+		}
+		if !result.Valid && (len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, "minimum")) {
+			t.Errorf("example %s errors = %+v, want the minimum violation", result.Document, result.Errors)
+		}
+	}
+}
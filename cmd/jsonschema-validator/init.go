@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+)
+
+// initConfigFile is the file "jsonschema-validator init" writes, the first name
+// configuration discovery looks for
+const initConfigFile = ".jsonschema-validator.yaml"
+
+// initConfig is the sample configuration written by "jsonschema-validator init"
+var initConfig = config.Config{
+	SchemaVersion: "draft/2020-12",
+	Schemas: []config.SchemaConfig{
+		{
+			Path:      "schemas/config.schema.json",
+			Documents: []string{"config/*.yaml", "config/*.json"},
+			RefOverrides: map[string]string{
+				"https://example.com/schemas/service.json": "./schemas/service.json",
+			},
+		},
+	},
+	RefOverrides: map[string]string{
+		"https://example.com/schemas/common.json": "./schemas/common.json",
+	},
+}
+
+// initComments explains each top-level key of the written configuration
+var initComments = map[string]string{
+	"schema_version":        "JSON Schema draft for schemas without \"$schema\" (draft-04, draft-06, draft-07, draft/2019-09, draft/2020-12)",
+	"schemas":               "Schemas and the documents (files or glob patterns) each one validates",
+	"error_template":        "Go template for error messages, e.g. \"{{.ErrorCount}} error(s):{{range .Errors}} {{.DocumentPath}}: {{.Message}}{{end}}\" (empty: default format)",
+	"ref_overrides":         "Local copies of remote $refs, shared by every schema; a schema's own ref_overrides win",
+	"forbid_duplicate_keys": "Reject JSON/JSON5 documents that repeat an object key",
+	"no_network":            "Fail on remote $refs that are not covered by ref_overrides",
+	"validate_schema":       "Check every schema against its draft's meta-schema before compiling it",
+	"max_errors":            "Show at most this many errors per document, lowest paths first, then \"... and N more\" (0: all)",
+	"fetch_timeout":         "Timeout for each HTTP(S) request for a remote document or $ref, e.g. \"10s\" (empty: 30s)",
+	"fail_fast":             "Stop at the first invalid document, skipping the rest (the results so far are still reported)",
+	"jobs":                  "Validate up to this many documents of a schema at once; results keep document order (0 or 1: one at a time)",
+}
+
+// initSchemaComments explains each key of a "schemas" entry
+var initSchemaComments = map[string]string{
+	"path":           "Path to the schema file (.json, .json5, .yaml, .yml)",
+	"documents":      "Documents to validate; glob patterns are supported",
+	"force_filetype": "Document format (json, jsonc, json5, yaml, toml, jsonl, msgpack, cbor); empty: detect from the extension",
+	"ref_overrides":  "Local copies of remote $refs for this schema only",
+	"schema_version": "Draft for this schema; empty: the top-level schema_version",
+	"error_template": "Error template for this schema; empty: the top-level error_template",
+}
+
+// runInit implements "jsonschema-validator init": it writes a commented sample
+// configuration to .jsonschema-validator.yaml
+func runInit(args []string) error {
+	flags := pflag.NewFlagSet("init", pflag.ContinueOnError)
+
+	var (
+		force    bool
+		showHelp bool
+	)
+
+	flags.BoolVarP(&force, "force", "f", false, "Overwrite an existing "+initConfigFile)
+	flags.BoolVarP(&showHelp, "help", "h", false, "Show help and exit")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, `jsonschema-validator init - Write a sample %s
+
+Usage:
+  jsonschema-validator init [--force]
+
+Flags:
+`, initConfigFile)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if showHelp {
+		flags.Usage()
+		return nil
+	}
+
+	if err := writeInitConfig(initConfigFile, force); err != nil {
+		return fmt.Errorf("init: %w", err)
+	}
+	fmt.Printf("Wrote %s; edit its schemas and run jsonschema-validator\n", initConfigFile)
+	return nil
+}
+
+// writeInitConfig writes the sample configuration to path, refusing to replace an
+// existing file unless force is set
+func writeInitConfig(path string, force bool) error {
+	content, err := initConfigYAML()
+	if err != nil {
+		return err
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flag, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%q already exists; use --force to overwrite it", path)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	return file.Close()
+}
+
+// initConfigYAML encodes initConfig through the config struct tags, so the sample
+// has every option the loader reads, with each key preceded by its comment
+func initConfigYAML() ([]byte, error) {
+	var root yaml.Node
+	if err := root.Encode(initConfig); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	commentKeys(&root, initComments)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "schemas" {
+			for _, entry := range root.Content[i+1].Content {
+				commentKeys(entry, initSchemaComments)
+				// A comment on the first key would follow the "- " of the entry
+				entry.HeadComment, entry.Content[0].HeadComment = entry.Content[0].HeadComment, ""
+			}
+		}
+	}
+
+	document := &yaml.Node{
+		Kind:        yaml.DocumentNode,
+		HeadComment: "jsonschema-validator configuration\nSee https://github.com/binlab/terraform-provider-jsonschema/tree/main/cmd/jsonschema-validator",
+		Content:     []*yaml.Node{&root},
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// commentKeys sets the head comment of each key of a mapping node from comments
+func commentKeys(mapping *yaml.Node, comments map[string]string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		if comment, ok := comments[key.Value]; ok {
+			key.HeadComment = comment
+		}
+	}
+}
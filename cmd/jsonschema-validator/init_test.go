@@ -0,0 +1,110 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"gopkg.in/yaml.v3"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
+)
+
+func TestWriteInitConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), initConfigFile)
+
+	if err := writeInitConfig(path, false); err != nil {
+		t.Fatalf("writeInitConfig() error = %v", err)
+	}
+
+	// The sample loads back as the configuration it was written from
+	cfg, err := config.NewLoader().LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+	if !reflect.DeepEqual(*cfg, initConfig) {
+		t.Errorf("loaded config = %+v, want %+v", *cfg, initConfig)
+	}
+
+	if err := os.WriteFile(path, []byte("schemas: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err = writeInitConfig(path, false)
+	if err == nil || !strings.Contains(err.Error(), "already exists; use --force to overwrite it") {
+		t.Fatalf("writeInitConfig() on an existing file error = %v, want already exists", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "schemas: []\n" {
+		t.Errorf("existing file was modified: %q", content)
+	}
+
+	if err := writeInitConfig(path, true); err != nil {
+		t.Fatalf("writeInitConfig(force) error = %v", err)
+	}
+	if content, _ := os.ReadFile(path); !strings.Contains(string(content), "schema_version: draft/2020-12") {
+		t.Errorf("forced write did not replace the file:\n%s", content)
+	}
+}
+
+func TestInitConfigYAML_EveryKeyCommented(t *testing.T) {
+	content, err := initConfigYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		t.Fatal(err)
+	}
+
+	// A config field added without a comment shows up here
+	root := document.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i].Value
+		if _, ok := initComments[key]; !ok {
+			t.Errorf("top-level key %q has no comment in initComments", key)
+		}
+		if key != "schemas" {
+			continue
+		}
+		for _, entry := range root.Content[i+1].Content {
+			for j := 0; j+1 < len(entry.Content); j += 2 {
+				if _, ok := initSchemaComments[entry.Content[j].Value]; !ok {
+					t.Errorf("schemas key %q has no comment in initSchemaComments", entry.Content[j].Value)
+				}
+			}
+		}
+	}
+	if !strings.Contains(string(content), "# Fail on remote $refs that are not covered by ref_overrides\nno_network: false\n") {
+		t.Errorf("comments missing from the sample:\n%s", content)
+	}
+}
+
+func TestInitConfigYAML_MatchesConfigSchema(t *testing.T) {
+	schemaData, err := validator.ParseJSON(config.Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("config.schema.json", schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("config.schema.json")
+	if err != nil {
+		t.Fatalf("compiling --print-config-schema output: %v", err)
+	}
+
+	content, err := initConfigYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	document, err := validator.ParseYAML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.Validate(document); err != nil {
+		t.Errorf("sample config does not match the config schema: %v", err)
+	}
+}
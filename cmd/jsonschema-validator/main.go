@@ -1,10 +1,16 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/spf13/pflag"
@@ -17,8 +23,21 @@ const (
 	ExitSuccess        = 0
 	ExitValidationFail = 1
 	ExitUsageError     = 2
+	ExitFileError      = 3 // --strict-files: a document is missing or could not be parsed
 )
 
+// stdinPath is the schema or document argument that reads it from standard input
+const stdinPath = "-"
+
+// stdinLabel names standard input in results and error messages
+const stdinLabel = "<stdin>"
+
+// stdin is read for a "-" document; tests replace it
+var stdin io.Reader = os.Stdin
+
+// errUnreadableDocuments is wrapped by validateSchema when a document could not be read or parsed
+var errUnreadableDocuments = errors.New("one or more documents are missing or could not be parsed")
+
 var version = "dev" // Set by goreleaser
 
 func main() {
@@ -29,6 +48,20 @@ func main() {
 }
 
 func run() error {
+	// Subcommands take their own flags
+	if len(os.Args) > 1 && os.Args[1] == "resolve" {
+		return runResolve(os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		return runInit(os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "canonicalize" {
+		return runCanonicalize(os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "test-examples" {
+		return runTestExamples(os.Args[2:])
+	}
+
 	// Define flags
 	var (
 		showVersion   bool
@@ -41,24 +74,67 @@ func run() error {
 		documents     []string
 		envPrefix     string
 		forceFiletype string
+		profile       string
+		forbidDupKeys bool
+		noNetwork     bool
+		quiet         bool
+		verbose       bool
+		strictFiles   bool
+		watch         bool
+		changedOnly   bool
+		baseRef       string
+		failFast      bool
+		timings       bool
+		checkSchemas  bool
+		explainConfig bool
+		printSchema   bool
+		noSearch      bool
+		maxErrors     int
+		jobs          int
+		fetchTimeout  string
+		output        string
 	)
 
 	pflag.BoolVarP(&showVersion, "version", "v", false, "Show version and exit")
 	pflag.BoolVarP(&showHelp, "help", "h", false, "Show help and exit")
 	pflag.StringVarP(&configFile, "config", "c", "", "Path to configuration file (.yaml, .toml, or .json)")
-	pflag.StringVarP(&schemaPath, "schema", "s", "", "Path to JSON Schema file (required unless in config)")
+	pflag.BoolVar(&noSearch, "no-config-search", false, "Only look for .jsonschema-validator.yaml in the current directory, not in its parents")
+	pflag.StringVarP(&schemaPath, "schema", "s", "", "Path to JSON Schema file (required unless in config; \"-\" reads it from stdin)")
 	pflag.StringVar(&schemaVersion, "schema-version", "", "JSON Schema version (draft/2020-12, draft/2019-09, draft-07, draft-06, draft-04)")
 	pflag.StringVarP(&errorTemplate, "error-template", "e", "", "Go template for error formatting")
 	pflag.StringArrayVarP(&refOverrides, "ref-override", "r", nil, "Override $ref URL with local file (format: url=path)")
-	pflag.StringArrayVarP(&documents, "document", "d", nil, "Document file(s) to validate (supports globs)")
+	pflag.StringArrayVarP(&documents, "document", "d", nil, "Document file(s) to validate (supports globs and http(s):// URLs; \"-\" reads one document from stdin)")
 	pflag.StringVar(&envPrefix, "env-prefix", "JSONSCHEMA_VALIDATOR_", "Environment variable prefix (must end with underscore)")
-	pflag.StringVar(&forceFiletype, "force-filetype", "", "Force file type for documents (json, json5, yaml, toml). Auto-detected from extension if not set")
+	pflag.StringVar(&forceFiletype, "force-filetype", "", "Force file type for documents (json, jsonc, json5, yaml, toml, jsonl, msgpack, cbor). Auto-detected from extension if not set")
+	pflag.BoolVar(&forbidDupKeys, "forbid-duplicate-keys", false, "Reject JSON/JSON5 documents that repeat an object key")
+	pflag.BoolVar(&noNetwork, "no-network", false, "Fail on remote (http/https) $refs and documents instead of fetching them; use --ref-override for local copies")
+	pflag.StringVar(&fetchTimeout, "fetch-timeout", "", "Timeout for each HTTP(S) request for a remote document or $ref, e.g. 10s (default 30s)")
+	pflag.BoolVarP(&quiet, "quiet", "q", false, "Only print failures and the final summary (text output)")
+	pflag.BoolVar(&verbose, "verbose", false, "Also print the schema version and draft used for each document (text output)")
+	pflag.BoolVar(&strictFiles, "strict-files", false, "Exit with code 3 when a document is missing or cannot be parsed (validation failures keep exit code 1)")
+	pflag.BoolVar(&timings, "timings", false, "Print each schema's compile time and each document's parse and validate time (text, json and ndjson output)")
+	pflag.BoolVar(&checkSchemas, "validate-schema", false, "Validate each schema against its draft's meta-schema before compiling it, reporting violations like document errors")
+	pflag.IntVar(&maxErrors, "max-errors", 0, "Show at most this many errors per document (lowest paths first), then \"... and N more\"; 0 shows all")
+	pflag.BoolVar(&explainConfig, "explain-config", false, "Print each effective configuration value with the source that set it (default, file, profile, env or flag) and exit")
+	pflag.BoolVar(&printSchema, "print-config-schema", false, "Print the JSON Schema of the configuration file, for editor completion and validation, and exit")
+	pflag.IntVarP(&jobs, "jobs", "j", 1, "Validate up to this many documents of a schema concurrently; results are still reported in document order")
+	pflag.BoolVar(&failFast, "fail-fast", false, "Stop at the first invalid document, skipping the remaining documents; the results so far are still reported")
+	pflag.BoolVar(&changedOnly, "changed-only", false, "Only validate documents changed since --base-ref (git diff --name-only base...HEAD); nothing changed is a success")
+	pflag.StringVar(&baseRef, "base-ref", defaultBaseRef, "Git ref that --changed-only compares HEAD against, e.g. the pull request's target branch")
+	pflag.BoolVar(&watch, "watch", false, "Keep running and re-validate when a schema, ref override or document changes (stop with Ctrl+C)")
+	pflag.StringVarP(&output, "output", "o", OutputText, "Output format: text, json, ndjson, sarif, junit")
+	pflag.StringVar(&output, "format", OutputText, "Alias for --output")
+	pflag.StringVar(&profile, "profile", "", "Configuration profile to apply from the \"profiles\" section (or set <env-prefix>PROFILE)")
 
 	pflag.Usage = func() {
 		fmt.Fprintf(os.Stderr, `jsonschema-validator - Validate JSON/JSON5/YAML/TOML documents against JSON Schema
 
 Usage:
   jsonschema-validator [flags] [documents...]
+  jsonschema-validator resolve --schema in.json [--out resolved.json] [--allow pattern...]
+  jsonschema-validator init [--force]
+  jsonschema-validator canonicalize [--write] [--to json] [--schema s.json] documents...
+  jsonschema-validator test-examples --schema s.json
 
 Flags:
 `)
@@ -74,21 +150,84 @@ Examples:
   # Validate a TOML document (auto-detected)
   jsonschema-validator -s schema.json config.toml
 
+  # Validate every line of a JSONL/NDJSON file as its own record
+  jsonschema-validator -s event.schema.json events.jsonl
+
   # Force file type override
   jsonschema-validator -s schema.json --force-filetype yaml data.txt
 
   # Validate multiple documents
   jsonschema-validator -s schema.json doc1.json doc2.yaml doc3.toml
 
+  # Validate a document piped on stdin ("-" must be the only document)
+  generate-config | jsonschema-validator -s schema.json --force-filetype yaml -
+
+  # Validate a document served by an internal service (format from Content-Type or the URL)
+  jsonschema-validator -s schema.json --fetch-timeout 10s https://config.internal/service.yaml
+
+  # Read the schema from stdin instead (only one of schema or document can be "-")
+  generate-schema | jsonschema-validator -s - config.json
+
   # Use glob patterns
   jsonschema-validator -s schema.json "configs/*.yaml"
 
   # Override remote $ref with local file
   jsonschema-validator -s schema.json -r https://example.com/schema.json=./local.json doc.json
 
+  # Refuse any remote $ref that is not overridden with a local file
+  jsonschema-validator -s schema.json --no-network -r https://example.com/schema.json=./local.json doc.json
+
+  # Only print failures and the "N valid, M invalid" summary
+  jsonschema-validator -s schema.json --quiet "configs/*.json"
+
+  # Validate 8 documents at a time (results are still printed in document order)
+  jsonschema-validator -s schema.json --jobs 8 "configs/*.json"
+
+  # In CI, only validate the documents a pull request changed
+  jsonschema-validator --changed-only --base-ref origin/main
+
+  # Stop at the first invalid document in a large batch
+  jsonschema-validator -s schema.json --fail-fast "configs/*.json"
+
+  # Exit 3 (instead of 1) when a document is missing or unparseable
+  jsonschema-validator -s schema.json --strict-files config.json missing.json
+
+  # Re-validate on every save while editing
+  jsonschema-validator -s schema.json --watch "configs/*.yaml"
+
+  # Find slow documents and schemas (add --output json for machine-readable timings)
+  jsonschema-validator -s schema.json --timings "configs/*.json"
+
+  # Write a commented .jsonschema-validator.yaml to start from
+  jsonschema-validator init
+
   # Use configuration file
   jsonschema-validator -c .jsonschema-validator.yaml
 
+  # Emit all results as a single JSON document for CI pipelines
+  jsonschema-validator -s schema.json --output json "configs/*.json"
+
+  # Produce a SARIF report for GitHub code scanning
+  jsonschema-validator -s schema.json --output sarif "configs/*.json" > results.sarif
+
+  # Write a JUnit XML report for CI test dashboards
+  jsonschema-validator -s schema.json --output junit "configs/*.json" > report.xml
+
+  # Stream one JSON result per line (NDJSON) for other tools
+  jsonschema-validator -s schema.json --output ndjson "configs/*.json" | jq .
+
+  # Inline all $refs into a self-contained schema
+  jsonschema-validator resolve --schema schema.json --out schema.resolved.json
+
+  # Apply a named profile from the configuration file
+  jsonschema-validator --profile prod
+
+  # Show where each configuration value comes from (file, profile, env or flag)
+  jsonschema-validator --explain-config
+
+  # Save the configuration file's JSON Schema for editor completion
+  jsonschema-validator --print-config-schema > .jsonschema-validator.schema.json
+
   # Configuration auto-discovery (checks in order):
   #   1. .jsonschema-validator.yaml (or .yml, .toml, .json)
   #   2. pyproject.toml [tool.jsonschema-validator]
@@ -111,6 +250,40 @@ For more information, see: https://github.com/binlab/terraform-provider-jsonsche
 		return nil
 	}
 
+	if printSchema {
+		_, err := os.Stdout.Write(config.Schema())
+		return err
+	}
+
+	if quiet && verbose {
+		return fmt.Errorf("--quiet and --verbose cannot be used together")
+	}
+
+	// --watch prints the results of every run, each with its own reporter
+	makeReporter := func() (reporter, error) {
+		rep, err := newReporter(output, os.Stdout, os.Stderr)
+		if err != nil {
+			return nil, err
+		}
+		// --quiet and --verbose only affect the human-readable output
+		switch r := rep.(type) {
+		case *textReporter:
+			r.quiet = quiet
+			r.verbose = verbose
+			r.strictFiles = strictFiles
+			r.timings = timings
+		case *jsonReporter:
+			r.timings = timings
+		case *ndjsonReporter:
+			r.timings = timings
+		}
+		return rep, nil
+	}
+	rep, err := makeReporter()
+	if err != nil {
+		return err
+	}
+
 	// Load configuration
 	loader := config.NewLoader()
 
@@ -123,9 +296,16 @@ For more information, see: https://github.com/binlab/terraform-provider-jsonsche
 		loader.SetEnvPrefix(envPrefix)
 	}
 
+	if profile != "" {
+		loader.SetProfile(profile)
+	}
+
+	if noSearch {
+		loader.DisableConfigSearch()
+	}
+
 	// Load from specific config file if provided
 	var cfg *config.Config
-	var err error
 
 	if configFile != "" {
 		cfg, err = loader.LoadFromFile(configFile)
@@ -167,6 +347,52 @@ For more information, see: https://github.com/binlab/terraform-provider-jsonsche
 			// Override first schema with command-line args
 			cfg.Schemas[0] = schemaConfig
 		}
+		loader.SetSource("schemas", "command-line schema and documents")
+	}
+
+	if forbidDupKeys {
+		cfg.ForbidDuplicateKeys = true
+		loader.SetSource("forbid_duplicate_keys", "flag --forbid-duplicate-keys")
+	}
+
+	if noNetwork {
+		cfg.NoNetwork = true
+		loader.SetSource("no_network", "flag --no-network")
+	}
+
+	if checkSchemas {
+		cfg.ValidateSchema = true
+		loader.SetSource("validate_schema", "flag --validate-schema")
+	}
+
+	if pflag.CommandLine.Changed("max-errors") {
+		cfg.MaxErrors = maxErrors
+		loader.SetSource("max_errors", "flag --max-errors")
+	}
+
+	if fetchTimeout != "" {
+		cfg.FetchTimeout = fetchTimeout
+		loader.SetSource("fetch_timeout", "flag --fetch-timeout")
+	}
+
+	if failFast {
+		cfg.FailFast = true
+		loader.SetSource("fail_fast", "flag --fail-fast")
+	}
+
+	if pflag.CommandLine.Changed("jobs") {
+		cfg.Jobs = jobs
+		loader.SetSource("jobs", "flag --jobs")
+	}
+
+	// Print the configuration instead of validating with it
+	if explainConfig {
+		explained, err := loader.Explain(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to explain configuration: %w", err)
+		}
+		fmt.Print(explained)
+		return nil
 	}
 
 	// Validate configuration
@@ -174,8 +400,31 @@ For more information, see: https://github.com/binlab/terraform-provider-jsonsche
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	// Expand globs in document paths
+	if err := checkStdin(cfg); err != nil {
+		return err
+	}
+
+	if watch {
+		if cfg.FailFast {
+			return fmt.Errorf("--fail-fast cannot be used with --watch")
+		}
+		if changedOnly {
+			return fmt.Errorf("--changed-only cannot be used with --watch")
+		}
+		exitCode, err := runWatch(cfg, forceFiletype, makeReporter, strictFiles)
+		if err != nil {
+			return err
+		}
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return nil
+	}
+
+	// Expand globs in document paths, remembering patterns that matched nothing
+	unmatchedGlobs := make([][]string, len(cfg.Schemas))
 	for i := range cfg.Schemas {
+		unmatchedGlobs[i] = cfg.Schemas[i].UnmatchedDocumentGlobs()
 		expanded, err := cfg.Schemas[i].ExpandDocumentGlobs()
 		if err != nil {
 			return fmt.Errorf("failed to expand glob patterns: %w", err)
@@ -183,15 +432,55 @@ For more information, see: https://github.com/binlab/terraform-provider-jsonsche
 		cfg.Schemas[i].Documents = expanded
 	}
 
+	// Keep only the documents changed since the base ref, or all of them outside git
+	if changedOnly {
+		changed, err := changedFiles(baseRef)
+		switch {
+		case errors.Is(err, errNotGitRepository):
+			fmt.Fprintf(os.Stderr, "Warning: --changed-only: %v; validating every document\n", err)
+		case err != nil:
+			return fmt.Errorf("--changed-only: %w", err)
+		default:
+			// Patterns that matched nothing have no changed documents either, and the
+			// filtered schemas no longer line up with unmatchedGlobs
+			unmatchedGlobs = nil
+			cfg.Schemas = filterChangedDocuments(cfg.Schemas, changed)
+		}
+	}
+
 	// Validate all schemas
 	hasErrors := false
-	for _, schemaConfig := range cfg.Schemas {
-		if err := validateSchema(schemaConfig, cfg, forceFiletype); err != nil {
+	hasFileErrors := false
+	for i, schemaConfig := range cfg.Schemas {
+		if r, ok := rep.(unmatchedGlobReporter); ok && unmatchedGlobs != nil {
+			for _, pattern := range unmatchedGlobs[i] {
+				if err := r.ReportUnmatchedGlob(schemaConfig.Path, pattern); err != nil {
+					return fmt.Errorf("failed to write results: %w", err)
+				}
+			}
+		}
+
+		if err := validateSchema(schemaConfig, cfg, forceFiletype, rep); err != nil {
 			fmt.Fprintf(os.Stderr, "%v\n", err)
 			hasErrors = true
+			if errors.Is(err, errUnreadableDocuments) {
+				hasFileErrors = true
+			}
+			// The results so far are still written by Finish
+			if cfg.FailFast {
+				break
+			}
 		}
 	}
 
+	if err := rep.Finish(); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+
+	if hasFileErrors && strictFiles {
+		os.Exit(ExitFileError)
+	}
+
 	if hasErrors {
 		os.Exit(ExitValidationFail)
 	}
@@ -199,72 +488,169 @@ For more information, see: https://github.com/binlab/terraform-provider-jsonsche
 	return nil
 }
 
-func validateSchema(schemaConfig config.SchemaConfig, globalConfig *config.Config, forceFiletype string) error {
-	// Read and parse schema (auto-detect format)
-	schemaData, err := validator.ParseFile(schemaConfig.Path, validator.FileTypeAuto)
+func validateSchema(schemaConfig config.SchemaConfig, globalConfig *config.Config, forceFiletype string, rep reporter) error {
+	loaded, err := loadSchema(schemaConfig, globalConfig, forceFiletype)
 	if err != nil {
-		return fmt.Errorf("failed to parse schema %q: %w", schemaConfig.Path, err)
+		return err
+	}
+	if schemaConfig.Path == stdinPath {
+		schemaConfig.Path = stdinLabel
+	}
+	return validateDocuments(schemaConfig.Documents, loaded, schemaConfig, globalConfig, forceFiletype, rep)
+}
+
+// loadedSchema is a compiled schema with what its document results report about it
+type loadedSchema struct {
+	schema *jsonschema.Schema
+	// version is the effective schema version ("" when taken from $schema)
+	version string
+	// compileTime covers parsing and compiling the schema and its ref overrides
+	compileTime time.Duration
+	// data is the parsed schema, compiled as url by compiler, which also compiles its
+	// subschemas on request (e.g. url + "#/properties/port")
+	data     interface{}
+	url      string
+	compiler *jsonschema.Compiler
+}
+
+// loadSchema parses and compiles the schema of schemaConfig with its ref overrides
+func loadSchema(schemaConfig config.SchemaConfig, globalConfig *config.Config, forceFiletype string) (*loadedSchema, error) {
+	start := time.Now()
+	// Read and parse schema (auto-detect format). A schema on stdin is labeled <stdin>
+	// and resolves relative $refs against the working directory.
+	var (
+		schemaData interface{}
+		err        error
+	)
+	label := schemaConfig.Path
+	if label == stdinPath {
+		label = stdinLabel
+		schemaData, err = parseStdinSchema(validator.FileType(schemaConfig.GetEffectiveForceFiletype(forceFiletype)))
+	} else {
+		schemaData, err = validator.ParseFile(schemaConfig.Path, validator.FileTypeAuto)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema %q: %w", label, err)
 	}
 
 	// Create compiler
 	compiler := jsonschema.NewCompiler()
-	compiler.UseLoader(jsonschema.SchemeURLLoader{
+	loader := jsonschema.SchemeURLLoader{
 		"file": validator.JSON5FileLoader{},
-	})
+	}
+	if globalConfig.NoNetwork {
+		loader["http"] = noNetworkLoader{}
+		loader["https"] = noNetworkLoader{}
+	} else {
+		fetcher := remoteFetcher(globalConfig)
+		loader["http"] = fetcher
+		loader["https"] = fetcher
+	}
+	compiler.UseLoader(loader)
 
 	// Set schema version
+	var defaultDraft *jsonschema.Draft
 	effectiveVersion := schemaConfig.GetEffectiveSchemaVersion(globalConfig.SchemaVersion)
 	if effectiveVersion != "" {
-		draft, err := getDraftForVersion(effectiveVersion)
+		defaultDraft, err = getDraftForVersion(effectiveVersion)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		compiler.DefaultDraft(defaultDraft)
+	}
+
+	// Report meta-schema violations with the error template rather than as a compile error
+	if globalConfig.ValidateSchema {
+		if err := validator.ValidateAgainstMetaSchema(schemaData, defaultDraft); err != nil {
+			effectiveTemplate, templateErr := schemaConfig.GetEffectiveErrorTemplate(globalConfig.ErrorTemplate)
+			if templateErr != nil {
+				return nil, templateErr
+			}
+			if effectiveTemplate == "" {
+				effectiveTemplate = "{{.FullMessage}}"
+			}
+			return nil, fmt.Errorf("schema %q is not valid against its meta-schema: %w", label, validator.FormatValidationError(err, label, label, effectiveTemplate))
 		}
-		compiler.DefaultDraft(draft)
 	}
 
 	// Merge and register ref overrides
 	mergedOverrides := config.MergeRefOverrides(
-		globalConfig.Schemas[0].RefOverrides, // Global overrides
-		schemaConfig.RefOverrides,            // Schema-specific overrides
+		globalConfig.RefOverrides, // Global overrides
+		schemaConfig.RefOverrides, // Schema-specific overrides
 	)
 
 	for remoteURL, localPath := range mergedOverrides {
 		// Parse ref override file (auto-detect format)
 		overrideData, err := validator.ParseFile(localPath, validator.FileTypeAuto)
 		if err != nil {
-			return fmt.Errorf("ref-override: failed to parse %q for URL %q: %w", localPath, remoteURL, err)
+			return nil, fmt.Errorf("ref-override: failed to parse %q for URL %q: %w", localPath, remoteURL, err)
 		}
 
 		if err := compiler.AddResource(remoteURL, overrideData); err != nil {
-			return fmt.Errorf("ref-override: failed to register %q -> %q: %w", remoteURL, localPath, err)
+			return nil, fmt.Errorf("ref-override: failed to register %q -> %q: %w", remoteURL, localPath, err)
 		}
 	}
 
 	// Add and compile schema
-	schemaAbsPath, err := filepath.Abs(schemaConfig.Path)
+	schemaAbsPath, err := filepath.Abs(label)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path for schema: %w", err)
+		return nil, fmt.Errorf("failed to get absolute path for schema: %w", err)
 	}
 	schemaURL := fmt.Sprintf("file://%s", schemaAbsPath)
 
 	if err := compiler.AddResource(schemaURL, schemaData); err != nil {
-		return fmt.Errorf("failed to add schema resource: %w", err)
+		return nil, fmt.Errorf("failed to add schema resource: %w", err)
 	}
 
 	compiledSchema, err := compiler.Compile(schemaURL)
 	if err != nil {
-		return fmt.Errorf("failed to compile schema: %w", err)
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
 	}
+	return &loadedSchema{
+		schema:      compiledSchema,
+		version:     effectiveVersion,
+		compileTime: time.Since(start),
+		data:        schemaData,
+		url:         schemaURL,
+		compiler:    compiler,
+	}, nil
+}
+
+// validateDocuments validates docPaths against the compiled schema of schemaConfig,
+// up to globalConfig.Jobs at a time, reporting each result in document order. The
+// error summarizes failures for the schema.
+func validateDocuments(docPaths []string, loaded *loadedSchema, schemaConfig config.SchemaConfig, globalConfig *config.Config, forceFiletype string, rep reporter) error {
+	stop := make(chan struct{})
+	defer close(stop)
+	pending := startValidation(docPaths, globalConfig.Jobs, stop, func(docPath string) documentResult {
+		result := validateDocument(docPath, loaded.schema, schemaConfig, globalConfig, forceFiletype)
+		result.schemaVersion = loaded.version
+		result.draft = draftName(loaded.schema.DraftVersion)
+		result.compileTime = loaded.compileTime
+		return result
+	})
 
-	// Validate each document
 	hasErrors := false
-	for _, docPath := range schemaConfig.Documents {
-		if err := validateDocument(docPath, compiledSchema, schemaConfig, globalConfig, forceFiletype); err != nil {
-			fmt.Fprintf(os.Stderr, "%v\n", err)
+	hasFileErrors := false
+	for i, docPath := range docPaths {
+		result := <-pending[i]
+		if !result.Valid {
 			hasErrors = true
 		}
+		if result.parseFailed {
+			hasFileErrors = true
+		}
+		if err := rep.Report(result); err != nil {
+			return fmt.Errorf("failed to write result for %q: %w", docPath, err)
+		}
+		if !result.Valid && globalConfig.FailFast {
+			break
+		}
 	}
 
+	if hasFileErrors {
+		return fmt.Errorf("validation failed for schema %q: %w", schemaConfig.Path, errUnreadableDocuments)
+	}
 	if hasErrors {
 		return fmt.Errorf("validation failed for schema %q", schemaConfig.Path)
 	}
@@ -272,7 +658,131 @@ func validateSchema(schemaConfig config.SchemaConfig, globalConfig *config.Confi
 	return nil
 }
 
-func validateDocument(docPath string, schema *jsonschema.Schema, schemaConfig config.SchemaConfig, globalConfig *config.Config, flagForceFiletype string) error {
+// startValidation validates docPaths with up to jobs workers (one when jobs is less than
+// 2) and returns a channel per document, in input order, that receives its result.
+// The compiled schema is only read, so the workers share it. Closing stop keeps the
+// documents not started yet from being validated; their channels receive nothing.
+func startValidation(docPaths []string, jobs int, stop <-chan struct{}, validate func(docPath string) documentResult) []chan documentResult {
+	pending := make([]chan documentResult, len(docPaths))
+	for i := range pending {
+		pending[i] = make(chan documentResult, 1)
+	}
+
+	next := make(chan int)
+	go func() {
+		defer close(next)
+		for i := range docPaths {
+			select {
+			case next <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	for worker := 0; worker < max(jobs, 1); worker++ {
+		go func() {
+			for i := range next {
+				pending[i] <- validate(docPaths[i])
+			}
+		}()
+	}
+	return pending
+}
+
+// parseStdinSchema reads the schema from standard input. Without a forced file type
+// the format is detected from the content, as for a "-" document.
+func parseStdinSchema(fileType validator.FileType) (interface{}, error) {
+	content, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+	if fileType == "" || fileType == validator.FileTypeAuto {
+		fileType = validator.DetectContentType(content)
+	}
+	return validator.ParseBytes(content, fileType, validator.ParseOptions{})
+}
+
+// checkStdin allows "-" (stdin) for only one schema or one document: standard input
+// can be read once, so it can't be repeated or combined with other documents
+func checkStdin(cfg *config.Config) error {
+	stdinSchemas := 0
+	for _, schemaConfig := range cfg.Schemas {
+		if schemaConfig.Path == stdinPath {
+			stdinSchemas++
+		}
+	}
+
+	stdinCount, total := 0, 0
+	for _, schemaConfig := range cfg.Schemas {
+		for _, document := range schemaConfig.Documents {
+			total++
+			if document == stdinPath {
+				stdinCount++
+			}
+		}
+	}
+	if stdinSchemas > 0 && stdinCount > 0 {
+		return fmt.Errorf("the schema and a document cannot both be read from stdin (%q); pass one of them as a file", stdinPath)
+	}
+	if stdinSchemas > 1 {
+		return fmt.Errorf("%q (stdin) can be the schema of only one schema configuration", stdinPath)
+	}
+	if stdinCount > 0 && total > 1 {
+		return fmt.Errorf("%q (stdin) must be the only document; it cannot be repeated or combined with other documents", stdinPath)
+	}
+	return nil
+}
+
+// remoteFetcher returns the fetcher for remote documents and $refs, each request bounded
+// by fetch_timeout
+func remoteFetcher(globalConfig *config.Config) *validator.RemoteFetcher {
+	timeout, _ := globalConfig.GetFetchTimeout() // Checked by Config.Validate
+	return &validator.RemoteFetcher{Timeout: timeout}
+}
+
+// fetchDocument downloads the document at rawURL, returning its content and, unless
+// fileType is forced, its format: from the Content-Type, else the extension of the URL
+// path, else the content
+func fetchDocument(rawURL string, fileType validator.FileType, globalConfig *config.Config) ([]byte, validator.FileType, error) {
+	if globalConfig.NoNetwork {
+		return nil, "", fmt.Errorf("remote document requires network access, which is disabled (no_network)")
+	}
+	content, contentType, err := remoteFetcher(globalConfig).FetchWithContentType(rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if fileType == validator.FileTypeAuto {
+		fileType = validator.FileTypeFromContentType(contentType)
+	}
+	if fileType == "" {
+		if u, err := url.Parse(rawURL); err == nil && path.Ext(u.Path) != "" {
+			fileType = validator.DetectFileType(u.Path)
+		} else {
+			fileType = validator.DetectContentType(content)
+		}
+	}
+	return content, fileType, nil
+}
+
+// noNetworkLoader rejects remote $refs under --no-network. Overridden URLs are
+// registered as resources and never reach a loader.
+type noNetworkLoader struct{}
+
+func (noNetworkLoader) Load(url string) (interface{}, error) {
+	return nil, fmt.Errorf("remote $ref '%s' requires network access; use --ref-override", url)
+}
+
+func validateDocument(docPath string, schema *jsonschema.Schema, schemaConfig config.SchemaConfig, globalConfig *config.Config, flagForceFiletype string) (result documentResult) {
+	start := time.Now()
+	label := docPath
+	if docPath == stdinPath {
+		label = stdinLabel
+	}
+	result = documentResult{Document: label, Schema: schemaConfig.Path}
+	defer func() { result.elapsed = time.Since(start) }()
+
 	// Get effective force_filetype: command-line flag > config file > auto-detect
 	effectiveForceFiletype := schemaConfig.GetEffectiveForceFiletype(flagForceFiletype)
 
@@ -282,24 +792,134 @@ func validateDocument(docPath string, schema *jsonschema.Schema, schemaConfig co
 		fileType = validator.FileTypeAuto
 	}
 
-	docData, err := validator.ParseFile(docPath, fileType)
+	// Standard input has no extension: the format comes from --force-filetype or the
+	// content. A remote document is fetched, its format also read from the Content-Type.
+	var content []byte
+	switch {
+	case docPath == stdinPath:
+		var err error
+		content, err = io.ReadAll(stdin)
+		if err != nil {
+			return parseFailure(result, fmt.Errorf("failed to parse document %q: reading stdin: %w", label, err))
+		}
+		if fileType == validator.FileTypeAuto {
+			fileType = validator.DetectContentType(content)
+		}
+	case validator.IsRemoteURL(docPath):
+		var err error
+		content, fileType, err = fetchDocument(docPath, fileType, globalConfig)
+		if err != nil {
+			return parseFailure(result, fmt.Errorf("failed to parse document %q: %w", label, err))
+		}
+	}
+
+	if fileType == validator.FileTypeAuto {
+		fileType = validator.DetectFileType(docPath)
+	}
+	// JSONL lines are parsed while validating, so their time counts as validation
+	if fileType == validator.FileTypeJSONL {
+		defer func() { result.validateTime = time.Since(start) }()
+		if content != nil {
+			return validateJSONLDocument(result, bytes.NewReader(content), schema, schemaConfig, globalConfig)
+		}
+		file, err := os.Open(docPath)
+		if err != nil {
+			return parseFailure(result, fmt.Errorf("failed to parse document %q: %w", label, err))
+		}
+		defer file.Close()
+		return validateJSONLDocument(result, file, schema, schemaConfig, globalConfig)
+	}
+
+	parseOptions := validator.ParseOptions{
+		ForbidDuplicateKeys: globalConfig.ForbidDuplicateKeys,
+	}
+	var (
+		docData interface{}
+		err     error
+	)
+	if content != nil {
+		docData, err = validator.ParseBytes(content, fileType, parseOptions)
+	} else {
+		docData, err = validator.ParseFileWithOptions(docPath, fileType, parseOptions)
+	}
+	result.parseTime = time.Since(start)
 	if err != nil {
-		return fmt.Errorf("failed to parse document %q: %w", docPath, err)
+		return parseFailure(result, fmt.Errorf("failed to parse document %q: %w", label, err))
 	}
 
 	// Validate
-	if err := schema.Validate(docData); err != nil {
-		effectiveTemplate := schemaConfig.GetEffectiveErrorTemplate(globalConfig.ErrorTemplate)
+	validateStart := time.Now()
+	err = schema.Validate(docData)
+	result.validateTime = time.Since(validateStart)
+	if err != nil {
+		result.Errors = validator.ExtractValidationErrors(err, docData)
+		effectiveTemplate, templateErr := schemaConfig.GetEffectiveErrorTemplate(globalConfig.ErrorTemplate)
+		if templateErr != nil {
+			result.err = fmt.Errorf("document %q: %w", label, templateErr)
+			return result
+		}
 		if effectiveTemplate == "" {
 			effectiveTemplate = "{{.FullMessage}}"
 		}
 
-		formattedErr := validator.FormatValidationError(err, schemaConfig.Path, docPath, effectiveTemplate)
-		return fmt.Errorf("document %q: %w", docPath, formattedErr)
+		formattedErr := validator.FormatDocumentValidationError(err, schemaConfig.Path, label, docData, effectiveTemplate, validator.ErrorOptions{MaxErrors: globalConfig.MaxErrors})
+		result.err = fmt.Errorf("document %q: %w", label, formattedErr)
+		return result
 	}
 
-	fmt.Printf("✓ %s: valid\n", docPath)
-	return nil
+	result.Valid = true
+	return result
+}
+
+// parseFailure marks result as a document that could not be read or parsed
+func parseFailure(result documentResult, err error) documentResult {
+	result.err = err
+	result.parseFailed = true
+	result.Errors = validator.ExtractValidationErrors(err, nil)
+	return result
+}
+
+// validateJSONLDocument validates every line of a JSONL document as its own record.
+// Malformed lines are reported alongside validation errors instead of aborting the file.
+func validateJSONLDocument(result documentResult, r io.Reader, schema *jsonschema.Schema, schemaConfig config.SchemaConfig, globalConfig *config.Config) documentResult {
+	errorOptions := validator.ErrorOptions{MaxErrors: globalConfig.MaxErrors}
+	_, details, err := validator.ValidateJSONLWithOptions(r, schema, errorOptions)
+	if err != nil {
+		return parseFailure(result, fmt.Errorf("failed to parse document %q: %w", result.Document, err))
+	}
+
+	if len(details) > 0 {
+		result.Errors = details
+		effectiveTemplate, templateErr := schemaConfig.GetEffectiveErrorTemplate(globalConfig.ErrorTemplate)
+		if templateErr != nil {
+			result.err = fmt.Errorf("document %q: %w", result.Document, templateErr)
+			return result
+		}
+		if effectiveTemplate == "" {
+			effectiveTemplate = "{{.FullMessage}}"
+		}
+
+		formattedErr := validator.FormatJSONLValidationError(details, schemaConfig.Path, result.Document, effectiveTemplate, errorOptions)
+		result.err = fmt.Errorf("document %q: %w", result.Document, formattedErr)
+		return result
+	}
+
+	result.Valid = true
+	return result
+}
+
+// draftName returns the --schema-version spelling of a compiled schema's draft
+func draftName(draftVersion int) string {
+	switch draftVersion {
+	case 2020:
+		return "draft/2020-12"
+	case 2019:
+		return "draft/2019-09"
+	case 0:
+		return ""
+	default:
+		return fmt.Sprintf("draft-%02d", draftVersion)
+	}
 }
 
 func getDraftForVersion(version string) (*jsonschema.Draft, error) {
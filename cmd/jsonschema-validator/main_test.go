@@ -0,0 +1,676 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+)
+
+func TestValidateSchema_NoNetwork(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	remoteURL := "https://schemas.example.com/name.json"
+	schemaPath := writeFile("schema.json", `{
+		"type": "object",
+		"properties": {"name": {"$ref": "`+remoteURL+`"}}
+	}`)
+	documentPath := writeFile("document.json", `{"name": "ok"}`)
+	overridePath := writeFile("name.json", `{"type": "string"}`)
+
+	tests := []struct {
+		name          string
+		refOverrides  map[string]string
+		errorContains string
+	}{
+		{
+			name:          "un-overridden remote ref",
+			errorContains: "remote $ref '" + remoteURL + "' requires network access; use --ref-override",
+		},
+		{
+			name:         "overridden remote ref",
+			refOverrides: map[string]string{remoteURL: overridePath},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaConfig := config.SchemaConfig{
+				Path:         schemaPath,
+				Documents:    []string{documentPath},
+				RefOverrides: tt.refOverrides,
+			}
+			globalConfig := &config.Config{
+				Schemas:   []config.SchemaConfig{schemaConfig},
+				NoNetwork: true,
+			}
+			rep, err := newReporter(OutputText, io.Discard, io.Discard)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateSchema(schemaConfig, globalConfig, "", rep)
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Fatalf("validateSchema() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestValidateSchema_MetaSchema(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "properties": {"port": {"type": "int"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		validateSchema bool
+		errorTemplate  string
+		errorContains  string
+	}{
+		{
+			name:          "compile error without the check",
+			errorContains: "failed to compile schema",
+		},
+		{
+			name:           "meta-schema violation",
+			validateSchema: true,
+			errorContains:  "is not valid against its meta-schema: jsonschema validation failed with 'https://json-schema.org/draft/2020-12/schema#'\n- at '/properties/port/type': 'type' must be one of 'array', 'boolean', 'integer', 'null', 'number', 'object', 'string' or an array",
+		},
+		{
+			name:           "error template",
+			validateSchema: true,
+			errorTemplate:  "{{range .Errors}}{{.DocumentPath}} [{{.SchemaPath}}]{{end}}",
+			errorContains:  "/properties/port/type [https://json-schema.org/draft/2020-12/meta/validation#/properties/type/anyOf]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaConfig := config.SchemaConfig{Path: schemaPath, ErrorTemplate: tt.errorTemplate}
+			globalConfig := &config.Config{
+				Schemas:        []config.SchemaConfig{schemaConfig},
+				ValidateSchema: tt.validateSchema,
+			}
+			_, err := loadSchema(schemaConfig, globalConfig, "")
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestValidateSchema_UnreadableDocuments(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	invalidPath := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidPath, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	brokenPath := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(brokenPath, []byte(`{"name": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		documents      []string
+		wantUnreadable bool
+	}{
+		{name: "validation failure only", documents: []string{invalidPath}},
+		{name: "missing document", documents: []string{invalidPath, filepath.Join(dir, "missing.json")}, wantUnreadable: true},
+		{name: "unparseable document", documents: []string{brokenPath}, wantUnreadable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaConfig := config.SchemaConfig{Path: schemaPath, Documents: tt.documents}
+			globalConfig := &config.Config{Schemas: []config.SchemaConfig{schemaConfig}}
+			rep, err := newReporter(OutputText, io.Discard, io.Discard)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateSchema(schemaConfig, globalConfig, "", rep)
+			if err == nil {
+				t.Fatal("expected validation error")
+			}
+			if got := errors.Is(err, errUnreadableDocuments); got != tt.wantUnreadable {
+				t.Errorf("errors.Is(err, errUnreadableDocuments) = %v, want %v (err: %v)", got, tt.wantUnreadable, err)
+			}
+		})
+	}
+}
+
+func TestValidateSchema_FailFast(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	schemaPath := writeFile("schema.json", `{"type": "object", "required": ["name"]}`)
+	documents := []string{
+		writeFile("valid.json", `{"name": "a"}`),
+		writeFile("first.json", `{}`),
+		writeFile("second.json", `{}`),
+	}
+
+	for _, failFast := range []bool{false, true} {
+		schemaConfig := config.SchemaConfig{Path: schemaPath, Documents: documents}
+		globalConfig := &config.Config{Schemas: []config.SchemaConfig{schemaConfig}, FailFast: failFast}
+		var stdout bytes.Buffer
+		rep, err := newReporter(OutputJSON, &stdout, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := validateSchema(schemaConfig, globalConfig, "", rep); err == nil {
+			t.Errorf("failFast=%v: validateSchema() = nil, want a validation error", failFast)
+		}
+		if err := rep.Finish(); err != nil {
+			t.Fatal(err)
+		}
+
+		var results []struct{ Document string }
+		if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+			t.Fatalf("failFast=%v: invalid JSON output: %v\n%s", failFast, err, stdout.String())
+		}
+		var reported []string
+		for _, result := range results {
+			reported = append(reported, filepath.Base(result.Document))
+		}
+		want := []string{"valid.json", "first.json", "second.json"}
+		if failFast {
+			want = want[:2]
+		}
+		if !slices.Equal(reported, want) {
+			t.Errorf("failFast=%v: reported %v, want %v", failFast, reported, want)
+		}
+	}
+}
+
+func TestValidateSchema_JobsKeepDocumentOrder(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var documents, want []string
+	for i := range 50 {
+		name := fmt.Sprintf("doc%02d.json", i)
+		content := `{"name": "a"}`
+		if i%3 == 0 {
+			content = `{}`
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		documents = append(documents, filepath.Join(dir, name))
+		want = append(want, name)
+	}
+
+	for _, jobs := range []int{0, 1, 8} {
+		schemaConfig := config.SchemaConfig{Path: schemaPath, Documents: documents}
+		globalConfig := &config.Config{Schemas: []config.SchemaConfig{schemaConfig}, Jobs: jobs}
+		var stdout bytes.Buffer
+		rep, err := newReporter(OutputJSON, &stdout, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := validateSchema(schemaConfig, globalConfig, "", rep); err == nil {
+			t.Errorf("jobs=%d: validateSchema() = nil, want a validation error", jobs)
+		}
+		if err := rep.Finish(); err != nil {
+			t.Fatal(err)
+		}
+
+		var results []struct {
+			Document string
+			Valid    bool
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+			t.Fatalf("jobs=%d: invalid JSON output: %v\n%s", jobs, err, stdout.String())
+		}
+		var reported []string
+		for i, result := range results {
+			reported = append(reported, filepath.Base(result.Document))
+			if result.Valid != (i%3 != 0) {
+				t.Errorf("jobs=%d: %s valid = %v", jobs, reported[i], result.Valid)
+			}
+		}
+		if !slices.Equal(reported, want) {
+			t.Errorf("jobs=%d: reported %v, want %v", jobs, reported, want)
+		}
+	}
+}
+
+func BenchmarkValidateDocuments(b *testing.B) {
+	dir := b.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	schema := `{"type": "object", "properties": {"items": {"type": "array", "items": {"type": "object", "required": ["id", "name"], "properties": {"id": {"type": "integer"}, "name": {"type": "string", "pattern": "^[a-z]+$"}}}}}}`
+	if err := os.WriteFile(schemaPath, []byte(schema), 0644); err != nil {
+		b.Fatal(err)
+	}
+	var items []string
+	for i := range 200 {
+		items = append(items, fmt.Sprintf(`{"id": %d, "name": "item"}`, i))
+	}
+	document := `{"items": [` + strings.Join(items, ", ") + `]}`
+	var documents []string
+	for i := range 64 {
+		path := filepath.Join(dir, fmt.Sprintf("doc%02d.json", i))
+		if err := os.WriteFile(path, []byte(document), 0644); err != nil {
+			b.Fatal(err)
+		}
+		documents = append(documents, path)
+	}
+
+	schemaConfig := config.SchemaConfig{Path: schemaPath, Documents: documents}
+	for _, jobs := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("jobs=%d", jobs), func(b *testing.B) {
+			globalConfig := &config.Config{Schemas: []config.SchemaConfig{schemaConfig}, Jobs: jobs}
+			loaded, err := loadSchema(schemaConfig, globalConfig, "")
+			if err != nil {
+				b.Fatal(err)
+			}
+			for b.Loop() {
+				rep, err := newReporter(OutputJSON, io.Discard, io.Discard)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := validateDocuments(documents, loaded, schemaConfig, globalConfig, "", rep); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSchema_RefOverridesPerSchema(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	remoteURL := "https://schemas.example.com/name.json"
+	schemaContent := `{"type": "object", "properties": {"name": {"$ref": "` + remoteURL + `"}}}`
+	firstSchema := writeFile("first.schema.json", schemaContent)
+	secondSchema := writeFile("second.schema.json", schemaContent)
+	documentPath := writeFile("document.json", `{"name": "ok"}`)
+	overrides := map[string]string{remoteURL: writeFile("name.json", `{"type": "string"}`)}
+
+	tests := []struct {
+		name          string
+		global        map[string]string
+		first, second map[string]string
+		wantFirstErr  bool
+		wantSecondErr bool
+	}{
+		{
+			name:         "only the second schema defines overrides",
+			second:       overrides,
+			wantFirstErr: true,
+		},
+		{
+			name:          "overrides of the first schema do not leak into the second",
+			first:         overrides,
+			wantSecondErr: true,
+		},
+		{
+			name:   "top-level overrides apply to every schema",
+			global: overrides,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			globalConfig := &config.Config{
+				RefOverrides: tt.global,
+				NoNetwork:    true,
+				Schemas: []config.SchemaConfig{
+					{Path: firstSchema, Documents: []string{documentPath}, RefOverrides: tt.first},
+					{Path: secondSchema, Documents: []string{documentPath}, RefOverrides: tt.second},
+				},
+			}
+
+			for i, wantErr := range []bool{tt.wantFirstErr, tt.wantSecondErr} {
+				rep, err := newReporter(OutputText, io.Discard, io.Discard)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = validateSchema(globalConfig.Schemas[i], globalConfig, "", rep)
+				if wantErr {
+					if err == nil || !strings.Contains(err.Error(), "requires network access") {
+						t.Errorf("schemas[%d]: expected network access error, got %v", i, err)
+					}
+				} else if err != nil {
+					t.Errorf("schemas[%d]: validateSchema() error = %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDocument_Stdin(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		input         string
+		forceFiletype string
+		wantValid     bool
+		errorContains string
+	}{
+		{name: "JSON detected from content", input: `{"name": "a"}`, wantValid: true},
+		{name: "YAML detected from content", input: "name: a\n", wantValid: true},
+		{name: "forced TOML", input: `name = "a"`, forceFiletype: "toml", wantValid: true},
+		{name: "invalid document", input: `{}`, errorContains: `document "<stdin>"`},
+		{name: "forced type mismatch", input: "name: a\n", forceFiletype: "json", errorContains: `failed to parse document "<stdin>"`},
+		{name: "JSONL records", input: "{\"name\": \"a\"}\n{}\n", forceFiletype: "jsonl", errorContains: "line 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdin = strings.NewReader(tt.input)
+			t.Cleanup(func() { stdin = os.Stdin })
+
+			schemaConfig := config.SchemaConfig{Path: schemaPath, Documents: []string{stdinPath}}
+			globalConfig := &config.Config{Schemas: []config.SchemaConfig{schemaConfig}}
+			compiled, err := jsonschema.NewCompiler().Compile(schemaPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := validateDocument(stdinPath, compiled, schemaConfig, globalConfig, tt.forceFiletype)
+			if result.Document != stdinLabel {
+				t.Errorf("Document = %q, want %q", result.Document, stdinLabel)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (err: %v)", result.Valid, tt.wantValid, result.err)
+			}
+			if tt.errorContains != "" && (result.err == nil || !strings.Contains(result.err.Error(), tt.errorContains)) {
+				t.Errorf("expected error containing %q, got %v", tt.errorContains, result.err)
+			}
+		})
+	}
+}
+
+func TestValidateDocument_RemoteURL(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/config":
+			w.Header().Set("Content-Type", "application/yaml")
+			_, _ = w.Write([]byte("name: a\n"))
+		case "/config.toml":
+			_, _ = w.Write([]byte(`name = "a"`))
+		case "/invalid":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"name": "a"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		path          string
+		forceFiletype string
+		globalConfig  config.Config
+		wantValid     bool
+		errorContains string
+	}{
+		{name: "format from Content-Type", path: "/config", wantValid: true},
+		{name: "format from the URL path", path: "/config.toml", wantValid: true},
+		{name: "forced type wins over Content-Type", path: "/config", forceFiletype: "json", errorContains: "failed to parse document"},
+		{name: "invalid document labeled by its URL", path: "/invalid", errorContains: `document "` + server.URL + `/invalid": jsonschema validation failed`},
+		{name: "HTTP error", path: "/missing", errorContains: "unexpected status 404"},
+		{name: "timeout", path: "/slow", globalConfig: config.Config{FetchTimeout: "50ms"}, errorContains: "Client.Timeout exceeded"},
+		{name: "no network", path: "/config", globalConfig: config.Config{NoNetwork: true}, errorContains: "remote document requires network access, which is disabled (no_network)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docURL := server.URL + tt.path
+			schemaConfig := config.SchemaConfig{Path: schemaPath, Documents: []string{docURL}}
+			compiled, err := jsonschema.NewCompiler().Compile(schemaPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := validateDocument(docURL, compiled, schemaConfig, &tt.globalConfig, tt.forceFiletype)
+			if result.Document != docURL {
+				t.Errorf("Document = %q, want %q", result.Document, docURL)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (err: %v)", result.Valid, tt.wantValid, result.err)
+			}
+			if tt.errorContains != "" && (result.err == nil || !strings.Contains(result.err.Error(), tt.errorContains)) {
+				t.Errorf("expected error containing %q, got %v", tt.errorContains, result.err)
+			}
+		})
+	}
+}
+
+func TestCheckStdin(t *testing.T) {
+	tests := []struct {
+		name    string
+		schemas []config.SchemaConfig
+		wantErr bool
+	}{
+		{name: "stdin only", schemas: []config.SchemaConfig{{Documents: []string{"-"}}}},
+		{name: "files only", schemas: []config.SchemaConfig{{Documents: []string{"a.json", "*.yaml"}}}},
+		{name: "stdin twice", schemas: []config.SchemaConfig{{Documents: []string{"-", "-"}}}, wantErr: true},
+		{name: "stdin and a glob", schemas: []config.SchemaConfig{{Documents: []string{"-", "configs/*.json"}}}, wantErr: true},
+		{
+			name:    "stdin for two schemas",
+			schemas: []config.SchemaConfig{{Documents: []string{"-"}}, {Documents: []string{"b.json"}}},
+			wantErr: true,
+		},
+		{name: "stdin schema", schemas: []config.SchemaConfig{{Path: "-", Documents: []string{"a.json", "b.json"}}}},
+		{name: "stdin schema and document", schemas: []config.SchemaConfig{{Path: "-", Documents: []string{"-"}}}, wantErr: true},
+		{
+			name:    "stdin schema twice",
+			schemas: []config.SchemaConfig{{Path: "-", Documents: []string{"a.json"}}, {Path: "-", Documents: []string{"b.json"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStdin(&config.Config{Schemas: tt.schemas})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkStdin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSchema_Stdin(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	validPath := writeFile("valid.json", `{"name": "a"}`)
+	invalidPath := writeFile("invalid.json", `{}`)
+
+	tests := []struct {
+		name          string
+		input         string
+		forceFiletype string
+		document      string
+		errorContains string
+	}{
+		{name: "JSON detected from content", input: `{"type": "object", "required": ["name"]}`, document: validPath},
+		{name: "YAML detected from content", input: "type: object\nrequired: [name]\n", document: validPath},
+		{name: "invalid document", input: `{"type": "object", "required": ["name"]}`, document: invalidPath, errorContains: `validation failed for schema "<stdin>"`},
+		{name: "unparseable schema", input: "{", document: validPath, errorContains: `failed to parse schema "<stdin>"`},
+		{name: "forced type mismatch", input: "type: object\n", forceFiletype: "json", document: validPath, errorContains: `failed to parse schema "<stdin>"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdin = strings.NewReader(tt.input)
+			t.Cleanup(func() { stdin = os.Stdin })
+
+			schemaConfig := config.SchemaConfig{Path: stdinPath, Documents: []string{tt.document}}
+			globalConfig := &config.Config{Schemas: []config.SchemaConfig{schemaConfig}}
+			rep, err := newReporter(OutputText, io.Discard, io.Discard)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateSchema(schemaConfig, globalConfig, tt.forceFiletype, rep)
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Fatalf("validateSchema() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestValidateDocument_NamedErrorTemplate(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "properties": {"port": {"type": "integer"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	documentPath := filepath.Join(dir, "document.json")
+	if err := os.WriteFile(documentPath, []byte(`{"port": "80"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	compiled, err := jsonschema.NewCompiler().Compile(schemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		schemaTemplate string
+		globalTemplate string
+		errorContains  string
+	}{
+		{
+			name:           "schema uses @detailed",
+			schemaTemplate: "@detailed",
+			globalTemplate: "{{.FullMessage}}",
+			errorContains:  "1 validation error(s) found:\n1. at '/port': got string, want integer at /port\n",
+		},
+		{
+			name:           "global @with_path",
+			globalTemplate: "@with_path",
+			errorContains:  "/port: at '/port': got string, want integer\n",
+		},
+		{
+			name:           "unknown name",
+			schemaTemplate: "@detail",
+			errorContains:  `unknown error template "@detail"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaConfig := config.SchemaConfig{Path: schemaPath, Documents: []string{documentPath}, ErrorTemplate: tt.schemaTemplate}
+			globalConfig := &config.Config{Schemas: []config.SchemaConfig{schemaConfig}, ErrorTemplate: tt.globalTemplate}
+
+			result := validateDocument(documentPath, compiled, schemaConfig, globalConfig, "")
+			if result.Valid {
+				t.Fatal("Valid = true, want false")
+			}
+			if result.err == nil || !strings.Contains(result.err.Error(), tt.errorContains) {
+				t.Errorf("expected error containing %q, got %v", tt.errorContains, result.err)
+			}
+		})
+	}
+}
+
+func TestValidateDocument_MaxErrors(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"additionalProperties": {"type": "integer"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	documentPath := filepath.Join(dir, "document.json")
+	if err := os.WriteFile(documentPath, []byte(`{"e": "5", "d": "4", "c": "3", "b": "2", "a": "1"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	compiled, err := jsonschema.NewCompiler().Compile(schemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaConfig := config.SchemaConfig{Path: schemaPath, Documents: []string{documentPath}}
+	globalConfig := &config.Config{Schemas: []config.SchemaConfig{schemaConfig}, ErrorTemplate: "@with_path", MaxErrors: 2}
+
+	result := validateDocument(documentPath, compiled, schemaConfig, globalConfig, "")
+	want := "/a: at '/a': got string, want integer\n/b: at '/b': got string, want integer\n... and 3 more"
+	if result.err == nil || !strings.HasSuffix(result.err.Error(), want) {
+		t.Errorf("expected error ending with %q, got %v", want, result.err)
+	}
+	// Reports still carry every error
+	if len(result.Errors) != 5 {
+		t.Errorf("len(Errors) = %d, want 5", len(result.Errors))
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
+)
+
+// Output formats accepted by --output
+const (
+	OutputText   = "text"
+	OutputJSON   = "json"
+	OutputNDJSON = "ndjson"
+	OutputSARIF  = "sarif"
+	OutputJUnit  = "junit"
+)
+
+// documentResult is the outcome of validating a single document
+type documentResult struct {
+	Document string                            `json:"document"`
+	Schema   string                            `json:"schema"`
+	Valid    bool                              `json:"valid"`
+	Errors   []validator.ValidationErrorDetail `json:"errors"`
+	// Timings is only set by the json and ndjson output with --timings
+	Timings *documentTimings `json:"timings,omitempty"`
+
+	// err is the formatted (templated) error used by the text output
+	err error
+	// parseFailed is set when the document could not be read or parsed (never validated)
+	parseFailed bool
+	// elapsed is the wall-clock time spent parsing and validating the document
+	elapsed time.Duration
+	// parseTime and validateTime split elapsed (a JSONL document only has validateTime);
+	// compileTime is the compile time of the schema, shared by all of its documents
+	parseTime, validateTime, compileTime time.Duration
+	// schemaVersion is the configured schema version ("" when taken from $schema)
+	// and draft the draft the schema was compiled with, both shown by --verbose
+	schemaVersion string
+	draft         string
+}
+
+// documentTimings is the --timings breakdown of a result in milliseconds
+type documentTimings struct {
+	CompileMS  float64 `json:"compile_ms"`
+	ParseMS    float64 `json:"parse_ms"`
+	ValidateMS float64 `json:"validate_ms"`
+}
+
+func (r documentResult) timings() *documentTimings {
+	return &documentTimings{
+		CompileMS:  milliseconds(r.compileTime),
+		ParseMS:    milliseconds(r.parseTime),
+		ValidateMS: milliseconds(r.validateTime),
+	}
+}
+
+// milliseconds converts d to fractional milliseconds, rounded to the microsecond
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Round(time.Microsecond)) / float64(time.Millisecond)
+}
+
+// reporter receives document results as they complete
+type reporter interface {
+	// Report is called once per document, in validation order
+	Report(result documentResult) error
+	// Finish is called after all documents have been reported
+	Finish() error
+}
+
+// unmatchedGlobReporter is implemented by reporters that record glob patterns
+// which matched no documents (other reporters skip them silently)
+type unmatchedGlobReporter interface {
+	ReportUnmatchedGlob(schema, pattern string) error
+}
+
+// newReporter creates the reporter for the given output format
+func newReporter(format string, stdout, stderr io.Writer) (reporter, error) {
+	switch format {
+	case "", OutputText:
+		return &textReporter{stdout: stdout, stderr: stderr}, nil
+	case OutputJSON:
+		return &jsonReporter{w: stdout}, nil
+	case OutputNDJSON:
+		return &ndjsonReporter{w: bufio.NewWriter(stdout)}, nil
+	case OutputSARIF:
+		return &sarifReporter{w: stdout, log: validator.NewSARIFLog(version)}, nil
+	case OutputJUnit:
+		return &junitReporter{w: stdout, suites: validator.NewJUnitTestSuites()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q (supported: %s, %s, %s, %s, %s)", format, OutputText, OutputJSON, OutputNDJSON, OutputSARIF, OutputJUnit)
+	}
+}
+
+// textReporter prints human-readable results: successes to stdout, failures to stderr,
+// followed by a summary line on stdout
+type textReporter struct {
+	stdout io.Writer
+	stderr io.Writer
+	// quiet suppresses the per-document success lines
+	quiet bool
+	// verbose adds the schema version and draft used for every document
+	verbose bool
+	// strictFiles counts missing or unparseable documents separately in the summary
+	strictFiles bool
+	// timings prints each schema's compile time and each document's parse and validate time
+	timings bool
+	// compiled records the schemas whose compile time was printed
+	compiled map[string]bool
+
+	valid, invalid, unreadable int
+}
+
+func (r *textReporter) Report(result documentResult) error {
+	w := r.stderr
+	if result.Valid {
+		r.valid++
+		if r.quiet {
+			return nil
+		}
+		w = r.stdout
+		if _, err := fmt.Fprintf(w, "✓ %s: valid\n", result.Document); err != nil {
+			return err
+		}
+	} else {
+		if r.strictFiles && result.parseFailed {
+			r.unreadable++
+		} else {
+			r.invalid++
+		}
+		if _, err := fmt.Fprintf(w, "%v\n", result.err); err != nil {
+			return err
+		}
+	}
+
+	if r.timings {
+		if !r.compiled[result.Schema] {
+			if r.compiled == nil {
+				r.compiled = make(map[string]bool)
+			}
+			r.compiled[result.Schema] = true
+			if _, err := fmt.Fprintf(w, "  schema %s compiled in %s\n", result.Schema, result.compileTime.Round(time.Microsecond)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "  parse: %s, validate: %s\n", result.parseTime.Round(time.Microsecond), result.validateTime.Round(time.Microsecond)); err != nil {
+			return err
+		}
+	}
+
+	if r.verbose && result.draft != "" {
+		schemaVersion := result.schemaVersion
+		if schemaVersion == "" {
+			schemaVersion = "auto ($schema)"
+		}
+		if _, err := fmt.Fprintf(w, "  schema version: %s, draft: %s\n", schemaVersion, result.draft); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *textReporter) Finish() error {
+	if r.strictFiles {
+		_, err := fmt.Fprintf(r.stdout, "%d valid, %d invalid, %d unreadable\n", r.valid, r.invalid, r.unreadable)
+		return err
+	}
+	_, err := fmt.Fprintf(r.stdout, "%d valid, %d invalid\n", r.valid, r.invalid)
+	return err
+}
+
+// ndjsonReporter streams one JSON object per line, flushing after every document
+// so consumers can process results while validation is still running
+type ndjsonReporter struct {
+	w *bufio.Writer
+	// timings adds the timings object to every result
+	timings bool
+}
+
+func (r *ndjsonReporter) Report(result documentResult) error {
+	if result.Errors == nil {
+		result.Errors = []validator.ValidationErrorDetail{}
+	}
+	if r.timings {
+		result.Timings = result.timings()
+	}
+
+	line, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("encoding result for %q: %w", result.Document, err)
+	}
+
+	if _, err := r.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return r.w.Flush()
+}
+
+func (r *ndjsonReporter) Finish() error {
+	return r.w.Flush()
+}
+
+// jsonReporter collects all results and writes them as a single JSON array on Finish
+type jsonReporter struct {
+	w       io.Writer
+	results []documentResult
+	// timings adds the timings object to every result
+	timings bool
+}
+
+func (r *jsonReporter) Report(result documentResult) error {
+	if result.Errors == nil {
+		result.Errors = []validator.ValidationErrorDetail{}
+	}
+	if r.timings {
+		result.Timings = result.timings()
+	}
+	r.results = append(r.results, result)
+	return nil
+}
+
+func (r *jsonReporter) Finish() error {
+	results := r.results
+	if results == nil {
+		results = []documentResult{}
+	}
+
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// sarifReporter builds a SARIF 2.1.0 report (e.g. for GitHub code scanning) written on Finish
+type sarifReporter struct {
+	w   io.Writer
+	log *validator.SARIFLog
+}
+
+func (r *sarifReporter) Report(result documentResult) error {
+	r.log.AddDocument(result.Schema, result.Document, result.Errors)
+	return nil
+}
+
+func (r *sarifReporter) Finish() error {
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.log)
+}
+
+// junitReporter builds a JUnit XML report (one testsuite per schema) written on Finish
+type junitReporter struct {
+	w      io.Writer
+	suites *validator.JUnitTestSuites
+}
+
+func (r *junitReporter) Report(result documentResult) error {
+	switch {
+	case result.Valid:
+		r.suites.AddPass(result.Schema, result.Document, result.elapsed)
+	case result.parseFailed:
+		r.suites.AddError(result.Schema, result.Document, result.elapsed, "ParseError", result.err.Error())
+	default:
+		r.suites.AddFailure(result.Schema, result.Document, result.elapsed, len(result.Errors), result.err.Error())
+	}
+	return nil
+}
+
+func (r *junitReporter) ReportUnmatchedGlob(schema, pattern string) error {
+	r.suites.AddError(schema, pattern, 0, "NoMatches", fmt.Sprintf("glob pattern %q matched no documents", pattern))
+	return nil
+}
+
+func (r *junitReporter) Finish() error {
+	out, err := r.suites.Marshal()
+	if err != nil {
+		return err
+	}
+	_, err = r.w.Write(out)
+	return err
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runMainArgsEnv holds the arguments, one per line, of a test binary run by runMain
+const runMainArgsEnv = "JSONSCHEMA_VALIDATOR_TEST_ARGS"
+
+// TestRunMainHelper runs main() in a test binary started by runMain
+func TestRunMainHelper(t *testing.T) {
+	args, ok := os.LookupEnv(runMainArgsEnv)
+	if !ok {
+		t.Skip("only run by runMain")
+	}
+	os.Args = append([]string{"jsonschema-validator"}, strings.Split(args, "\n")...)
+	main()
+	os.Exit(ExitSuccess)
+}
+
+// runMain runs the CLI with args in a separate process, since it exits with os.Exit,
+// and returns its standard output and exit code. It runs in an empty directory and
+// home directory, so no configuration file is picked up.
+func runMain(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRunMainHelper$")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "HOME="+dir, runMainArgsEnv+"="+strings.Join(args, "\n"))
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		t.Fatalf("running the CLI: %v", err)
+	}
+	return stdout.String(), cmd.ProcessState.ExitCode()
+}
+
+// writeReportFixtures writes a schema with a valid document and a document with two
+// errors, returning the CLI arguments that validate both
+func writeReportFixtures(t *testing.T) []string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"schema.json": `{"type": "object", "properties": {"name": {"type": "string"}, "port": {"type": "integer"}}}`,
+		"valid.json":  `{"name": "web", "port": 80}`,
+		"bad.json":    `{"name": 1, "port": "80"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return []string{
+		"--no-config-search",
+		"--schema", filepath.Join(dir, "schema.json"),
+		"--document", filepath.Join(dir, "valid.json"),
+		"--document", filepath.Join(dir, "bad.json"),
+	}
+}
+
+func TestTextReporter_QuietAndVerbose(t *testing.T) {
+	results := []documentResult{
+		{Document: "ok.json", Valid: true, schemaVersion: "draft-07", draft: "draft-07"},
+		{Document: "bad.json", err: errors.New(`document "bad.json": missing property 'a'`), draft: "draft/2020-12"},
+		{Document: "missing.json", err: errors.New(`failed to parse document "missing.json"`), parseFailed: true},
+	}
+
+	tests := []struct {
+		name        string
+		quiet       bool
+		verbose     bool
+		strictFiles bool
+		wantStdout  string
+		wantStderr  string
+	}{
+		{
+			name:       "default",
+			wantStdout: "✓ ok.json: valid\n1 valid, 2 invalid\n",
+			wantStderr: "document \"bad.json\": missing property 'a'\nfailed to parse document \"missing.json\"\n",
+		},
+		{
+			name:       "quiet",
+			quiet:      true,
+			wantStdout: "1 valid, 2 invalid\n",
+			wantStderr: "document \"bad.json\": missing property 'a'\nfailed to parse document \"missing.json\"\n",
+		},
+		{
+			name:       "verbose",
+			verbose:    true,
+			wantStdout: "✓ ok.json: valid\n  schema version: draft-07, draft: draft-07\n1 valid, 2 invalid\n",
+			wantStderr: "document \"bad.json\": missing property 'a'\n  schema version: auto ($schema), draft: draft/2020-12\nfailed to parse document \"missing.json\"\n",
+		},
+		{
+			name:        "strict files",
+			strictFiles: true,
+			wantStdout:  "✓ ok.json: valid\n1 valid, 1 invalid, 1 unreadable\n",
+			wantStderr:  "document \"bad.json\": missing property 'a'\nfailed to parse document \"missing.json\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			rep := &textReporter{stdout: &stdout, stderr: &stderr, quiet: tt.quiet, verbose: tt.verbose, strictFiles: tt.strictFiles}
+			for _, result := range results {
+				if err := rep.Report(result); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := rep.Finish(); err != nil {
+				t.Fatal(err)
+			}
+
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestReporters_Timings(t *testing.T) {
+	results := []documentResult{
+		{Document: "a.json", Schema: "s.json", Valid: true, compileTime: 12 * time.Millisecond, parseTime: 1500 * time.Microsecond, validateTime: 250 * time.Microsecond},
+		{Document: "b.json", Schema: "s.json", Valid: true, compileTime: 12 * time.Millisecond, parseTime: 2 * time.Millisecond, validateTime: 3 * time.Millisecond},
+		{Document: "c.json", Schema: "t.json", Valid: true, compileTime: 4 * time.Millisecond, parseTime: time.Millisecond, validateTime: time.Millisecond},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var stdout bytes.Buffer
+		rep := &textReporter{stdout: &stdout, stderr: &stdout, timings: true}
+		for _, result := range results {
+			if err := rep.Report(result); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want := "✓ a.json: valid\n  schema s.json compiled in 12ms\n  parse: 1.5ms, validate: 250µs\n" +
+			"✓ b.json: valid\n  parse: 2ms, validate: 3ms\n" +
+			"✓ c.json: valid\n  schema t.json compiled in 4ms\n  parse: 1ms, validate: 1ms\n"
+		if stdout.String() != want {
+			t.Errorf("output = %q, want %q", stdout.String(), want)
+		}
+	})
+
+	for _, format := range []string{OutputJSON, OutputNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			for _, timings := range []bool{false, true} {
+				var out bytes.Buffer
+				rep, err := newReporter(format, &out, &out)
+				if err != nil {
+					t.Fatal(err)
+				}
+				switch r := rep.(type) {
+				case *jsonReporter:
+					r.timings = timings
+				case *ndjsonReporter:
+					r.timings = timings
+				}
+				if err := rep.Report(results[0]); err != nil {
+					t.Fatal(err)
+				}
+				if err := rep.Finish(); err != nil {
+					t.Fatal(err)
+				}
+
+				const want = `"timings":{"compile_ms":12,"parse_ms":1.5,"validate_ms":0.25}`
+				var compact bytes.Buffer
+				if err := json.Compact(&compact, out.Bytes()); err != nil {
+					t.Fatal(err)
+				}
+				if got := strings.Contains(compact.String(), want); got != timings {
+					t.Errorf("timings=%v: output %s", timings, compact.String())
+				}
+			}
+		})
+	}
+}
+
+func TestNDJSONReporter_Output(t *testing.T) {
+	stdout, code := runMain(t, append(writeReportFixtures(t), "--output", OutputNDJSON)...)
+	if code != ExitValidationFail {
+		t.Errorf("exit code = %d, want %d", code, ExitValidationFail)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("output has %d lines, want one per document:\n%s", len(lines), stdout)
+	}
+	var results []documentResult
+	for _, line := range lines {
+		var result documentResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Fatalf("line %q is not a JSON object: %v", line, err)
+		}
+		results = append(results, result)
+	}
+	if !results[0].Valid || len(results[0].Errors) != 0 {
+		t.Errorf("first line = %+v, want the valid document without errors", results[0])
+	}
+	// The errors of a document stay on its line
+	if results[1].Valid || len(results[1].Errors) != 2 {
+		t.Errorf("second line = %+v, want the invalid document with its 2 errors", results[1])
+	}
+}
+
+func TestJSONReporter_Output(t *testing.T) {
+	stdout, code := runMain(t, append(writeReportFixtures(t), "--output", OutputJSON)...)
+	if code != ExitValidationFail {
+		t.Errorf("exit code = %d, want %d", code, ExitValidationFail)
+	}
+
+	// One array for the whole run, not one value per document
+	decoder := json.NewDecoder(strings.NewReader(stdout))
+	var results []documentResult
+	if err := decoder.Decode(&results); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, stdout)
+	}
+	if decoder.More() {
+		t.Errorf("output has more than one JSON value:\n%s", stdout)
+	}
+	if len(results) != 2 {
+		t.Fatalf("array has %d results, want one per document", len(results))
+	}
+	if !results[0].Valid || results[1].Valid || len(results[1].Errors) != 2 {
+		t.Errorf("results = %+v, want the valid document then the invalid one with its 2 errors", results)
+	}
+}
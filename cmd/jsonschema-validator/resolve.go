@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
+)
+
+// runResolve implements "jsonschema-validator resolve": it inlines every $ref of a schema
+// and writes the self-contained result as deterministic JSON
+func runResolve(args []string) error {
+	flags := pflag.NewFlagSet("resolve", pflag.ContinueOnError)
+
+	var (
+		schemaPath string
+		outPath    string
+		allow      []string
+		showHelp   bool
+	)
+
+	flags.StringVarP(&schemaPath, "schema", "s", "", "Path to JSON Schema file to resolve (required)")
+	flags.StringVarP(&outPath, "out", "o", "-", "Output file for the resolved schema (\"-\" for stdout)")
+	flags.StringArrayVar(&allow, "allow", nil, "Glob pattern of files or URLs a $ref may point to (repeatable; default: any local file)")
+	flags.BoolVarP(&showHelp, "help", "h", false, "Show help and exit")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, `jsonschema-validator resolve - Inline all $refs into a self-contained schema
+
+Usage:
+  jsonschema-validator resolve --schema in.json [--out resolved.json] [--allow pattern...]
+
+Flags:
+`)
+		flags.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Examples:
+  # Write a fully-dereferenced schema
+  jsonschema-validator resolve --schema schemas/main.json --out schemas/main.resolved.json
+
+  # Only allow references inside the schemas directory
+  jsonschema-validator resolve --schema schemas/main.json --allow "schemas/*.json"
+`)
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if showHelp {
+		flags.Usage()
+		return nil
+	}
+
+	if schemaPath == "" {
+		return fmt.Errorf("resolve: --schema is required")
+	}
+
+	resolver := validator.NewRefResolver(allow)
+	resolver.Fetcher = &validator.RemoteFetcher{}
+
+	resolved, err := resolver.ResolveRefs(schemaPath)
+	if err != nil {
+		return fmt.Errorf("resolve: %w", err)
+	}
+
+	out, err := validator.MarshalDeterministic(resolved)
+	if err != nil {
+		return fmt.Errorf("resolve: failed to encode schema: %w", err)
+	}
+	out = append(out, '\n')
+
+	if outPath == "" || outPath == "-" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+
+	if err := os.WriteFile(outPath, out, 0o644); err != nil {
+		return fmt.Errorf("resolve: failed to write %q: %w", outPath, err)
+	}
+
+	return nil
+}
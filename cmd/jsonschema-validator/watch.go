@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
+)
+
+// watchDebounce is how long --watch waits after the last file event before
+// validating, so the burst of writes and renames of one save triggers one run
+const watchDebounce = 200 * time.Millisecond
+
+// runWatch validates every schema once, then re-validates on changes until interrupted.
+// A changed schema or ref override recompiles the schema and re-validates all of its
+// documents; a changed document is re-validated alone. The returned exit code reflects
+// the latest result of every document when watching stops.
+func runWatch(cfg *config.Config, forceFiletype string, newReporter func() (reporter, error), strictFiles bool) (int, error) {
+	for _, schemaConfig := range cfg.Schemas {
+		if schemaConfig.Path == stdinPath || slices.Contains(schemaConfig.Documents, stdinPath) {
+			return 0, fmt.Errorf("--watch cannot read from stdin (%q)", stdinPath)
+		}
+		if slices.ContainsFunc(schemaConfig.Documents, validator.IsRemoteURL) {
+			return 0, fmt.Errorf("--watch cannot watch remote documents")
+		}
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return 0, fmt.Errorf("failed to start watching: %w", err)
+	}
+	defer fsWatcher.Close()
+
+	state := newWatchState(cfg, forceFiletype, newReporter, os.Stderr)
+	if err := state.validate(nil); err != nil {
+		return 0, err
+	}
+	state.watchDirs(fsWatcher)
+	fmt.Fprintln(os.Stderr, "Watching for changes (press Ctrl+C to stop)...")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	changed := make(map[string]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			return state.exitCode(strictFiles), nil
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return state.exitCode(strictFiles), nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			changed[absPath(event.Name)] = true
+			debounce.Reset(watchDebounce)
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return state.exitCode(strictFiles), nil
+			}
+			fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+		case <-debounce.C:
+			if err := state.validate(changed); err != nil {
+				return 0, err
+			}
+			state.watchDirs(fsWatcher)
+			changed = make(map[string]bool)
+		}
+	}
+}
+
+// watchState keeps the compiled schemas and the latest document results between runs
+type watchState struct {
+	cfg           *config.Config
+	forceFiletype string
+	newReporter   func() (reporter, error)
+	log           io.Writer
+
+	loaded    []*loadedSchema // nil when the schema failed to compile
+	schemaErr []error
+	// results holds the latest result of each document, per schema, by path
+	results []map[string]documentResult
+	watched map[string]bool
+}
+
+func newWatchState(cfg *config.Config, forceFiletype string, newReporter func() (reporter, error), log io.Writer) *watchState {
+	return &watchState{
+		cfg:           cfg,
+		forceFiletype: forceFiletype,
+		newReporter:   newReporter,
+		log:           log,
+		loaded:        make([]*loadedSchema, len(cfg.Schemas)),
+		schemaErr:     make([]error, len(cfg.Schemas)),
+		results:       make([]map[string]documentResult, len(cfg.Schemas)),
+		watched:       make(map[string]bool),
+	}
+}
+
+// watchRun is the work of one run for one schema
+type watchRun struct {
+	recompile bool
+	documents []string
+}
+
+// validate re-validates what the changed absolute paths affect; nil validates everything.
+// Nothing is printed when no schema or document was affected.
+func (s *watchState) validate(changed map[string]bool) error {
+	runs := make([]watchRun, len(s.cfg.Schemas))
+	names := make(map[string]bool)
+	for i, schemaConfig := range s.cfg.Schemas {
+		// Globs are expanded on every run so new matching files are picked up
+		documents, err := schemaConfig.ExpandDocumentGlobs()
+		if err != nil {
+			return fmt.Errorf("failed to expand glob patterns: %w", err)
+		}
+
+		// Forget documents that no longer match
+		current := make(map[string]documentResult, len(documents))
+		for _, document := range documents {
+			if result, ok := s.results[i][document]; ok {
+				current[document] = result
+			}
+		}
+		s.results[i] = current
+
+		run := &runs[i]
+		run.recompile = changed == nil
+		for _, path := range s.schemaFiles(schemaConfig) {
+			if changed[absPath(path)] {
+				run.recompile = true
+				names[path] = true
+			}
+		}
+		for _, document := range documents {
+			if changed[absPath(document)] {
+				names[document] = true
+			}
+			if run.recompile || (changed[absPath(document)] && s.loaded[i] != nil) {
+				run.documents = append(run.documents, document)
+			}
+		}
+	}
+	if changed != nil && len(names) == 0 {
+		return nil
+	}
+
+	if changed != nil {
+		fmt.Fprintf(s.log, "\nChanged: %s\n", strings.Join(sortedKeys(names), ", "))
+	}
+	rep, err := s.newReporter()
+	if err != nil {
+		return err
+	}
+	for i, schemaConfig := range s.cfg.Schemas {
+		run := runs[i]
+		if run.recompile {
+			s.loaded[i], s.schemaErr[i] = loadSchema(schemaConfig, s.cfg, s.forceFiletype)
+			if s.schemaErr[i] != nil {
+				fmt.Fprintf(s.log, "%v\n", s.schemaErr[i])
+				continue
+			}
+		}
+		if len(run.documents) == 0 {
+			continue
+		}
+
+		recorder := &recordingReporter{reporter: rep, results: s.results[i]}
+		if err := validateDocuments(run.documents, s.loaded[i], schemaConfig, s.cfg, s.forceFiletype, recorder); err != nil {
+			fmt.Fprintf(s.log, "%v\n", err)
+		}
+	}
+
+	if err := rep.Finish(); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	return nil
+}
+
+// schemaFiles returns the files whose changes recompile the schema: the schema itself
+// and its ref overrides. Other files reached through $ref are not watched.
+func (s *watchState) schemaFiles(schemaConfig config.SchemaConfig) []string {
+	files := []string{schemaConfig.Path}
+	for _, localPath := range config.MergeRefOverrides(s.cfg.RefOverrides, schemaConfig.RefOverrides) {
+		files = append(files, localPath)
+	}
+	return files
+}
+
+// watchDirs watches the directories of every schema, ref override and document, and
+// the directory of each glob pattern. Directories are watched rather than files so
+// editors that save by renaming a new file over the old one keep being noticed.
+func (s *watchState) watchDirs(fsWatcher *fsnotify.Watcher) {
+	var dirs []string
+	for i, schemaConfig := range s.cfg.Schemas {
+		for _, path := range s.schemaFiles(schemaConfig) {
+			dirs = append(dirs, filepath.Dir(absPath(path)))
+		}
+		for _, pattern := range schemaConfig.Documents {
+			if dir := filepath.Dir(pattern); !strings.ContainsAny(dir, "*?[") {
+				dirs = append(dirs, absPath(dir))
+			}
+		}
+		for document := range s.results[i] {
+			dirs = append(dirs, filepath.Dir(absPath(document)))
+		}
+	}
+
+	for _, dir := range dirs {
+		if s.watched[dir] {
+			continue
+		}
+		if err := fsWatcher.Add(dir); err != nil {
+			// Missing directories are retried after the next run
+			continue
+		}
+		s.watched[dir] = true
+	}
+}
+
+// exitCode is the exit code for the latest result of every schema and document
+func (s *watchState) exitCode(strictFiles bool) int {
+	hasErrors, hasFileErrors := false, false
+	for i := range s.cfg.Schemas {
+		if s.schemaErr[i] != nil {
+			hasErrors = true
+		}
+		for _, result := range s.results[i] {
+			if !result.Valid {
+				hasErrors = true
+			}
+			if result.parseFailed {
+				hasFileErrors = true
+			}
+		}
+	}
+
+	switch {
+	case hasFileErrors && strictFiles:
+		return ExitFileError
+	case hasErrors:
+		return ExitValidationFail
+	default:
+		return 0
+	}
+}
+
+// recordingReporter passes results on while keeping the latest one per document
+type recordingReporter struct {
+	reporter
+	results map[string]documentResult
+}
+
+func (r *recordingReporter) Report(result documentResult) error {
+	r.results[result.Document] = result
+	return r.reporter.Report(result)
+}
+
+// absPath returns path made absolute, or path itself if that fails
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// sortedKeys returns the keys of set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
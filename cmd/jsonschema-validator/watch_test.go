@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/binlab/terraform-provider-jsonschema/pkg/config"
+)
+
+func TestWatchState_Validate(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	schemaPath := writeFile("schema.json", `{"type": "object", "required": ["name"]}`)
+	firstPath := writeFile("first.json", `{"name": "a"}`)
+	secondPath := writeFile("second.json", `{"name": "b"}`)
+
+	cfg := &config.Config{Schemas: []config.SchemaConfig{{
+		Path:      schemaPath,
+		Documents: []string{firstPath, secondPath, filepath.Join(dir, "new-*.json")},
+	}}}
+
+	var reported []string
+	makeReporter := func() (reporter, error) {
+		reported = nil
+		return &collectingReporter{documents: &reported}, nil
+	}
+	var log bytes.Buffer
+	state := newWatchState(cfg, "", makeReporter, &log)
+
+	run := func(changed ...string) []string {
+		t.Helper()
+		var set map[string]bool
+		if changed != nil {
+			set = make(map[string]bool)
+			for _, path := range changed {
+				set[path] = true
+			}
+		}
+		reported = nil
+		if err := state.validate(set); err != nil {
+			t.Fatalf("validate() error = %v", err)
+		}
+		return reported
+	}
+
+	if got := run(); strings.Join(got, ",") != firstPath+","+secondPath {
+		t.Fatalf("initial run reported %v", got)
+	}
+	if code := state.exitCode(false); code != 0 {
+		t.Errorf("exitCode() = %d, want 0", code)
+	}
+
+	// A changed document is re-validated alone
+	writeFile("second.json", `{}`)
+	if got := run(secondPath); strings.Join(got, ",") != secondPath {
+		t.Errorf("document change reported %v, want only %s", got, secondPath)
+	}
+	if code := state.exitCode(false); code != ExitValidationFail {
+		t.Errorf("exitCode() = %d, want %d", code, ExitValidationFail)
+	}
+
+	// Unrelated files are ignored
+	if got := run(filepath.Join(dir, "notes.txt")); got != nil {
+		t.Errorf("unrelated change reported %v", got)
+	}
+
+	// A new file matching a glob is picked up
+	newPath := writeFile("new-third.json", `{"name": "c"}`)
+	if got := run(newPath); strings.Join(got, ",") != newPath {
+		t.Errorf("new file reported %v, want only %s", got, newPath)
+	}
+
+	// A changed schema is recompiled and every document re-validated
+	writeFile("schema.json", `{"type": "object"}`)
+	if got := run(schemaPath); len(got) != 3 {
+		t.Errorf("schema change reported %v, want all 3 documents", got)
+	}
+	if code := state.exitCode(false); code != 0 {
+		t.Errorf("exitCode() = %d, want 0 after the schema accepts every document", code)
+	}
+
+	// A broken schema fails until it is fixed; document changes wait for it
+	writeFile("schema.json", `{"type": `)
+	run(schemaPath)
+	if !strings.Contains(log.String(), "failed to parse schema") {
+		t.Errorf("expected schema error in log, got %q", log.String())
+	}
+	if code := state.exitCode(false); code != ExitValidationFail {
+		t.Errorf("exitCode() = %d, want %d with a broken schema", code, ExitValidationFail)
+	}
+	if got := run(firstPath); got != nil {
+		t.Errorf("document change with a broken schema reported %v", got)
+	}
+
+	// A removed document counts as unreadable
+	writeFile("schema.json", `{"type": "object"}`)
+	run(schemaPath)
+	if err := os.Remove(firstPath); err != nil {
+		t.Fatal(err)
+	}
+	run(firstPath)
+	if code := state.exitCode(true); code != ExitFileError {
+		t.Errorf("exitCode(strictFiles) = %d, want %d", code, ExitFileError)
+	}
+}
+
+func TestRunWatch_Stdin(t *testing.T) {
+	for _, schemaConfig := range []config.SchemaConfig{
+		{Path: stdinPath, Documents: []string{"a.json"}},
+		{Path: "schema.json", Documents: []string{stdinPath}},
+	} {
+		cfg := &config.Config{Schemas: []config.SchemaConfig{schemaConfig}}
+		makeReporter := func() (reporter, error) { return newReporter(OutputText, io.Discard, io.Discard) }
+		if _, err := runWatch(cfg, "", makeReporter, false); err == nil || !strings.Contains(err.Error(), "--watch cannot read from stdin") {
+			t.Errorf("runWatch(%v) error = %v", schemaConfig, err)
+		}
+	}
+}
+
+// collectingReporter records the documents reported in one run
+type collectingReporter struct {
+	documents *[]string
+}
+
+func (r *collectingReporter) Report(result documentResult) error {
+	*r.documents = append(*r.documents, result.Document)
+	return nil
+}
+
+func (r *collectingReporter) Finish() error {
+	return nil
+}
@@ -2,8 +2,11 @@ package provider
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
 )
 
 // ProviderConfig holds the provider-level configuration
@@ -19,6 +22,33 @@ type ProviderConfig struct {
 
 	// DefaultDraft is the default draft to use
 	DefaultDraft *jsonschema.Draft
+
+	// StrictFormat enables "format" assertion for every data source
+	StrictFormat bool
+
+	// CustomFormats are registered with every compiler before compilation
+	CustomFormats []*jsonschema.Format
+
+	// SchemaCacheDir is where remote schemas are cached (caching is disabled when empty)
+	SchemaCacheDir string
+
+	// Offline rejects every remote schema and $ref instead of fetching it
+	Offline bool
+
+	// NormalizeNumbers makes equal numbers encode identically in valid_json and the ID,
+	// whichever format and notation the document wrote them in
+	NormalizeNumbers bool
+
+	// ValidateSchema checks every schema against its draft's meta-schema before compiling
+	// it, so violations are reported like document errors rather than as a compile error
+	ValidateSchema bool
+
+	// RefOverrides maps remote $ref URLs to local files for every data source;
+	// a data source's own ref_overrides take precedence
+	RefOverrides map[string]string
+
+	// RedactValues keeps document values out of the validation errors of every data source
+	RedactValues bool
 }
 
 // NewProviderConfig creates a new provider configuration with defaults
@@ -28,6 +58,11 @@ func NewProviderConfig(schemaVersion, errorTemplate string, detailedErrors bool)
 		errorTemplate = "{{.FullMessage}}"
 	}
 
+	errorTemplate, err := validator.ResolveErrorTemplate(errorTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("error_message_template: %w", err)
+	}
+
 	config := &ProviderConfig{
 		DefaultSchemaVersion: schemaVersion,
 		DefaultErrorTemplate: errorTemplate,
@@ -47,9 +82,63 @@ func NewProviderConfig(schemaVersion, errorTemplate string, detailedErrors bool)
 	return config, nil
 }
 
+// SetCustomFormats builds regex-backed formats from a format name -> regex map.
+// Invalid regexes are reported here so they surface at provider-configure time.
+func (c *ProviderConfig) SetCustomFormats(patterns map[string]interface{}) error {
+	names := make([]string, 0, len(patterns))
+	for name := range patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	c.CustomFormats = nil
+	for _, name := range names {
+		pattern, _ := patterns[name].(string)
+		format, err := validator.NewRegexFormat(name, pattern)
+		if err != nil {
+			return fmt.Errorf("custom_formats: %w", err)
+		}
+		c.CustomFormats = append(c.CustomFormats, format)
+	}
+
+	return nil
+}
+
+// DraftVersionName returns the schema_version spelling of a compiled schema's
+// DraftVersion (e.g. 2020 -> "draft/2020-12"), or "" for an unknown draft
+func DraftVersionName(draftVersion int) string {
+	switch draftVersion {
+	case 4:
+		return "draft-04"
+	case 6:
+		return "draft-06"
+	case 7:
+		return "draft-07"
+	case 2019:
+		return "draft/2019-09"
+	case 2020:
+		return "draft/2020-12"
+	default:
+		return ""
+	}
+}
+
+// SchemaVersionDraft3 is the schema_version of legacy draft-03 schemas, which are
+// converted to draft-04 before compiling
+const SchemaVersionDraft3 = "draft-03"
+
+// isDraft3 reports whether a schema_version selects the draft-03 conversion
+func isDraft3(version string) bool {
+	return version == SchemaVersionDraft3 || version == validator.Draft3SchemaURL
+}
+
 // GetDraftForVersion returns the appropriate draft for a given schema version string
 func GetDraftForVersion(version string) (*jsonschema.Draft, error) {
 	switch version {
+	case SchemaVersionDraft3, validator.Draft3SchemaURL:
+		// The library has no draft-03; such schemas are converted by ConvertDraft3 and
+		// compiled as draft-04
+		return jsonschema.Draft4, nil
 	case "draft-04", "http://json-schema.org/draft-04/schema#":
 		return jsonschema.Draft4, nil
 	case "draft-06", "http://json-schema.org/draft-06/schema#":
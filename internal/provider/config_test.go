@@ -56,6 +56,19 @@ func TestNewProviderConfig(t *testing.T) {
 			errorTemplate: "",
 			expectError:   false,
 		},
+		{
+			name:          "named template reference",
+			schemaVersion: "",
+			errorTemplate: "@detailed",
+			expectError:   false,
+		},
+		{
+			name:          "unknown named template",
+			schemaVersion: "",
+			errorTemplate: "@nope",
+			expectError:   true,
+			errorContains: `error_message_template: unknown error template "@nope"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -101,6 +114,7 @@ func TestGetDraftForVersion(t *testing.T) {
 		version     string
 		expectError bool
 	}{
+		{"draft-03", false},
 		{"draft-04", false},
 		{"draft-06", false},
 		{"draft-07", false},
@@ -99,7 +99,23 @@ func TestDataSourceJsonschemaValidatorRead_NoDraftConfiguration(t *testing.T) {
 		"schema_version":         {Type: schema.TypeString},
 		"error_message_template": {Type: schema.TypeString},
 		"ref_overrides":          {Type: schema.TypeMap},
+		"matched_schema":         {Type: schema.TypeString},
+		"extracted_value":        {Type: schema.TypeString},
+		"schema_sha256":          {Type: schema.TypeString},
+		"document_sha256":        {Type: schema.TypeString},
+		"effective_draft":        {Type: schema.TypeString},
+		"errors_by_path":         {Type: schema.TypeMap, Elem: &schema.Schema{Type: schema.TypeString}},
+		"warnings":               {Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeString}},
+		"valid":                  {Type: schema.TypeBool},
+		"validation_errors":      {Type: schema.TypeString},
+		"errors":                 dataSourceJsonschemaValidator().Schema["errors"],
+		"annotations":            dataSourceJsonschemaValidator().Schema["annotations"],
+		"coercions":              dataSourceJsonschemaValidator().Schema["coercions"],
+		"fix_patch":              dataSourceJsonschemaValidator().Schema["fix_patch"],
 		"valid_json":             {Type: schema.TypeString},
+		"valid_yaml":             {Type: schema.TypeString},
+		"valid_toml":             {Type: schema.TypeString},
+		"valid_toml_error":       {Type: schema.TypeString},
 	}, map[string]interface{}{
 		"document":       docPath,
 		"schema":         schemaPath,
@@ -161,7 +177,23 @@ func TestDataSourceJsonschemaValidatorRead_DuplicateSchemaURL(t *testing.T) {
 		"schema_version":         {Type: schema.TypeString},
 		"error_message_template": {Type: schema.TypeString},
 		"ref_overrides":          {Type: schema.TypeMap},
+		"matched_schema":         {Type: schema.TypeString},
+		"extracted_value":        {Type: schema.TypeString},
+		"schema_sha256":          {Type: schema.TypeString},
+		"document_sha256":        {Type: schema.TypeString},
+		"effective_draft":        {Type: schema.TypeString},
+		"errors_by_path":         {Type: schema.TypeMap, Elem: &schema.Schema{Type: schema.TypeString}},
+		"warnings":               {Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeString}},
+		"valid":                  {Type: schema.TypeBool},
+		"validation_errors":      {Type: schema.TypeString},
+		"errors":                 dataSourceJsonschemaValidator().Schema["errors"],
+		"annotations":            dataSourceJsonschemaValidator().Schema["annotations"],
+		"coercions":              dataSourceJsonschemaValidator().Schema["coercions"],
+		"fix_patch":              dataSourceJsonschemaValidator().Schema["fix_patch"],
 		"valid_json":             {Type: schema.TypeString},
+		"valid_yaml":             {Type: schema.TypeString},
+		"valid_toml":             {Type: schema.TypeString},
+		"valid_toml_error":       {Type: schema.TypeString},
 	}, map[string]interface{}{
 		"document": docPath,
 		"schema":   schemaPath,
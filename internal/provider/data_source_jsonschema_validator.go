@@ -1,37 +1,157 @@
 package provider
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/santhosh-tekuri/jsonschema/v6"
 
+	validatorconfig "github.com/binlab/terraform-provider-jsonschema/pkg/config"
 	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
 )
 
+// Accepted values of schema_match_mode
+const (
+	SchemaMatchModeAll = "all"
+	SchemaMatchModeAny = "any"
+)
+
+// Accepted values of canonical_format
+const (
+	CanonicalFormatCompact = "compact"
+	CanonicalFormatIndent  = "indent"
+)
+
 func dataSourceJsonschemaValidator() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceJsonschemaValidatorRead,
 
 		Schema: map[string]*schema.Schema{
 			"document": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Path to document file to validate (supports .json, .json5, .yaml, .yml, .toml)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"document", "document_content"},
+				Description:  "Path to document file to validate (supports .json, .jsonc, .json5, .yaml, .yml, .toml, and .jsonl/.ndjson with one record per line). Exactly one of document or document_content must be set.",
+			},
+			"document_content": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"document", "document_content"},
+				Description:  "Inline document content to validate, e.g. from jsonencode() or templatefile(). The format is detected from the content (JSON/JSON5 or YAML) unless force_filetype is set. Exactly one of document or document_content must be set.",
 			},
 			"force_filetype": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Force document file type (json, json5, yaml, toml). If not set, type is auto-detected from file extension.",
+				Description: "Force document file type (json, jsonc, json5, yaml, toml, jsonl, msgpack, cbor). If not set, type is auto-detected from file extension.",
 			},
 			"schema": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"schemas", "schema_content", "self_describing", "discriminator_map"},
+				AtLeastOneOf:  []string{"schema", "schemas", "schema_content", "self_describing", "discriminator_map", "require_keys"},
+				Description:   "Path to schema file (supports .json, .json5, .yaml, .yml). At most one of schema, schemas, schema_content, self_describing or discriminator_map may be set, and one must be unless require_keys is.",
+			},
+			"schemas": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MinItems:      1,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"schema", "schema_content", "self_describing", "discriminator_map"},
+				AtLeastOneOf:  []string{"schema", "schemas", "schema_content", "self_describing", "discriminator_map", "require_keys"},
+				Description:   "Paths to schema files the document must satisfy. The document is validated against every schema (allOf semantics) and errors from all failing schemas are reported together. At most one of schema, schemas, schema_content, self_describing or discriminator_map may be set, and one must be unless require_keys is.",
+			},
+			"schema_content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"schema", "schemas", "self_describing", "discriminator_map"},
+				AtLeastOneOf:  []string{"schema", "schemas", "schema_content", "self_describing", "discriminator_map", "require_keys"},
+				Description:   "Inline schema content (JSON, JSON5 or YAML). Relative $refs resolve against the current working directory. At most one of schema, schemas, schema_content, self_describing or discriminator_map may be set, and one must be unless require_keys is.",
+			},
+			"self_describing": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"schema", "schemas", "schema_content", "discriminator_map"},
+				AtLeastOneOf:  []string{"schema", "schemas", "schema_content", "self_describing", "discriminator_map", "require_keys"},
+				Description:   "Validate the document against a schema bundled in the document itself: its \"$schema\" must be a fragment such as \"#/schemas/config\". Local $refs may point to other schemas under the same top-level property. At most one of schema, schemas, schema_content, self_describing or discriminator_map may be set, and one must be unless require_keys is.",
+			},
+			"discriminator": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"discriminator_map"},
+				Description:  "Name of the top-level document property (e.g. \"kind\") whose value selects the schema from discriminator_map.",
+			},
+			"discriminator_map": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				RequiredWith:  []string{"discriminator"},
+				ConflictsWith: []string{"schema", "schemas", "schema_content", "self_describing"},
+				AtLeastOneOf:  []string{"schema", "schemas", "schema_content", "self_describing", "discriminator_map", "require_keys"},
+				Description:   "Map of discriminator values to schema paths (or http:// / https:// URLs). The document is validated against the schema mapped to its discriminator property's value; a value without a mapping is an error. At most one of schema, schemas, schema_content, self_describing or discriminator_map may be set, and one must be unless require_keys is.",
+			},
+			"schema_match_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      SchemaMatchModeAll,
+				ValidateFunc: validation.StringInSlice([]string{SchemaMatchModeAll, SchemaMatchModeAny}, false),
+				Description:  "How a document is matched against schemas: \"all\" (default) requires every schema to pass, \"any\" requires at least one schema to pass.",
+			},
+			"canonical_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      CanonicalFormatCompact,
+				ValidateFunc: validation.StringInSlice([]string{CanonicalFormatCompact, CanonicalFormatIndent}, false),
+				Description:  "Layout of valid_json: \"compact\" (default) or \"indent\" for key-sorted JSON indented by two spaces.",
+			},
+			"sort_arrays_at": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "JSON Pointers (RFC 6901) to arrays whose order carries no meaning, e.g. [\"/tags\"]. Each array is sorted by the canonical JSON of its elements in valid_json, valid_yaml, valid_toml, extracted_value and the ID. Validation sees the document as written. Pointers that do not resolve are skipped.",
+			},
+			"context": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(validator.AccessRead), string(validator.AccessWrite)}, false),
+				Description:  "Direction the document travels through an API: \"write\" (e.g. a request body) rejects values whose schema is readOnly, \"read\" (e.g. a response) rejects values whose schema is writeOnly. Each such value is reported as a validation error at its path. Not supported for JSONL documents.",
+			},
+			"base_uri": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Absolute URI to register the schema under instead of its file:// path, e.g. \"https://example.com/schemas/main.json\". Lets schemas that reference each other by logical $id resolve. Relative $refs then resolve against this URI; map its prefix to a local directory with ref_overrides (e.g. \"https://example.com/schemas/**\").",
+			},
+			"schema_fetch_timeout": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Path to schema file (supports .json, .json5, .yaml, .yml)",
+				Optional:    true,
+				Default:     "30s",
+				Description: "Timeout for fetching a remote (http:// or https://) schema, as a Go duration (e.g. \"10s\", \"1m\").",
+			},
+			"strict_format": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable format assertion so values violating \"format\" (email, uri, date-time, ...) fail validation. Also enabled by the provider's strict_format. Unknown format names become a compile error.",
+			},
+			"redact_values": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Keep document values out of validation errors, for documents that hold secrets: the `value` of every `errors` entry and `{{.Document}}` become \"***\", and messages that quote the value (pattern, format, minimum, ...) show \"***\" instead. Also enabled by the provider's redact_values.",
+			},
+			"redact_paths": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "JSON Pointer globs whose values are redacted like redact_values, e.g. [\"/credentials/*\", \"/**/password\"]: \"*\" matches one path segment and \"**\" any number of them. A match hides the whole value under it; values elsewhere stay visible in `value` and the messages.",
 			},
 			"schema_version": {
 				Type:        schema.TypeString,
@@ -41,20 +161,286 @@ func dataSourceJsonschemaValidator() *schema.Resource {
 			"error_message_template": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Template for formatting validation error messages. Available variables: {{.SchemaFile}}, {{.Document}}, {{.FullMessage}}, {{.Errors}}, {{.ErrorCount}}. Use {{range .Errors}} to iterate over individual errors.",
+				Description: "Template for formatting validation error messages. Available variables: {{.SchemaFile}}, {{.Document}}, {{.FullMessage}}, {{.Errors}}, {{.ErrorCount}}. Use {{range .Errors}} to iterate over individual errors. A value like \"@detailed\" selects a built-in template by name. Unset or empty uses the provider's error_message_template.",
+			},
+			"use_raw_error": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"error_message_template"},
+				Description:   "Report the library's error message ({{.FullMessage}}) as is, ignoring the provider's error_message_template. An empty error_message_template can't do this, as it falls back to the provider's template.",
 			},
 			"ref_overrides": {
 				Type:        schema.TypeMap,
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "Map of remote schema URLs to local file paths. When a $ref references a URL in this map, the local file will be used instead. This allows offline validation with schemas that reference remote resources.",
+				Description: "Map of remote schema URLs to local file paths. When a $ref references a URL in this map, the local file will be used instead. This allows offline validation with schemas that reference remote resources. A key ending in \"**\" (e.g. \"https://schemas.example.com/**\") maps every URL under that prefix to the same relative path below a local directory.",
+			},
+			"file_ref_allowlist": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Glob patterns (filepath.Match syntax, e.g. \"schemas/*.json\") of the local files the schema compiler may read for a $ref. A $ref to any other file, such as \"../../etc/secrets.json\", fails to compile. Relative patterns are relative to the working directory. Unset allows every local file.",
+			},
+			"inline_refs": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Inline the schema's $refs to local files before compiling, through a resolver that only reads the files allowed_ref_paths allows, instead of letting the compiler load any file a $ref names. Remote (http:// and https://) $refs are left to the compiler, with ref_overrides. Recursive $refs are not supported. Only for local schema files.",
+			},
+			"allowed_ref_paths": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				RequiredWith: []string{"inline_refs"},
+				Description:  "Glob patterns (filepath.Match syntax, e.g. \"schemas/*.json\") of the files inline_refs may read for a $ref. Relative patterns are relative to the working directory. Empty allows every local file.",
+			},
+			"ref_base_redirect": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of remote URL prefixes to local directories, e.g. {\"https://json-schema.org/\" = \"./mirror\"}. Every $ref under a prefix is loaded from the same relative path below its directory. Equivalent to a ref_overrides key ending in \"**\", which wins for the same prefix.",
+			},
+			"extract": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "JSON Pointer (RFC 6901) to a value in the validated document, e.g. \"/config/servers/0/port\". The value is exposed as extracted_value.",
+			},
+			"ref_overrides_content": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of remote schema URLs to inline schema content (JSON, JSON5 or YAML). Works like ref_overrides but takes the schema body instead of a file path; content wins when both map the same URL.",
+			},
+			"enable_data_keyword": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable the ajv-style \"$data\" keyword, e.g. {\"maximum\": {\"$data\": \"1/limit\"}}, so a keyword's value is read from the document. Supported keywords: const, enum, exclusiveMaximum, exclusiveMinimum, maxItems, maxLength, maxProperties, maximum, minItems, minLength, minProperties, minimum, multipleOf, pattern, required, uniqueItems.",
+			},
+			"report_unknown_keys": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Report each property rejected by \"additionalProperties\": false as its own error, named and located at the property's path (e.g. \"/server/tls\"), instead of one error on the enclosing object.",
+			},
+			"max_errors": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Show at most this many errors (lowest document paths first) in the error message, followed by \"... and N more\". The errors attribute still lists every error. 0 (the default) shows all. Not applied to JSONL documents.",
+			},
+			"reject_duplicate_keys": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Fail when a JSON, JSONC or JSON5 document repeats an object key, naming the key, its object and its line and column. Standard parsers silently keep the last value.",
+			},
+			"report_deprecations": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Report document properties whose schema is annotated with \"x-deprecated\" (true or a message) in the warnings attribute. Deprecations never fail validation.",
+			},
+			"collect_comments": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Collect the \"$comment\" of every subschema that applies to a value the document sets into the annotations attribute, e.g. to document a configuration from its schema.",
+			},
+			"coerce_types": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Convert quoted scalars to the type their schema requires before validation, e.g. \"8080\" to 8080 for {\"type\": \"integer\"} or \"true\" to true for {\"type\": \"boolean\"}. Only values whose schema allows exactly one of boolean, integer or number, and not string, are converted. Each conversion is listed in coercions and valid_json holds the converted values. Not supported for JSONL documents.",
+			},
+			"apply_defaults": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "After successful validation, set every property missing from the document to its schema's \"default\", including in nested objects and array items, so valid_json (and the other outputs) hold the defaults. The defaults are not validated. Not supported for JSONL documents.",
+			},
+			"require_keys": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MinItems:     1,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"schema", "schemas", "schema_content", "self_describing", "discriminator_map", "require_keys"},
+				Description:  "Top-level keys the document must have, checked before any schema is compiled. A document missing one fails with a \"required\" error in the usual format without running the full validation, so this works as a cheap preflight; without a schema it is the whole check. Not supported for JSONL documents or with each.",
+			},
+			"each": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "The document is an array and the schema describes one element: validate every element against it, with errors located under the element's index (e.g. /1/port). valid_json holds the whole array. Not supported for JSONL documents, or with context, coerce_types, apply_defaults, suggest_fixes, report_deprecations, collect_comments, discriminator_map, self_describing or require_keys.",
+			},
+			"suggest_fixes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Experimental. When validation fails and fail_on_error = false, set fix_patch to a JSON Patch (RFC 6902) for the failures with one obvious fix: a missing required property with a schema default, a value of the wrong type with a default, a const mismatch or a property additionalProperties = false rejects. Not supported for JSONL documents.",
 			},
 
+			"fail_on_error": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether a validation failure fails the plan (default true). When false, failures are reported through the valid and validation_errors attributes instead.",
+			},
+
+			"valid": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the document passed validation. Only useful with fail_on_error = false; otherwise a failure returns an error.",
+			},
+			"validation_errors": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The formatted validation error message (using error_message_template) when validation fails and fail_on_error = false. Empty when the document is valid.",
+			},
+			"errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Individual validation errors when validation fails and fail_on_error = false. Empty when the document is valid.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Human-readable error message",
+						},
+						"document_path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON Pointer (RFC 6901) to the failing location in the document (\"\" for the root)",
+						},
+						"schema_path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Schema URL with JSON Pointer fragment of the failing constraint",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON encoding of the value that failed validation (if available)",
+						},
+						"value_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON type of value: string, number, boolean, object, array or null (\"\" when there is no value)",
+						},
+						"line": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "1-based line of the failing record in a JSONL document (0 for other formats)",
+						},
+					},
+				},
+			},
+			"errors_by_path": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Validation errors keyed by document JSON Pointer (\"\" for the root), with several messages for the same path joined by \"; \". Populated like errors, e.g. for lookup(data.jsonschema_validator.x.errors_by_path, \"/name\", \"\").",
+			},
+			"warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Deprecated properties used by the document, as \"<document path>: <message>\". Only populated when report_deprecations is true.",
+			},
+			"annotations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The \"$comment\" annotations of the subschemas applied to the document, sorted by document path (per schema, in list order, with schemas). Only populated when collect_comments is true.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"document_path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON Pointer (RFC 6901) to the annotated location in the document (\"\" for the root)",
+						},
+						"schema_path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Schema URL with JSON Pointer fragment of the \"$comment\" keyword",
+						},
+						"comment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The \"$comment\" value",
+						},
+						"line": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "1-based line of the annotated record in a JSONL document (0 for other formats)",
+						},
+					},
+				},
+			},
+			"coercions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The values coerce_types converted, sorted by document path (per schema, in list order, with schemas). Only populated when coerce_types is true.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"document_path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON Pointer (RFC 6901) to the converted value in the document (\"\" for the root)",
+						},
+						"from": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The string as written in the document",
+						},
+						"to": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON encoding of the converted value",
+						},
+					},
+				},
+			},
+			"fix_patch": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON Patch (RFC 6902) that resolves the failures suggest_fixes knows how to fix, sorted by path (\"[]\" when there are none). Applying it may leave other failures. Only set when suggest_fixes is true and validation fails with fail_on_error = false.",
+			},
+			"matched_schema": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Path of the schema the document validated against. With schema this echoes the input; with schemas it is the first schema (in list order) the document passed.",
+			},
+			"extracted_value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON encoding of the value at the extract pointer. Only set when extract is configured and validation succeeds. Use jsondecode() to access it.",
+			},
+			"effective_draft": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON Schema draft the schema was compiled with (e.g. \"draft/2020-12\"), after applying schema_version, the provider default and the schema's $schema. With schemas, the distinct drafts in list order, comma-separated.",
+			},
+			"schema_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hex SHA-256 of the schema in canonical JSON form, stable across equivalent JSON, JSON5 and YAML representations. With schemas, the hash covers every schema's canonical JSON in list order (one per line).",
+			},
+			"document_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hex SHA-256 of the document in the canonical JSON form of valid_json, so formatting, whitespace, comments and key order in the source file do not change it. Set whether or not the document is valid.",
+			},
 			"valid_json": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The validated document in canonical JSON format. Only set when validation succeeds. Use jsondecode() to access nested structures.",
 			},
+			"valid_yaml": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The validated document as YAML with sorted keys. Only set when validation succeeds. Whole numbers are written as integers (42, not 42.0).",
+			},
+			"valid_toml": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The validated document as TOML with sorted keys. Only set when validation succeeds and the document can be written as TOML: its root must be an object and it must not contain null.",
+			},
+			"valid_toml_error": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Why valid_toml is empty for a valid document that has no TOML form, e.g. \"TOML requires a table at the document root, got an array\". Empty otherwise.",
+			},
 		},
 	}
 }
@@ -65,60 +451,761 @@ func dataSourceJsonschemaValidatorRead(d *schema.ResourceData, m interface{}) er
 		return fmt.Errorf("invalid provider configuration")
 	}
 
-	documentPath := d.Get("document").(string)
+	documentPath, _ := d.Get("document").(string)
 	documentForceFiletype, _ := d.Get("force_filetype").(string)
-	schemaPath := d.Get("schema").(string)
 	schemaVersionOverride := d.Get("schema_version").(string)
-	errorMessageTemplate := d.Get("error_message_template").(string)
 
-	// Use provider default if no template specified
-	if errorMessageTemplate == "" {
-		errorMessageTemplate = config.DefaultErrorTemplate
+	schemaPaths, err := getSchemaPaths(d)
+	if err != nil {
+		return err
+	}
+
+	// Inline content is labeled by its attribute name in errors and matched_schema
+	var schemaContent []byte
+	if content, ok := d.GetOk("schema_content"); ok {
+		schemaContent = []byte(content.(string))
 	}
 
-	// Parse document file (supports JSON, JSON5, YAML, TOML)
+	var rawDocument []byte
+	documentLabel := documentPath
+	if content, ok := d.GetOk("document_content"); ok {
+		rawDocument = []byte(content.(string))
+		documentLabel = documentContentSource
+	}
+
+	failOnError := true
+	if v, ok := d.Get("fail_on_error").(bool); ok {
+		failOnError = v
+	}
+
+	matchMode, _ := d.Get("schema_match_mode").(string)
+	if matchMode == "" {
+		matchMode = SchemaMatchModeAll
+	}
+	if matchMode != SchemaMatchModeAll && matchMode != SchemaMatchModeAny {
+		return fmt.Errorf("invalid schema_match_mode %q (supported: %s, %s)", matchMode, SchemaMatchModeAll, SchemaMatchModeAny)
+	}
+
+	canonicalFormat, _ := d.Get("canonical_format").(string)
+	if canonicalFormat == "" {
+		canonicalFormat = CanonicalFormatCompact
+	}
+	if canonicalFormat != CanonicalFormatCompact && canonicalFormat != CanonicalFormatIndent {
+		return fmt.Errorf("invalid canonical_format %q (supported: %s, %s)", canonicalFormat, CanonicalFormatCompact, CanonicalFormatIndent)
+	}
+
+	contextValue, _ := d.Get("context").(string)
+	accessContext := validator.AccessContext(contextValue)
+	if accessContext != "" && accessContext != validator.AccessRead && accessContext != validator.AccessWrite {
+		return fmt.Errorf("invalid context %q (supported: %s, %s)", accessContext, validator.AccessRead, validator.AccessWrite)
+	}
+
+	errorMessageTemplate, err := dataSourceErrorTemplate(d, config)
+	if err != nil {
+		return err
+	}
+
+	// Parse document file or content (supports JSON, JSON5, YAML, TOML)
 	docFileType := validator.FileType(documentForceFiletype)
 	if docFileType == "" {
 		docFileType = validator.FileTypeAuto
 	}
 
-	documentData, err := validator.ParseFile(documentPath, docFileType)
+	if docFileType == validator.FileTypeAuto {
+		if rawDocument != nil {
+			docFileType = validator.DetectContentType(rawDocument)
+		} else {
+			docFileType = validator.DetectFileType(documentPath)
+		}
+	}
+
+	// A JSONL document is validated record by record; documentData holds the
+	// well-formed records as an array (malformed lines are reported as errors)
+	var (
+		documentData    interface{}
+		documentContent []byte
+		jsonlRecords    []validator.JSONLRecord
+	)
+	isJSONL := docFileType == validator.FileTypeJSONL
+	parseOptions := validator.ParseOptions{
+		ForbidDuplicateKeys: d.Get("reject_duplicate_keys") == true,
+	}
+	if isJSONL {
+		documentContent = rawDocument
+		if documentContent == nil {
+			documentContent, err = os.ReadFile(documentPath)
+			if err != nil {
+				return fmt.Errorf("failed to parse document file %q: reading file: %w", documentPath, err)
+			}
+		}
+		records := []interface{}{}
+		_ = validator.ScanJSONL(bytes.NewReader(documentContent), func(record validator.JSONLRecord) error {
+			if record.Err == nil {
+				jsonlRecords = append(jsonlRecords, record)
+				records = append(records, record.Data)
+			}
+			return nil
+		})
+		documentData = records
+	} else if rawDocument != nil {
+		documentData, err = validator.ParseBytes(rawDocument, docFileType, parseOptions)
+		if err != nil {
+			return fmt.Errorf("failed to parse document_content: %w", err)
+		}
+	} else {
+		documentData, err = validator.ParseFileWithOptions(documentPath, docFileType, parseOptions)
+		if err != nil {
+			return fmt.Errorf("failed to parse document file %q: %w", documentPath, err)
+		}
+	}
+
+	// With each the schema describes an element, so options that walk the schema
+	// alongside the document don't apply to the array
+	each, _ := d.Get("each").(bool)
+	var elements []interface{}
+	if each {
+		if isJSONL {
+			return fmt.Errorf("each is not supported for JSONL documents")
+		}
+		var ok bool
+		if elements, ok = documentData.([]interface{}); !ok {
+			return fmt.Errorf("each: document must be an array")
+		}
+		for _, option := range []string{"context", "coerce_types", "apply_defaults", "suggest_fixes", "report_deprecations", "collect_comments", "discriminator_map", "self_describing", "require_keys"} {
+			if _, ok := d.GetOk(option); ok {
+				return fmt.Errorf("%s is not supported with each", option)
+			}
+		}
+	}
+
+	// A self-describing document carries its schema, referenced by its own "$schema"
+	if d.Get("self_describing") == true {
+		if isJSONL {
+			return fmt.Errorf("self_describing is not supported for JSONL documents")
+		}
+		bundled, err := validator.SelfDescribingSchema(documentData)
+		if err != nil {
+			return fmt.Errorf("self_describing: %w", err)
+		}
+		schemaContent, err = validator.MarshalDeterministic(bundled)
+		if err != nil {
+			return fmt.Errorf("self_describing: failed to encode schema: %w", err)
+		}
+	}
+
+	if accessContext != "" && isJSONL {
+		return fmt.Errorf("context is not supported for JSONL documents")
+	}
+	coerceTypes, _ := d.Get("coerce_types").(bool)
+	if coerceTypes && isJSONL {
+		return fmt.Errorf("coerce_types is not supported for JSONL documents")
+	}
+	applyDefaults, _ := d.Get("apply_defaults").(bool)
+	if applyDefaults && isJSONL {
+		return fmt.Errorf("apply_defaults is not supported for JSONL documents")
+	}
+	suggestFixes, _ := d.Get("suggest_fixes").(bool)
+	if suggestFixes && isJSONL {
+		return fmt.Errorf("suggest_fixes is not supported for JSONL documents")
+	}
+	var requireKeys []string
+	if raw, ok := d.GetOk("require_keys"); ok {
+		if isJSONL {
+			return fmt.Errorf("require_keys is not supported for JSONL documents")
+		}
+		for _, item := range raw.([]interface{}) {
+			key, _ := item.(string)
+			requireKeys = append(requireKeys, key)
+		}
+	}
+
+	// A discriminator picks one schema by the value of a document property
+	if _, ok := d.GetOk("discriminator_map"); ok {
+		if isJSONL {
+			return fmt.Errorf("discriminator is not supported for JSONL documents")
+		}
+		schemaPath, err := discriminatedSchema(d, documentData)
+		if err != nil {
+			return err
+		}
+		schemaPaths = []string{schemaPath}
+	}
+
+	// Determine which schema version to use
+	effectiveSchemaVersion := config.DefaultSchemaVersion
+	if schemaVersionOverride != "" {
+		effectiveSchemaVersion = schemaVersionOverride
+	}
+
+	fetchTimeout := validator.DefaultFetchTimeout
+	if raw, _ := d.Get("schema_fetch_timeout").(string); raw != "" {
+		fetchTimeout, err = time.ParseDuration(raw)
+		if err != nil || fetchTimeout <= 0 {
+			return fmt.Errorf("invalid schema_fetch_timeout %q: must be a positive duration such as \"30s\"", raw)
+		}
+	}
+	fetcher := &validator.RemoteFetcher{CacheDir: config.SchemaCacheDir, Timeout: fetchTimeout}
+
+	// Compile every schema and validate the document against each.
+	// "all" requires every schema to pass, "any" requires at least one.
+	reportDeprecations, _ := d.Get("report_deprecations").(bool)
+	collectComments, _ := d.Get("collect_comments").(bool)
+	reportUnknownKeys, _ := d.Get("report_unknown_keys").(bool)
+	maxErrors, _ := d.Get("max_errors").(int)
+	errorOptions := validator.ErrorOptions{
+		UnknownKeys:  reportUnknownKeys,
+		MaxErrors:    maxErrors,
+		RedactValues: config.RedactValues || d.Get("redact_values") == true,
+	}
+	if errorOptions.RedactPaths, err = redactPaths(d); err != nil {
+		return err
+	}
+	var (
+		schemaJSONs   []string
+		failures      []validator.SchemaValidationFailure
+		lineErrors    []validator.ValidationErrorDetail // JSONL only: per-line errors of every failing schema
+		matchedSchema string
+		warnings      []string
+		annotations   []interface{}
+		coercions     []interface{}
+		fixes         []validator.PatchOperation
+		passed        []*jsonschema.Schema // Compiled schemas the document passed, in list order
+		drafts        []string
+	)
+
+	// require_keys is a cheap preflight: a document missing a key fails without
+	// compiling any schema
+	if len(requireKeys) > 0 {
+		if err := validator.CheckRequiredKeys(documentData, requireKeys, requireKeysSource); err != nil {
+			failures = append(failures, validator.SchemaValidationFailure{SchemaFile: requireKeysSource, Err: err})
+			schemaPaths = nil
+		}
+	}
+
+	for _, schemaPath := range schemaPaths {
+		compiledSchema, schemaJSON, err := compileSchema(d, config, fetcher, schemaPath, schemaContent, effectiveSchemaVersion)
+		if err != nil {
+			return err
+		}
+		schemaJSONs = append(schemaJSONs, string(schemaJSON))
+		drafts = append(drafts, DraftVersionName(compiledSchema.DraftVersion))
+
+		// Quoted scalars are converted before anything reads the document, so
+		// validation and the outputs see the converted values
+		if coerceTypes {
+			var applied []validator.Coercion
+			documentData, applied = validator.CoerceTypes(compiledSchema, documentData)
+			for _, coercion := range applied {
+				to, err := validator.MarshalDeterministicString(coercion.To)
+				if err != nil {
+					return fmt.Errorf("coerce_types: failed to encode value: %w", err)
+				}
+				coercions = append(coercions, map[string]interface{}{
+					"document_path": coercion.DocumentPath,
+					"from":          coercion.From,
+					"to":            to,
+				})
+			}
+		}
+
+		if reportDeprecations {
+			var schemaData interface{}
+			if err := json.Unmarshal(schemaJSON, &schemaData); err != nil {
+				return fmt.Errorf("failed to parse schema JSON: %w", err)
+			}
+			if isJSONL {
+				for _, record := range jsonlRecords {
+					for _, warning := range validator.FindDeprecations(schemaData, record.Data) {
+						warnings = append(warnings, fmt.Sprintf("line %d: %s", record.Line, warning))
+					}
+				}
+			} else {
+				warnings = append(warnings, validator.FindDeprecations(schemaData, documentData)...)
+			}
+		}
+
+		if collectComments {
+			if isJSONL {
+				for _, record := range jsonlRecords {
+					annotations = append(annotations, annotationValues(validator.CollectComments(compiledSchema, record.Data), record.Line)...)
+				}
+			} else {
+				annotations = append(annotations, annotationValues(validator.CollectComments(compiledSchema, documentData), 0)...)
+			}
+		}
+
+		if isJSONL {
+			// The compiled schema is reused for every line
+			_, details, err := validator.ValidateJSONLWithOptions(bytes.NewReader(documentContent), compiledSchema, errorOptions)
+			if err != nil {
+				return fmt.Errorf("failed to read document %q: %w", documentLabel, err)
+			}
+			if len(details) > 0 {
+				if len(schemaPaths) > 1 {
+					for i := range details {
+						details[i].SchemaFile = schemaPath
+					}
+				}
+				lineErrors = append(lineErrors, details...)
+				failures = append(failures, validator.SchemaValidationFailure{SchemaFile: schemaPath})
+			} else if matchedSchema == "" {
+				matchedSchema = schemaPath
+			}
+		} else {
+			var err error
+			if each {
+				err = validator.ValidateEach(compiledSchema, elements)
+			} else {
+				err = compiledSchema.Validate(documentData)
+			}
+			// readOnly/writeOnly values are reported alongside the schema's own errors
+			if accessContext != "" {
+				err = validator.JoinValidationErrors(err, validator.CheckAccess(compiledSchema, documentData, accessContext))
+			}
+			if err != nil {
+				failures = append(failures, validator.SchemaValidationFailure{SchemaFile: schemaPath, Err: err})
+				if suggestFixes {
+					fixes = append(fixes, validator.SuggestFixes(compiledSchema, documentData, err)...)
+				}
+			} else {
+				passed = append(passed, compiledSchema)
+				if matchedSchema == "" {
+					matchedSchema = schemaPath
+				}
+			}
+		}
+	}
+
+	failed := len(failures) > 0
+	if matchMode == SchemaMatchModeAny && len(schemaPaths) > 0 {
+		failed = len(failures) == len(schemaPaths)
+	}
+
+	// With fail_on_error = false a validation failure is reported through
+	// the valid/validation_errors attributes instead of failing the plan
+	var (
+		validationMessage string
+		validationDetails = []interface{}{}
+		errorsByPath      = map[string]string{}
+		fixPatch          string
+	)
+	if failed {
+		var validationErr error
+		if isJSONL {
+			failedSchemas := make([]string, len(failures))
+			for i, failure := range failures {
+				failedSchemas[i] = failure.SchemaFile
+			}
+			validationErr = validator.FormatJSONLValidationError(lineErrors, strings.Join(failedSchemas, ", "), documentLabel, errorMessageTemplate, errorOptions)
+		} else if len(schemaPaths) <= 1 {
+			validationErr = validator.FormatDocumentValidationError(failures[0].Err, failures[0].SchemaFile, documentLabel, documentData, errorMessageTemplate, errorOptions)
+		} else {
+			validationErr = validator.FormatDocumentMultiSchemaValidationError(failures, documentLabel, documentData, errorMessageTemplate, errorOptions)
+		}
+		if failOnError {
+			return validationErr
+		}
+		validationMessage = validationErr.Error()
+		matchedSchema = ""
+
+		details := lineErrors
+		if !isJSONL {
+			for _, failure := range failures {
+				details = append(details, validator.ExtractValidationErrorsWithOptions(failure.Err, documentData, errorOptions)...)
+			}
+		}
+		errorsByPath = validator.GroupErrorsByPath(details)
+		if suggestFixes {
+			fixPatch, err = validator.MarshalFixPatch(fixes)
+			if err != nil {
+				return fmt.Errorf("suggest_fixes: failed to encode fix_patch: %w", err)
+			}
+		}
+		for _, detail := range details {
+			validationDetails = append(validationDetails, map[string]interface{}{
+				"message":       detail.Message,
+				"document_path": detail.DocumentPath,
+				"schema_path":   detail.SchemaPath,
+				"value":         detail.Value,
+				"value_type":    detail.ValueType,
+				"line":          detail.Line,
+			})
+		}
+	}
+
+	// Defaults come from the schemas the document passed: every schema with "all",
+	// the matched one with "any". A require_keys-only check has no schema to take them from.
+	if applyDefaults && !failed {
+		if matchMode == SchemaMatchModeAny && len(passed) > 0 {
+			passed = passed[:1]
+		}
+		for _, compiledSchema := range passed {
+			documentData = validator.ApplyDefaults(compiledSchema, documentData)
+		}
+	}
+
+	// Outputs and the ID are built from the normalized document; validation and
+	// error values above used the document as parsed
+	if config.NormalizeNumbers {
+		documentData = validator.NormalizeNumbers(documentData)
+	}
+	if raw, ok := d.GetOk("sort_arrays_at"); ok {
+		var pointers []string
+		for _, item := range raw.([]interface{}) {
+			pointer, _ := item.(string)
+			pointers = append(pointers, pointer)
+		}
+		documentData, err = validator.SortArraysAt(documentData, pointers)
+		if err != nil {
+			return fmt.Errorf("sort_arrays_at: %w", err)
+		}
+	}
+
+	// Convert document to deterministic canonical JSON
+	canonicalJSON, err := validator.MarshalDeterministic(documentData)
 	if err != nil {
-		return fmt.Errorf("failed to parse document file %q: %w", documentPath, err)
+		return fmt.Errorf("failed to convert document to canonical JSON: %w", err)
 	}
 
-	// Parse schema file (auto-detect from extension: .json/.json5 → JSON5 parser, .yaml/.yml → YAML parser)
-	schemaData, err := validator.ParseFile(schemaPath, validator.FileTypeAuto)
+	// Set the valid_json output field (only for valid documents)
+	validJSON := ""
+	if !failed {
+		validJSON = string(canonicalJSON)
+		if canonicalFormat == CanonicalFormatIndent {
+			indented, err := validator.MarshalDeterministicIndent(documentData)
+			if err != nil {
+				return fmt.Errorf("failed to convert document to canonical JSON: %w", err)
+			}
+			validJSON = string(indented)
+		}
+	}
+	if err := d.Set("valid_json", validJSON); err != nil {
+		return fmt.Errorf("failed to set valid_json field: %w", err)
+	}
+
+	validYAML := ""
+	if !failed {
+		encoded, err := validator.MarshalDeterministicYAML(documentData)
+		if err != nil {
+			return fmt.Errorf("failed to convert document to YAML: %w", err)
+		}
+		validYAML = string(encoded)
+	}
+	if err := d.Set("valid_yaml", validYAML); err != nil {
+		return fmt.Errorf("failed to set valid_yaml field: %w", err)
+	}
+
+	// Not every document has a TOML form (e.g. a root array); those leave valid_toml
+	// empty, with the reason in valid_toml_error, rather than failing validation of a
+	// document that is otherwise fine
+	validTOML, validTOMLError := "", ""
+	if !failed {
+		if encoded, err := validator.MarshalDeterministicTOML(documentData); err == nil {
+			validTOML = string(encoded)
+		} else {
+			validTOMLError = err.Error()
+		}
+	}
+	if err := d.Set("valid_toml", validTOML); err != nil {
+		return fmt.Errorf("failed to set valid_toml field: %w", err)
+	}
+	if err := d.Set("valid_toml_error", validTOMLError); err != nil {
+		return fmt.Errorf("failed to set valid_toml_error field: %w", err)
+	}
+
+	// Extract a single value from the validated document by JSON Pointer
+	extractedValue := ""
+	if pointer, ok := d.GetOk("extract"); ok && !failed {
+		value, err := validator.ResolveJSONPointer(documentData, pointer.(string))
+		if err != nil {
+			return fmt.Errorf("extract: %w", err)
+		}
+		encoded, err := validator.MarshalDeterministic(value)
+		if err != nil {
+			return fmt.Errorf("extract: failed to encode value: %w", err)
+		}
+		extractedValue = string(encoded)
+	}
+	if err := d.Set("extracted_value", extractedValue); err != nil {
+		return fmt.Errorf("failed to set extracted_value field: %w", err)
+	}
+
+	if err := d.Set("warnings", uniqueSorted(warnings)); err != nil {
+		return fmt.Errorf("failed to set warnings field: %w", err)
+	}
+
+	if err := d.Set("annotations", annotations); err != nil {
+		return fmt.Errorf("failed to set annotations field: %w", err)
+	}
+
+	if err := d.Set("coercions", coercions); err != nil {
+		return fmt.Errorf("failed to set coercions field: %w", err)
+	}
+
+	if err := d.Set("fix_patch", fixPatch); err != nil {
+		return fmt.Errorf("failed to set fix_patch field: %w", err)
+	}
+
+	if err := d.Set("matched_schema", matchedSchema); err != nil {
+		return fmt.Errorf("failed to set matched_schema field: %w", err)
+	}
+
+	if err := d.Set("valid", !failed); err != nil {
+		return fmt.Errorf("failed to set valid field: %w", err)
+	}
+
+	if err := d.Set("validation_errors", validationMessage); err != nil {
+		return fmt.Errorf("failed to set validation_errors field: %w", err)
+	}
+
+	if err := d.Set("errors", validationDetails); err != nil {
+		return fmt.Errorf("failed to set errors field: %w", err)
+	}
+
+	if err := d.Set("errors_by_path", errorsByPath); err != nil {
+		return fmt.Errorf("failed to set errors_by_path field: %w", err)
+	}
+
+	if err := d.Set("effective_draft", strings.Join(uniqueInOrder(drafts), ", ")); err != nil {
+		return fmt.Errorf("failed to set effective_draft field: %w", err)
+	}
+
+	if err := d.Set("schema_sha256", hash(strings.Join(schemaJSONs, "\n"))); err != nil {
+		return fmt.Errorf("failed to set schema_sha256 field: %w", err)
+	}
+
+	if err := d.Set("document_sha256", hash(string(canonicalJSON))); err != nil {
+		return fmt.Errorf("failed to set document_sha256 field: %w", err)
+	}
+
+	// Generate ID based on document, schema(s), and the drafts they were compiled
+	// with, so leaving the draft to $schema and naming the same draft agree.
+	// require_keys is added only when set, so existing IDs don't change.
+	compositeString := fmt.Sprintf("%s:%s:%s",
+		string(canonicalJSON),
+		strings.Join(schemaJSONs, ":"),
+		strings.Join(drafts, ":"),
+	)
+	if len(requireKeys) > 0 {
+		compositeString += ":" + strings.Join(requireKeys, "\n")
+	}
+	d.SetId(hash(compositeString))
+
+	return nil
+}
+
+// rawErrorTemplate formats a validation error as the library reports it
+const rawErrorTemplate = "{{.FullMessage}}"
+
+// dataSourceErrorTemplate returns the resolved error template of the data source: the
+// raw error with use_raw_error, else its error_message_template or, when that is unset
+// or empty, the provider's
+func dataSourceErrorTemplate(d *schema.ResourceData, config *ProviderConfig) (string, error) {
+	if d.Get("use_raw_error") == true {
+		return rawErrorTemplate, nil
+	}
+	template, _ := d.Get("error_message_template").(string)
+	if template == "" {
+		template = config.DefaultErrorTemplate
+	}
+	if template == "" {
+		template = rawErrorTemplate
+	}
+	template, err := validator.ResolveErrorTemplate(template)
 	if err != nil {
-		return fmt.Errorf("failed to parse schema file %q: %w", schemaPath, err)
+		return "", fmt.Errorf("error_message_template: %w", err)
+	}
+	return template, nil
+}
+
+// getSchemaPaths returns the schema files to validate against.
+// A single schema is treated as a one-element list; inline schema_content
+// and self_describing are returned as their labels. With only require_keys
+// set the list is empty.
+func getSchemaPaths(d *schema.ResourceData) ([]string, error) {
+	if schemaPath, ok := d.GetOk("schema"); ok {
+		return []string{schemaPath.(string)}, nil
+	}
+	if _, ok := d.GetOk("schema_content"); ok {
+		return []string{schemaContentSource}, nil
+	}
+	if d.Get("self_describing") == true {
+		return []string{selfDescribingSource}, nil
+	}
+	if _, ok := d.GetOk("discriminator_map"); ok {
+		// Replaced by the mapped schema once the document is parsed
+		return []string{discriminatorSource}, nil
+	}
+
+	var schemaPaths []string
+	if raw, ok := d.GetOk("schemas"); ok {
+		for i, item := range raw.([]interface{}) {
+			schemaPath, _ := item.(string)
+			if schemaPath == "" {
+				return nil, fmt.Errorf("schemas[%d] must not be empty", i)
+			}
+			schemaPaths = append(schemaPaths, schemaPath)
+		}
+	}
+
+	if len(schemaPaths) == 0 {
+		// require_keys alone checks the document without a schema
+		if _, ok := d.GetOk("require_keys"); ok {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("one of schema, schemas, schema_content, self_describing, discriminator_map or require_keys must be set")
+	}
+
+	return schemaPaths, nil
+}
+
+// discriminatedSchema returns the discriminator_map entry for the value of the
+// document's discriminator property. Non-string values match their JSON encoding
+// (e.g. 2 or true).
+func discriminatedSchema(d *schema.ResourceData, documentData interface{}) (string, error) {
+	field, _ := d.Get("discriminator").(string)
+	if field == "" {
+		return "", fmt.Errorf("discriminator must be set with discriminator_map")
+	}
+
+	object, ok := documentData.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("discriminator: document must be an object to read property %q", field)
+	}
+	raw, ok := object[field]
+	if !ok {
+		return "", fmt.Errorf("discriminator: document has no %q property", field)
+	}
+	value, ok := raw.(string)
+	if !ok {
+		encoded, err := validator.MarshalDeterministicString(raw)
+		if err != nil {
+			return "", fmt.Errorf("discriminator: failed to encode %q: %w", field, err)
+		}
+		value = encoded
+	}
+
+	mapping := d.Get("discriminator_map").(map[string]interface{})
+	schemaPath, _ := mapping[value].(string)
+	if schemaPath == "" {
+		known := make([]string, 0, len(mapping))
+		for key := range mapping {
+			known = append(known, key)
+		}
+		return "", fmt.Errorf("discriminator: no schema in discriminator_map for %s = %q (mapped values: %s)", field, value, strings.Join(uniqueSorted(known), ", "))
+	}
+	return schemaPath, nil
+}
+
+// compileSchema parses and compiles a schema file, or schemaContent when it is not nil,
+// returning the compiled schema and its deterministic JSON (used for the data source ID)
+func compileSchema(d *schema.ResourceData, config *ProviderConfig, fetcher *validator.RemoteFetcher, schemaPath string, schemaContent []byte, effectiveSchemaVersion string) (*jsonschema.Schema, []byte, error) {
+	remote := schemaContent == nil && validator.IsRemoteURL(schemaPath)
+
+	// Parse schema file (auto-detect from extension: .json/.json5 → JSON5 parser, .yaml/.yml → YAML parser)
+	// Remote schemas are fetched over HTTP(S) through the on-disk cache
+	var (
+		schemaData interface{}
+		err        error
+	)
+	if remote && config.Offline {
+		return nil, nil, fmt.Errorf("failed to fetch schema %q: network access is disabled (provider offline = true)", schemaPath)
+	}
+	if d.Get("inline_refs") == true && (remote || schemaContent != nil) {
+		return nil, nil, fmt.Errorf("inline_refs: schema %q is not a local file", schemaPath)
+	}
+	switch {
+	case schemaContent != nil:
+		schemaData, err = validator.ParseBytes(schemaContent, validator.DetectContentType(schemaContent), validator.ParseOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse schema_content: %w", err)
+		}
+	case remote:
+		schemaData, err = fetcher.ParseURL(schemaPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch schema %q: %w", schemaPath, err)
+		}
+	case d.Get("inline_refs") == true:
+		schemaData, err = inlineSchemaRefs(d, schemaPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("inline_refs: schema %q: %w", schemaPath, err)
+		}
+	default:
+		schemaData, err = validator.ParseFile(schemaPath, validator.FileTypeAuto)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse schema file %q: %w", schemaPath, err)
+		}
+	}
+
+	// Legacy draft-03 schemas are rewritten to draft-04, which the library compiles
+	if isDraft3(effectiveSchemaVersion) {
+		schemaData = validator.ConvertDraft3(schemaData)
 	}
 
 	// Create a new compiler instance for this validation
 	compiler := jsonschema.NewCompiler()
 
-	// Enable JSON5 support for $ref loading
-	compiler.UseLoader(jsonschema.SchemeURLLoader{
+	// Enable JSON5 support for $ref loading; a remote schema may also
+	// reference siblings relative to its own URL
+	loader := jsonschema.SchemeURLLoader{
 		"file": validator.JSON5FileLoader{},
-	})
+	}
+	var fileAllowlist []string
+	if raw, ok := d.GetOk("file_ref_allowlist"); ok {
+		for _, item := range raw.([]interface{}) {
+			pattern, _ := item.(string)
+			fileAllowlist = append(fileAllowlist, pattern)
+		}
+		loader["file"] = &validator.AllowlistFileLoader{Patterns: fileAllowlist, Next: validator.JSON5FileLoader{}}
+	}
+	switch {
+	case config.Offline:
+		loader["http"] = offlineLoader{}
+		loader["https"] = offlineLoader{}
+	case remote:
+		loader["http"] = fetcher
+		loader["https"] = fetcher
+	}
 
-	// Determine which schema version to use
-	effectiveSchemaVersion := config.DefaultSchemaVersion
-	if schemaVersionOverride != "" {
-		effectiveSchemaVersion = schemaVersionOverride
+	// Register org-specific formats from the provider's custom_formats
+	for _, format := range config.CustomFormats {
+		compiler.RegisterFormat(format)
+	}
+
+	// Assert "format" instead of treating it as an annotation; unknown formats
+	// would otherwise be ignored silently, so reject them up front
+	strictFormat := config.StrictFormat || d.Get("strict_format") == true
+	if strictFormat {
+		compiler.AssertFormat()
+		if unknown := validator.UnknownFormats(schemaData, config.CustomFormats); len(unknown) > 0 {
+			return nil, nil, fmt.Errorf("strict_format: schema %q uses unknown format(s) %s", schemaPath, strings.Join(unknown, ", "))
+		}
 	}
 
-	// Set the appropriate draft using DefaultDraft method (v6 API)
+	// Set the appropriate draft using DefaultDraft method (v6 API).
+	// The default only applies to schemas without "$schema"; a declared
+	// "$schema" (e.g. draft-04) always selects its own draft.
+	// Fallback to Draft2020 if no draft is set
+	draft := jsonschema.Draft2020
 	if effectiveSchemaVersion != "" {
-		draft, err := GetDraftForVersion(effectiveSchemaVersion)
+		draft, err = GetDraftForVersion(effectiveSchemaVersion)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		compiler.DefaultDraft(draft)
 	} else if config.DefaultDraft != nil {
-		compiler.DefaultDraft(config.DefaultDraft)
-	} else {
-		// Fallback to Draft2020 if no draft is set
-		compiler.DefaultDraft(jsonschema.Draft2020)
+		draft = config.DefaultDraft
+	}
+	compiler.DefaultDraft(draft)
+
+	// Report meta-schema violations with the error template rather than as a compile error
+	if config.ValidateSchema {
+		if err := validator.ValidateAgainstMetaSchema(schemaData, draft); err != nil {
+			template, templateErr := dataSourceErrorTemplate(d, config)
+			if templateErr != nil {
+				return nil, nil, templateErr
+			}
+			return nil, nil, fmt.Errorf("schema %q is not valid against its meta-schema: %w", schemaPath, validator.FormatValidationError(err, schemaPath, schemaPath, template))
+		}
 	}
 
 	// Pre-register ref overrides BEFORE adding the main schema.
@@ -136,85 +1223,238 @@ func dataSourceJsonschemaValidatorRead(d *schema.ResourceData, m interface{}) er
 	// - Version-controlled schemas (all files in repository)
 	// - Deterministic builds (same inputs = same results)
 	// - Air-gapped environments (no internet access required)
+	var (
+		overrides       = make(map[string]interface{})
+		overrideSources = make(map[string]string) // URL -> local path or "inline content", for errors
+		mirrors         = make(map[string]string) // "https://host/**" -> local base directory
+	)
+	// Provider-level ref_overrides apply to every data source; the data source's own entries win
+	resourceOverrides := make(map[string]string)
 	if refOverridesRaw, ok := d.GetOk("ref_overrides"); ok {
-		refOverrides := refOverridesRaw.(map[string]interface{})
+		for remoteURL, localPathRaw := range refOverridesRaw.(map[string]interface{}) {
+			resourceOverrides[remoteURL], _ = localPathRaw.(string)
+		}
+	}
 
-		for remoteURL, localPathRaw := range refOverrides {
-			localPath := localPathRaw.(string)
+	for remoteURL, localPath := range validatorconfig.MergeRefOverrides(config.RefOverrides, resourceOverrides) {
+		// A "**" pattern maps every URL under a prefix to a local directory;
+		// files are only read when a $ref actually points there
+		if validator.IsMirrorPattern(remoteURL) {
+			mirrors[remoteURL] = localPath
+			continue
+		}
 
-			// Parse the override schema file (supports JSON, JSON5, YAML, TOML - auto-detect)
-			overrideData, err := validator.ParseFile(localPath, validator.FileTypeAuto)
-			if err != nil {
-				return fmt.Errorf("ref_override: failed to parse local file %q for URL %q: %w",
-					localPath, remoteURL, err)
+		// Parse the override schema file (supports JSON, JSON5, YAML, TOML - auto-detect)
+		overrideData, err := validator.ParseFile(localPath, validator.FileTypeAuto)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ref_override: failed to parse local file %q for URL %q: %w",
+				localPath, remoteURL, err)
+		}
+		overrides[remoteURL] = overrideData
+		overrideSources[remoteURL] = localPath
+	}
+
+	// ref_base_redirect is the "**" form of ref_overrides keyed by the bare prefix
+	if redirectsRaw, ok := d.GetOk("ref_base_redirect"); ok {
+		for prefix, dirRaw := range redirectsRaw.(map[string]interface{}) {
+			if !validator.IsRemoteURL(prefix) {
+				return nil, nil, fmt.Errorf("ref_base_redirect: %q is not an http:// or https:// URL prefix", prefix)
+			}
+			pattern := prefix + validator.MirrorPatternSuffix
+			if _, ok := mirrors[pattern]; !ok {
+				mirrors[pattern], _ = dirRaw.(string)
 			}
+		}
+	}
 
-			// Pre-register this schema at the remote URL.
-			// When the compiler encounters "$ref": "remoteURL" during schema compilation,
-			// it will use this pre-registered data instead of attempting to load from the URL.
-			if err := compiler.AddResource(remoteURL, overrideData); err != nil {
-				return fmt.Errorf("ref_override: failed to register %q -> %q: %w",
-					remoteURL, localPath, err)
+	// Inline content has no file extension, so its format is detected from the content itself.
+	// It replaces a ref_overrides entry for the same URL.
+	if contentRaw, ok := d.GetOk("ref_overrides_content"); ok {
+		for remoteURL, bodyRaw := range contentRaw.(map[string]interface{}) {
+			body := []byte(bodyRaw.(string))
+			overrideData, err := validator.ParseBytes(body, validator.DetectContentType(body), validator.ParseOptions{})
+			if err != nil {
+				return nil, nil, fmt.Errorf("ref_overrides_content: failed to parse content for URL %q: %w", remoteURL, err)
 			}
+			overrides[remoteURL] = overrideData
+			overrideSources[remoteURL] = "inline content"
 		}
 	}
 
+	for remoteURL, overrideData := range overrides {
+		// Pre-register this schema at the remote URL.
+		// When the compiler encounters "$ref": "remoteURL" during schema compilation,
+		// it will use this pre-registered data instead of attempting to load from the URL.
+		if err := compiler.AddResource(remoteURL, overrideData); err != nil {
+			return nil, nil, fmt.Errorf("ref_override: failed to register %q -> %q: %w",
+				remoteURL, overrideSources[remoteURL], err)
+		}
+	}
+
+	// Mirrored prefixes are checked before the regular http(s) loaders
+	if len(mirrors) > 0 {
+		for _, scheme := range []string{"http", "https"} {
+			loader[scheme] = validator.NewMirrorLoader(mirrors, loader[scheme])
+		}
+	}
+	compiler.UseLoader(loader)
+
 	// Convert schema data to deterministic JSON string
 	schemaJSON, err := validator.MarshalDeterministic(schemaData)
 	if err != nil {
-		return fmt.Errorf("failed to convert schema to JSON: %w", err)
+		return nil, nil, fmt.Errorf("failed to convert schema to JSON: %w", err)
 	}
 
 	// Generate schema URL based on the actual schema file path
-	// This ensures unique URLs for different schemas in the same directory
-	schemaAbsPath, err := filepath.Abs(schemaPath)
+	// This ensures unique URLs for different schemas in the same directory.
+	// Inline content is placed in the working directory so relative $refs resolve there.
+	// base_uri replaces it, so $refs to the schema's logical URI resolve to the schema itself.
+	schemaURL := schemaPath
+	if baseURI, _ := d.Get("base_uri").(string); baseURI != "" {
+		parsed, err := url.Parse(baseURI)
+		if err != nil || !parsed.IsAbs() || parsed.Fragment != "" {
+			return nil, nil, fmt.Errorf("invalid base_uri %q: must be an absolute URI without a fragment", baseURI)
+		}
+		schemaURL = baseURI
+	} else if !remote {
+		schemaAbsPath, err := filepath.Abs(schemaPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get absolute path for schema: %w", err)
+		}
+		schemaURL = fmt.Sprintf("file://%s", schemaAbsPath)
+	}
+
+	dataKeyword := d.Get("enable_data_keyword") == true
+
+	// Reuse the schema compiled by an earlier read with the same inputs
+	overridesJSON, err := validator.MarshalDeterministic(overrides)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path for schema: %w", err)
+		return nil, nil, fmt.Errorf("failed to convert ref overrides to JSON: %w", err)
+	}
+	cacheKey := schemaCacheKey(schemaCacheInputs{
+		schemaURL:     schemaURL,
+		schemaJSON:    schemaJSON,
+		draft:         draft,
+		overridesJSON: overridesJSON,
+		mirrors:       mirrors,
+		strictFormat:  strictFormat,
+		dataKeyword:   dataKeyword,
+		offline:       config.Offline,
+		formats:       config.CustomFormats,
+		fileAllowlist: fileAllowlist,
+	})
+	if compiledSchema, ok := compiledSchemas.get(cacheKey); ok {
+		return compiledSchema, schemaJSON, nil
 	}
-	schemaURL := fmt.Sprintf("file://%s", schemaAbsPath)
 
 	// Add schema resource and compile (v6 API)
 	var parsedSchemaData interface{}
 	if err := json.Unmarshal(schemaJSON, &parsedSchemaData); err != nil {
-		return fmt.Errorf("failed to parse schema JSON: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse schema JSON: %w", err)
+	}
+
+	// $data references are hoisted into rules enforced by a custom vocabulary
+	if dataKeyword {
+		if err := validator.RewriteDataRefs(parsedSchemaData); err != nil {
+			return nil, nil, fmt.Errorf("enable_data_keyword: schema %q: %w", schemaPath, err)
+		}
+		compiler.RegisterVocabulary(validator.DataVocabulary())
+		compiler.AssertVocabs()
 	}
 
 	if err := compiler.AddResource(schemaURL, parsedSchemaData); err != nil {
-		return fmt.Errorf("failed to add schema resource: %w", err)
+		return nil, nil, fmt.Errorf("failed to add schema resource: %w", err)
 	}
 
 	compiledSchema, err := compiler.Compile(schemaURL)
 	if err != nil {
-		return fmt.Errorf("failed to compile schema: %w", err)
+		return nil, nil, fmt.Errorf("failed to compile schema: %w", err)
 	}
+	compiledSchemas.put(cacheKey, compiledSchema)
 
-	// Validate the document
-	if err := compiledSchema.Validate(documentData); err != nil {
-		return validator.FormatValidationError(err, schemaPath, documentPath, errorMessageTemplate)
-	}
+	return compiledSchema, schemaJSON, nil
+}
 
-	// Convert document to deterministic canonical JSON
-	canonicalJSON, err := validator.MarshalDeterministic(documentData)
-	if err != nil {
-		return fmt.Errorf("failed to convert document to canonical JSON: %w", err)
+// inlineSchemaRefs parses the schema file at schemaPath with every $ref to a local file
+// inlined, reading only the files allowed_ref_paths allows. Remote $refs are kept for
+// the compiler, which resolves them through ref_overrides or fetches them.
+func inlineSchemaRefs(d *schema.ResourceData, schemaPath string) (interface{}, error) {
+	var allowed []string
+	if raw, ok := d.GetOk("allowed_ref_paths"); ok {
+		for _, item := range raw.([]interface{}) {
+			pattern, _ := item.(string)
+			allowed = append(allowed, pattern)
+		}
 	}
+	resolver := validator.NewRefResolver(allowed)
+	resolver.KeepRemoteRefs = true
+	return resolver.ResolveRefs(schemaPath)
+}
 
-	// Set the valid_json output field
-	if err := d.Set("valid_json", string(canonicalJSON)); err != nil {
-		return fmt.Errorf("failed to set valid_json field: %w", err)
+// Labels used in place of a file path for inline content
+const (
+	schemaContentSource   = "schema_content"
+	documentContentSource = "document_content"
+	selfDescribingSource  = "self_describing"
+	discriminatorSource   = "discriminator_map"
+	requireKeysSource     = "require_keys"
+)
+
+// offlineLoader rejects remote $refs when the provider runs with offline = true.
+// ref_overrides are registered as resources and never reach a loader.
+type offlineLoader struct{}
+
+func (offlineLoader) Load(url string) (interface{}, error) {
+	return nil, fmt.Errorf("network access is disabled (provider offline = true); add %q to ref_overrides to use a local copy", url)
+}
+
+// redactPaths returns the data source's redact_paths, checking that each is a JSON Pointer
+func redactPaths(d *schema.ResourceData) ([]string, error) {
+	var paths []string
+	items, _ := d.Get("redact_paths").([]interface{})
+	for _, item := range items {
+		path, _ := item.(string)
+		if _, err := validator.ParseJSONPointer(path); err != nil {
+			return nil, fmt.Errorf("redact_paths: %w", err)
+		}
+		paths = append(paths, path)
 	}
+	return paths, nil
+}
 
-	// Generate ID based on document, schema, and configuration
-	// schemaJSON is already available from earlier in the function
+// uniqueInOrder returns the distinct values of items, keeping their first occurrence order
+func uniqueInOrder(items []string) []string {
+	result := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
 
-	compositeString := fmt.Sprintf("%s:%s:%s",
-		string(canonicalJSON),
-		string(schemaJSON),
-		effectiveSchemaVersion,
-	)
-	d.SetId(hash(compositeString))
+// uniqueSorted returns the distinct values of items in sorted order, never nil
+func uniqueSorted(items []string) []string {
+	result := uniqueInOrder(items)
+	sort.Strings(result)
+	return result
+}
 
-	return nil
+// annotationValues converts annotations to annotations attribute values; line is the
+// JSONL record line, or 0 for other formats
+func annotationValues(annotations []validator.Annotation, line int) []interface{} {
+	values := make([]interface{}, 0, len(annotations))
+	for _, annotation := range annotations {
+		values = append(values, map[string]interface{}{
+			"document_path": annotation.DocumentPath,
+			"schema_path":   annotation.SchemaPath,
+			"comment":       annotation.Comment,
+			"line":          line,
+		})
+	}
+	return values
 }
 
 func hash(s string) string {
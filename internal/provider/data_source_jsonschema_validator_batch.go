@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	validatorconfig "github.com/binlab/terraform-provider-jsonschema/pkg/config"
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
+)
+
+func dataSourceJsonschemaValidatorBatch() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceJsonschemaValidatorBatchRead,
+
+		Schema: map[string]*schema.Schema{
+			"documents": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Document file paths or glob patterns (e.g. \"configs/*.yaml\") to validate. Patterns that match no files are skipped.",
+			},
+			"schema": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path to schema file (supports .json, .json5, .yaml, .yml) or an http:// / https:// URL. The schema is compiled once for all documents.",
+			},
+			"force_filetype": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Force document file type (json, jsonc, json5, yaml, toml, jsonl, msgpack, cbor). If not set, type is auto-detected from each file's extension.",
+			},
+			"schema_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "JSON Schema version override for this validation (overrides provider default)",
+			},
+			"schema_fetch_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "30s",
+				Description: "Timeout for fetching a remote (http:// or https://) schema, as a Go duration (e.g. \"10s\", \"1m\").",
+			},
+			"strict_format": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable format assertion so values violating \"format\" fail validation. Also enabled by the provider's strict_format.",
+			},
+			"reject_duplicate_keys": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Fail a JSON, JSONC or JSON5 document that repeats an object key, as in jsonschema_validator.",
+			},
+			"redact_values": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Replace document values in error messages with \"***\", for documents that hold secrets. Also enabled by the provider's redact_values.",
+			},
+			"redact_paths": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "JSON Pointer globs (\"*\" matches one path segment, \"**\" any number) whose values are replaced with \"***\" in error messages, as in jsonschema_validator.",
+			},
+			"error_message_template": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template for formatting each document's validation error. Available variables: {{.SchemaFile}}, {{.Document}}, {{.FullMessage}}, {{.Errors}}, {{.ErrorCount}}.",
+			},
+			"ref_overrides": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of remote schema URLs (or \"**\" prefix patterns) to local file paths (or directories), as in jsonschema_validator.",
+			},
+			"ref_overrides_content": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of remote schema URLs to inline schema content, as in jsonschema_validator.",
+			},
+
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "One entry per matched document, in the order of documents (glob matches sorted by name).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"document": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Path of the document",
+						},
+						"valid": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the document passed validation",
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The formatted validation or parse error. Empty when the document is valid.",
+						},
+					},
+				},
+			},
+			"all_valid": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether every matched document passed validation",
+			},
+		},
+	}
+}
+
+func dataSourceJsonschemaValidatorBatchRead(d *schema.ResourceData, m interface{}) error {
+	config, ok := m.(*ProviderConfig)
+	if !ok {
+		return fmt.Errorf("invalid provider configuration")
+	}
+
+	var patterns []string
+	for i, item := range d.Get("documents").([]interface{}) {
+		pattern, _ := item.(string)
+		if pattern == "" {
+			return fmt.Errorf("documents[%d] must not be empty", i)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	// Glob expansion follows the CLI: literal paths are kept, patterns without matches are skipped
+	documentPaths, err := (&validatorconfig.SchemaConfig{Documents: patterns}).ExpandDocumentGlobs()
+	if err != nil {
+		return err
+	}
+
+	settings, err := readBatchSettings(d, config)
+	if err != nil {
+		return err
+	}
+
+	allValid := true
+	results := make([]interface{}, 0, len(documentPaths))
+	idParts := []string{string(settings.schemaJSON), DraftVersionName(settings.compiledSchema.DraftVersion)}
+	for _, documentPath := range documentPaths {
+		validationErr := settings.validateDocument(documentPath)
+
+		errorMessage := ""
+		if validationErr != nil {
+			allValid = false
+			errorMessage = validationErr.Error()
+		}
+		results = append(results, map[string]interface{}{
+			"document": documentPath,
+			"valid":    validationErr == nil,
+			"error":    errorMessage,
+		})
+		idParts = append(idParts, documentPath, errorMessage)
+	}
+
+	if err := d.Set("results", results); err != nil {
+		return fmt.Errorf("failed to set results field: %w", err)
+	}
+
+	if err := d.Set("all_valid", allValid); err != nil {
+		return fmt.Errorf("failed to set all_valid field: %w", err)
+	}
+
+	d.SetId(hash(strings.Join(idParts, ":")))
+
+	return nil
+}
+
+// batchSettings holds what the batch and map data sources share across
+// their documents: the schema compiled once, the error template and the
+// parse and error options
+type batchSettings struct {
+	schemaPath           string
+	fileType             validator.FileType
+	compiledSchema       *jsonschema.Schema
+	schemaJSON           []byte
+	errorMessageTemplate string
+	parseOptions         validator.ParseOptions
+	errorOptions         validator.ErrorOptions
+}
+
+// readBatchSettings resolves the error template and compiles the schema for
+// the batch and map data sources
+func readBatchSettings(d *schema.ResourceData, config *ProviderConfig) (*batchSettings, error) {
+	errorMessageTemplate, err := dataSourceErrorTemplate(d, config)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveSchemaVersion := config.DefaultSchemaVersion
+	if schemaVersionOverride, _ := d.Get("schema_version").(string); schemaVersionOverride != "" {
+		effectiveSchemaVersion = schemaVersionOverride
+	}
+
+	fetchTimeout := validator.DefaultFetchTimeout
+	if raw, _ := d.Get("schema_fetch_timeout").(string); raw != "" {
+		fetchTimeout, err = time.ParseDuration(raw)
+		if err != nil || fetchTimeout <= 0 {
+			return nil, fmt.Errorf("invalid schema_fetch_timeout %q: must be a positive duration such as \"30s\"", raw)
+		}
+	}
+	fetcher := &validator.RemoteFetcher{CacheDir: config.SchemaCacheDir, Timeout: fetchTimeout}
+
+	// Compile once, validate every document against the same schema
+	schemaPath := d.Get("schema").(string)
+	compiledSchema, schemaJSON, err := compileSchema(d, config, fetcher, schemaPath, nil, effectiveSchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	errorOptions := validator.ErrorOptions{
+		RedactValues: config.RedactValues || d.Get("redact_values") == true,
+	}
+	if errorOptions.RedactPaths, err = redactPaths(d); err != nil {
+		return nil, err
+	}
+
+	documentForceFiletype, _ := d.Get("force_filetype").(string)
+	return &batchSettings{
+		schemaPath:           schemaPath,
+		fileType:             validator.FileType(documentForceFiletype),
+		compiledSchema:       compiledSchema,
+		schemaJSON:           schemaJSON,
+		errorMessageTemplate: errorMessageTemplate,
+		parseOptions: validator.ParseOptions{
+			ForbidDuplicateKeys: d.Get("reject_duplicate_keys") == true,
+		},
+		errorOptions: errorOptions,
+	}, nil
+}
+
+// validateDocument parses and validates one document, returning the formatted
+// parse or validation error (nil when the document is valid)
+func (s *batchSettings) validateDocument(documentPath string) error {
+	fileType := s.fileType
+	if fileType == "" || fileType == validator.FileTypeAuto {
+		fileType = validator.DetectFileType(documentPath)
+	}
+
+	if fileType == validator.FileTypeJSONL {
+		content, err := os.ReadFile(documentPath)
+		if err != nil {
+			return fmt.Errorf("failed to parse document file %q: reading file: %w", documentPath, err)
+		}
+		_, details, err := validator.ValidateJSONLWithOptions(bytes.NewReader(content), s.compiledSchema, s.errorOptions)
+		if err != nil {
+			return fmt.Errorf("failed to read document file %q: %w", documentPath, err)
+		}
+		return validator.FormatJSONLValidationError(details, s.schemaPath, documentPath, s.errorMessageTemplate, s.errorOptions)
+	}
+
+	documentData, err := validator.ParseFileWithOptions(documentPath, fileType, s.parseOptions)
+	if err != nil {
+		return fmt.Errorf("failed to parse document file %q: %w", documentPath, err)
+	}
+
+	if err := s.compiledSchema.Validate(documentData); err != nil {
+		return validator.FormatDocumentValidationError(err, s.schemaPath, documentPath, documentData, s.errorMessageTemplate, s.errorOptions)
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceJsonschemaValidatorBatchRead(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "config.schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configsDir := filepath.Join(tempDir, "configs")
+	if err := os.Mkdir(configsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"a.json":  `{"name": "a"}`,
+		"b.yaml":  "name: b\n",
+		"c.json":  `{"other": true}`,
+		"d.json5": `{name: 'd',}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(configsDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	brokenFile := filepath.Join(tempDir, "broken.json")
+	if err := os.WriteFile(brokenFile, []byte(`{broken`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	duplicateFile := filepath.Join(tempDir, "duplicate.json")
+	if err := os.WriteFile(duplicateFile, []byte(`{"name": "a", "name": "b"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}
+
+	type result struct {
+		document      string
+		valid         bool
+		errorContains string
+	}
+	tests := []struct {
+		name                string
+		documents           []interface{}
+		rejectDuplicateKeys bool
+		allValid            bool
+		expected            []result
+	}{
+		{
+			name:      "all documents valid",
+			documents: []interface{}{filepath.Join(configsDir, "a.json"), filepath.Join(configsDir, "*.yaml")},
+			allValid:  true,
+			expected: []result{
+				{document: filepath.Join(configsDir, "a.json"), valid: true},
+				{document: filepath.Join(configsDir, "b.yaml"), valid: true},
+			},
+		},
+		{
+			name:      "glob with an invalid document",
+			documents: []interface{}{filepath.Join(configsDir, "*.json")},
+			allValid:  false,
+			expected: []result{
+				{document: filepath.Join(configsDir, "a.json"), valid: true},
+				{document: filepath.Join(configsDir, "c.json"), valid: false, errorContains: "missing property 'name'"},
+			},
+		},
+		{
+			name:      "parse errors are reported per document",
+			documents: []interface{}{brokenFile, filepath.Join(configsDir, "d.json5")},
+			allValid:  false,
+			expected: []result{
+				{document: brokenFile, valid: false, errorContains: "failed to parse document file"},
+				{document: filepath.Join(configsDir, "d.json5"), valid: true},
+			},
+		},
+		{
+			name:      "duplicate keys allowed by default",
+			documents: []interface{}{duplicateFile},
+			allValid:  true,
+			expected:  []result{{document: duplicateFile, valid: true}},
+		},
+		{
+			name:                "duplicate keys rejected",
+			documents:           []interface{}{duplicateFile, filepath.Join(configsDir, "a.json")},
+			rejectDuplicateKeys: true,
+			allValid:            false,
+			expected: []result{
+				{document: duplicateFile, valid: false, errorContains: `duplicate key "name" in object at ''`},
+				{document: filepath.Join(configsDir, "a.json"), valid: true},
+			},
+		},
+		{
+			name:      "unmatched glob yields no results",
+			documents: []interface{}{filepath.Join(configsDir, "*.toml")},
+			allValid:  true,
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidatorBatch().Schema, map[string]interface{}{
+				"documents":             tt.documents,
+				"schema":                schemaFile,
+				"reject_duplicate_keys": tt.rejectDuplicateKeys,
+			})
+
+			if err := dataSourceJsonschemaValidatorBatchRead(resourceData, config); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := resourceData.Get("all_valid").(bool); got != tt.allValid {
+				t.Errorf("all_valid = %v, want %v", got, tt.allValid)
+			}
+
+			results := resourceData.Get("results").([]interface{})
+			if len(results) != len(tt.expected) {
+				t.Fatalf("expected %d results, got %d: %v", len(tt.expected), len(results), results)
+			}
+			for i, want := range tt.expected {
+				got := results[i].(map[string]interface{})
+				if got["document"] != want.document || got["valid"] != want.valid {
+					t.Errorf("results[%d] = %v, want document %q valid %v", i, got, want.document, want.valid)
+				}
+				errorMessage := got["error"].(string)
+				if want.errorContains == "" && errorMessage != "" {
+					t.Errorf("results[%d].error = %q, want empty", i, errorMessage)
+				}
+				if !strings.Contains(errorMessage, want.errorContains) {
+					t.Errorf("results[%d].error = %q, want to contain %q", i, errorMessage, want.errorContains)
+				}
+			}
+
+			if resourceData.Id() == "" {
+				t.Error("expected ID to be set")
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorBatchRead_SchemaErrors(t *testing.T) {
+	tempDir := t.TempDir()
+
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidatorBatch().Schema, map[string]interface{}{
+		"documents": []interface{}{docFile},
+		"schema":    filepath.Join(tempDir, "missing.schema.json"),
+	})
+
+	err := dataSourceJsonschemaValidatorBatchRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+	if err == nil || !strings.Contains(err.Error(), "failed to parse schema file") {
+		t.Fatalf("expected schema parse error, got %v", err)
+	}
+}
+
+func TestDataSourceJsonschemaValidatorBatchRead_EmptyProviderTemplate(t *testing.T) {
+	tempDir := t.TempDir()
+
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidatorBatch().Schema, map[string]interface{}{
+		"documents": []interface{}{docFile},
+		"schema":    schemaFile,
+	})
+
+	// Without any template the error falls back to {{.FullMessage}}
+	if err := dataSourceJsonschemaValidatorBatchRead(resourceData, &ProviderConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := resourceData.Get("results.0.error").(string)
+	if !strings.Contains(got, "missing property 'name'") {
+		t.Errorf("error = %q, want the full message", got)
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceJsonschemaValidatorMap() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceJsonschemaValidatorMapRead,
+
+		Schema: map[string]*schema.Schema{
+			"documents": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of labels (e.g. environment names) to document file paths. Each label names its document in the results.",
+			},
+			"schema": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path to schema file (supports .json, .json5, .yaml, .yml) or an http:// / https:// URL. The schema is compiled once for all documents.",
+			},
+			"force_filetype": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Force document file type (json, jsonc, json5, yaml, toml, jsonl, msgpack, cbor). If not set, type is auto-detected from each file's extension.",
+			},
+			"schema_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "JSON Schema version override for this validation (overrides provider default)",
+			},
+			"schema_fetch_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "30s",
+				Description: "Timeout for fetching a remote (http:// or https://) schema, as a Go duration (e.g. \"10s\", \"1m\").",
+			},
+			"strict_format": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable format assertion so values violating \"format\" fail validation. Also enabled by the provider's strict_format.",
+			},
+			"reject_duplicate_keys": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Fail a JSON, JSONC or JSON5 document that repeats an object key, as in jsonschema_validator.",
+			},
+			"redact_values": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Replace document values in error messages with \"***\", for documents that hold secrets. Also enabled by the provider's redact_values.",
+			},
+			"redact_paths": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "JSON Pointer globs (\"*\" matches one path segment, \"**\" any number) whose values are replaced with \"***\" in error messages, as in jsonschema_validator.",
+			},
+			"error_message_template": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template for formatting each document's validation error. Available variables: {{.SchemaFile}}, {{.Document}}, {{.FullMessage}}, {{.Errors}}, {{.ErrorCount}}.",
+			},
+			"ref_overrides": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of remote schema URLs (or \"**\" prefix patterns) to local file paths (or directories), as in jsonschema_validator.",
+			},
+			"ref_overrides_content": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of remote schema URLs to inline schema content, as in jsonschema_validator.",
+			},
+
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "One entry per document, sorted by label.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Label of the document in documents",
+						},
+						"document": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Path of the document",
+						},
+						"valid": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the document passed validation",
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The formatted validation or parse error. Empty when the document is valid.",
+						},
+					},
+				},
+			},
+			"valid_by_label": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+				Description: "Whether each document passed validation, keyed by label",
+			},
+			"errors_by_label": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The formatted error of each invalid document, keyed by label. Valid documents have no entry, e.g. for lookup(data.jsonschema_validator_map.x.errors_by_label, \"prod\", \"\").",
+			},
+			"all_valid": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether every document passed validation",
+			},
+		},
+	}
+}
+
+func dataSourceJsonschemaValidatorMapRead(d *schema.ResourceData, m interface{}) error {
+	config, ok := m.(*ProviderConfig)
+	if !ok {
+		return fmt.Errorf("invalid provider configuration")
+	}
+
+	documents := d.Get("documents").(map[string]interface{})
+	labels := make([]string, 0, len(documents))
+	for label, item := range documents {
+		if path, _ := item.(string); path == "" {
+			return fmt.Errorf("documents[%q] must not be empty", label)
+		}
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	settings, err := readBatchSettings(d, config)
+	if err != nil {
+		return err
+	}
+
+	allValid := true
+	results := make([]interface{}, 0, len(labels))
+	validByLabel := make(map[string]interface{}, len(labels))
+	errorsByLabel := make(map[string]interface{})
+	idParts := []string{string(settings.schemaJSON), DraftVersionName(settings.compiledSchema.DraftVersion)}
+	for _, label := range labels {
+		documentPath := documents[label].(string)
+		validationErr := settings.validateDocument(documentPath)
+
+		errorMessage := ""
+		if validationErr != nil {
+			allValid = false
+			errorMessage = validationErr.Error()
+			errorsByLabel[label] = errorMessage
+		}
+		validByLabel[label] = validationErr == nil
+		results = append(results, map[string]interface{}{
+			"label":    label,
+			"document": documentPath,
+			"valid":    validationErr == nil,
+			"error":    errorMessage,
+		})
+		idParts = append(idParts, label, documentPath, errorMessage)
+	}
+
+	if err := d.Set("results", results); err != nil {
+		return fmt.Errorf("failed to set results field: %w", err)
+	}
+
+	if err := d.Set("valid_by_label", validByLabel); err != nil {
+		return fmt.Errorf("failed to set valid_by_label field: %w", err)
+	}
+
+	if err := d.Set("errors_by_label", errorsByLabel); err != nil {
+		return fmt.Errorf("failed to set errors_by_label field: %w", err)
+	}
+
+	if err := d.Set("all_valid", allValid); err != nil {
+		return fmt.Errorf("failed to set all_valid field: %w", err)
+	}
+
+	d.SetId(hash(strings.Join(idParts, ":")))
+
+	return nil
+}
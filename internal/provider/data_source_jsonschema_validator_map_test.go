@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceJsonschemaValidatorMapRead(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	schemaFile := writeFile("env.schema.json", `{"type": "object", "required": ["region"]}`)
+	devFile := writeFile("dev.yaml", "region: eu-west-1\n")
+	stagingFile := writeFile("staging.json", `{"region": "eu-west-1"}`)
+	prodFile := writeFile("prod.json", `{"replicas": 3}`)
+	brokenFile := writeFile("broken.json", `{broken`)
+
+	config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}
+
+	type result struct {
+		label         string
+		document      string
+		valid         bool
+		errorContains string
+	}
+	tests := []struct {
+		name      string
+		documents map[string]interface{}
+		allValid  bool
+		expected  []result
+	}{
+		{
+			name:      "all documents valid",
+			documents: map[string]interface{}{"staging": stagingFile, "dev": devFile},
+			allValid:  true,
+			expected: []result{
+				{label: "dev", document: devFile, valid: true},
+				{label: "staging", document: stagingFile, valid: true},
+			},
+		},
+		{
+			name:      "invalid and unparseable documents are reported by label",
+			documents: map[string]interface{}{"prod": prodFile, "dev": devFile, "qa": brokenFile},
+			allValid:  false,
+			expected: []result{
+				{label: "dev", document: devFile, valid: true},
+				{label: "prod", document: prodFile, valid: false, errorContains: "missing property 'region'"},
+				{label: "qa", document: brokenFile, valid: false, errorContains: "failed to parse document file"},
+			},
+		},
+		{
+			name:      "one document under two labels",
+			documents: map[string]interface{}{"a": devFile, "b": devFile},
+			allValid:  true,
+			expected: []result{
+				{label: "a", document: devFile, valid: true},
+				{label: "b", document: devFile, valid: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidatorMap().Schema, map[string]interface{}{
+				"documents": tt.documents,
+				"schema":    schemaFile,
+			})
+
+			if err := dataSourceJsonschemaValidatorMapRead(resourceData, config); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := resourceData.Get("all_valid").(bool); got != tt.allValid {
+				t.Errorf("all_valid = %v, want %v", got, tt.allValid)
+			}
+
+			results := resourceData.Get("results").([]interface{})
+			if len(results) != len(tt.expected) {
+				t.Fatalf("expected %d results, got %d: %v", len(tt.expected), len(results), results)
+			}
+			validByLabel := resourceData.Get("valid_by_label").(map[string]interface{})
+			errorsByLabel := resourceData.Get("errors_by_label").(map[string]interface{})
+			for i, want := range tt.expected {
+				got := results[i].(map[string]interface{})
+				if got["label"] != want.label || got["document"] != want.document || got["valid"] != want.valid {
+					t.Errorf("results[%d] = %v, want label %q document %q valid %v", i, got, want.label, want.document, want.valid)
+				}
+				if validByLabel[want.label] != want.valid {
+					t.Errorf("valid_by_label[%q] = %v, want %v", want.label, validByLabel[want.label], want.valid)
+				}
+
+				errorMessage, hasError := errorsByLabel[want.label].(string)
+				if want.errorContains == "" {
+					if hasError || got["error"] != "" {
+						t.Errorf("%q: unexpected error %q", want.label, got["error"])
+					}
+					continue
+				}
+				if !strings.Contains(errorMessage, want.errorContains) || got["error"] != errorMessage {
+					t.Errorf("%q: error = %q, errors_by_label = %q, want to contain %q", want.label, got["error"], errorMessage, want.errorContains)
+				}
+			}
+
+			if resourceData.Id() == "" {
+				t.Error("expected ID to be set")
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorMapRead_Errors(t *testing.T) {
+	tempDir := t.TempDir()
+
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		raw           map[string]interface{}
+		errorContains string
+	}{
+		{
+			name:          "missing schema",
+			raw:           map[string]interface{}{"documents": map[string]interface{}{"dev": docFile}, "schema": filepath.Join(tempDir, "missing.schema.json")},
+			errorContains: "failed to parse schema file",
+		},
+		{
+			name:          "empty path",
+			raw:           map[string]interface{}{"documents": map[string]interface{}{"dev": ""}, "schema": schemaFile},
+			errorContains: `documents["dev"] must not be empty`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidatorMap().Schema, tt.raw)
+
+			err := dataSourceJsonschemaValidatorMapRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorMapRead_EmptyProviderTemplate(t *testing.T) {
+	tempDir := t.TempDir()
+
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidatorMap().Schema, map[string]interface{}{
+		"documents": map[string]interface{}{"dev": docFile},
+		"schema":    schemaFile,
+	})
+
+	// Without any template the error falls back to {{.FullMessage}}
+	if err := dataSourceJsonschemaValidatorMapRead(resourceData, &ProviderConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := resourceData.Get("errors_by_label").(map[string]interface{})["dev"].(string)
+	if !strings.Contains(got, "missing property 'name'") {
+		t.Errorf("errors_by_label[\"dev\"] = %q, want the full message", got)
+	}
+}
@@ -148,6 +148,81 @@ func TestProviderConfiguration(t *testing.T) {
 	})
 }
 
+// TestSchemaContentFromHCLObject verifies the documented way to pass a schema held as an
+// HCL object: jsonencode keeps whole numbers valid for "integer", bools and quoted "$" keys
+func TestSchemaContentFromHCLObject(t *testing.T) {
+	config := `
+locals {
+  schema = {
+    "$schema"            = "https://json-schema.org/draft/2020-12/schema"
+    type                 = "object"
+    required             = ["port"]
+    additionalProperties = false
+    properties = {
+      port = { type = "integer", minimum = 1 }
+    }
+  }
+}
+
+data "jsonschema_validator" "test" {
+  document_content = jsonencode({ port = %s })
+  schema_content   = jsonencode(local.schema)
+  fail_on_error    = false
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, "8080"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jsonschema_validator.test", "valid", "true"),
+					resource.TestCheckResourceAttr("data.jsonschema_validator.test", "valid_json", `{"port":8080}`),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, "0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jsonschema_validator.test", "valid", "false"),
+					resource.TestCheckResourceAttr("data.jsonschema_validator.test", "errors.0.document_path", "/port"),
+				),
+			},
+		},
+	})
+}
+
+// TestRequireKeysApplyDefaultsAnyMatch verifies that a require_keys-only check, which has
+// no schema to take defaults from, accepts apply_defaults with schema_match_mode = "any"
+func TestRequireKeysApplyDefaultsAnyMatch(t *testing.T) {
+	docFile := filepath.Join(t.TempDir(), "service.json")
+	if err := os.WriteFile(docFile, []byte(`{"kind":"Service","metadata":{"name":"web"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "jsonschema_validator" "test" {
+  document          = %q
+  require_keys      = ["kind", "metadata"]
+  apply_defaults    = true
+  schema_match_mode = "any"
+}
+`, docFile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jsonschema_validator.test", "valid", "true"),
+					resource.TestCheckResourceAttr("data.jsonschema_validator.test", "valid_json", `{"kind":"Service","metadata":{"name":"web"}}`),
+				),
+			},
+		},
+	})
+}
+
 // TestMultipleSchemasInSameDirectory verifies that schemas with different filenames work correctly
 // This is a breaking change from the previous implementation that hardcoded "schema.json" in the URL
 func TestMultipleSchemasInSameDirectory(t *testing.T) {
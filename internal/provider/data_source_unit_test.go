@@ -2,9 +2,13 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -204,6 +208,11 @@ func TestDataSourceJsonschemaValidatorRead(t *testing.T) {
 				t.Errorf("expected valid_json %q, got %q", tt.expectedValidJson, validJson)
 			}
 
+			// Single schema: matched_schema echoes the input
+			if matched := resourceData.Get("matched_schema").(string); matched != tt.schemaFile {
+				t.Errorf("expected matched_schema %q, got %q", tt.schemaFile, matched)
+			}
+
 			// Verify ID was set
 			if resourceData.Id() == "" {
 				t.Errorf("expected resource ID to be set")
@@ -691,6 +700,101 @@ func TestDataSourceJsonschemaValidatorRead_RefOverrides(t *testing.T) {
 	}
 }
 
+func TestDataSourceJsonschemaValidatorRead_RefOverridesContent(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// The remote URL is never fetched: every case resolves it from an override
+	baseSchemaFile := filepath.Join(tempDir, "base.schema.json")
+	baseSchemaContent := `{"type": "object", "properties": {"user": {"$ref": "https://example.com/schemas/user.json"}}}`
+	if err := os.WriteFile(baseSchemaFile, []byte(baseSchemaContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	strictUserFile := filepath.Join(tempDir, "user.schema.json")
+	if err := os.WriteFile(strictUserFile, []byte(`{"type": "object", "required": ["name", "email"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		documentContent string
+		refOverrides    map[string]interface{}
+		content         map[string]interface{}
+		expectError     bool
+		errorContains   string
+	}{
+		{
+			name:            "inline JSON content",
+			documentContent: `{"user": {"name": "John"}}`,
+			content: map[string]interface{}{
+				"https://example.com/schemas/user.json": `{"type": "object", "required": ["name"]}`,
+			},
+		},
+		{
+			name:            "inline YAML content",
+			documentContent: `{"user": {}}`,
+			content: map[string]interface{}{
+				"https://example.com/schemas/user.json": "type: object\nrequired: [name]\n",
+			},
+			expectError:   true,
+			errorContains: "missing property 'name'",
+		},
+		{
+			name:            "content takes precedence over ref_overrides",
+			documentContent: `{"user": {"name": "John"}}`,
+			refOverrides: map[string]interface{}{
+				"https://example.com/schemas/user.json": strictUserFile,
+			},
+			content: map[string]interface{}{
+				"https://example.com/schemas/user.json": `{"type": "object", "required": ["name"]}`,
+			},
+		},
+		{
+			name:            "invalid content",
+			documentContent: `{"user": {"name": "John"}}`,
+			content: map[string]interface{}{
+				"https://example.com/schemas/user.json": `{invalid json`,
+			},
+			expectError:   true,
+			errorContains: `ref_overrides_content: failed to parse content for URL "https://example.com/schemas/user.json"`,
+		},
+	}
+
+	config := &ProviderConfig{
+		DefaultErrorTemplate: "{{.FullMessage}}",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docFile := filepath.Join(tempDir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			if err := os.WriteFile(docFile, []byte(tt.documentContent), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document":              docFile,
+				"schema":                baseSchemaFile,
+				"ref_overrides":         tt.refOverrides,
+				"ref_overrides_content": tt.content,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain %q, got %q", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestDataSourceJsonschemaValidatorRead_SchemaCompilationErrors(t *testing.T) {
 	// Create temporary directory
 	tempDir, err := os.MkdirTemp("", "jsonschema_test_compile")
@@ -864,3 +968,3318 @@ func TestDataSourceJsonschemaValidatorRead_DraftHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestDataSourceJsonschemaValidatorRead_MultipleSchemas(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	baseSchema := writeFile("base.schema.json", `{"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}`)
+	overlaySchema := writeFile("overlay.schema.json", `{"type": "object", "required": ["team"], "properties": {"team": {"type": "string"}}}`)
+
+	tests := []struct {
+		name            string
+		documentContent string
+		schemas         []interface{}
+		matchMode       string
+		expectError     bool
+		expectedMatch   string
+		errorContains   []string
+		errorExcludes   []string
+	}{
+		{
+			name:            "passes all schemas",
+			documentContent: `{"name": "api", "team": "platform"}`,
+			schemas:         []interface{}{baseSchema, overlaySchema},
+			expectedMatch:   baseSchema,
+		},
+		{
+			name:            "fails overlay only",
+			documentContent: `{"name": "api"}`,
+			schemas:         []interface{}{baseSchema, overlaySchema},
+			expectError:     true,
+			errorContains:   []string{overlaySchema + ": at '': missing property 'team'"},
+			errorExcludes:   []string{baseSchema + ":"},
+		},
+		{
+			name:            "fails both schemas with labeled errors",
+			documentContent: `{"name": 42}`,
+			schemas:         []interface{}{baseSchema, overlaySchema},
+			expectError:     true,
+			errorContains: []string{
+				baseSchema + ": at '/name': got number, want string",
+				overlaySchema + ": at '': missing property 'team'",
+			},
+		},
+		{
+			name:            "any mode passes when one schema matches",
+			documentContent: `{"name": "api"}`,
+			schemas:         []interface{}{overlaySchema, baseSchema},
+			matchMode:       "any",
+			expectedMatch:   baseSchema,
+		},
+		{
+			name:            "any mode lists why every schema failed",
+			documentContent: `{"name": 42}`,
+			schemas:         []interface{}{baseSchema, overlaySchema},
+			matchMode:       "any",
+			expectError:     true,
+			errorContains: []string{
+				baseSchema + ": at '/name': got number, want string",
+				overlaySchema + ": at '': missing property 'team'",
+			},
+		},
+		{
+			name:            "unknown match mode",
+			documentContent: `{"name": "api"}`,
+			schemas:         []interface{}{baseSchema},
+			matchMode:       "one",
+			expectError:     true,
+			errorContains:   []string{"invalid schema_match_mode"},
+		},
+		{
+			name:            "single element list behaves like schema",
+			documentContent: `{"name": "api"}`,
+			schemas:         []interface{}{baseSchema},
+			expectedMatch:   baseSchema,
+		},
+	}
+
+	config := &ProviderConfig{
+		DefaultErrorTemplate: "{{range .Errors}}{{.SchemaFile}}: {{.Message}}\n{{end}}",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docFile := writeFile(strings.ReplaceAll(tt.name, " ", "_")+".json", tt.documentContent)
+
+			raw := map[string]interface{}{
+				"document": docFile,
+				"schemas":  tt.schemas,
+			}
+			if tt.matchMode != "" {
+				raw["schema_match_mode"] = tt.matchMode
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+
+			if !tt.expectError {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if resourceData.Get("valid_json").(string) == "" {
+					t.Errorf("expected valid_json to be set")
+				}
+				if got := resourceData.Get("matched_schema").(string); got != tt.expectedMatch {
+					t.Errorf("expected matched_schema %q, got %q", tt.expectedMatch, got)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error but got none")
+			}
+			for _, want := range tt.errorContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to contain %q, got %q", want, err.Error())
+				}
+			}
+			for _, unwanted := range tt.errorExcludes {
+				if strings.Contains(err.Error(), unwanted) {
+					t.Errorf("expected error not to contain %q, got %q", unwanted, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_FailOnError(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "test.schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		documentContent string
+		failOnError     interface{}
+		expectError     bool
+		expectValid     bool
+		expectMessage   string
+		expectValidJSON bool
+	}{
+		{
+			name:            "default fails on invalid document",
+			documentContent: `{}`,
+			expectError:     true,
+		},
+		{
+			name:            "soft failure on invalid document",
+			documentContent: `{}`,
+			failOnError:     false,
+			expectValid:     false,
+			expectMessage:   "missing property 'name'",
+		},
+		{
+			name:            "soft mode with valid document",
+			documentContent: `{"name": "ok"}`,
+			failOnError:     false,
+			expectValid:     true,
+			expectValidJSON: true,
+		},
+		{
+			name:            "valid document with defaults",
+			documentContent: `{"name": "ok"}`,
+			expectValid:     true,
+			expectValidJSON: true,
+		},
+	}
+
+	config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docFile := filepath.Join(tempDir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			if err := os.WriteFile(docFile, []byte(tt.documentContent), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			raw := map[string]interface{}{
+				"document": docFile,
+				"schema":   schemaFile,
+			}
+			if tt.failOnError != nil {
+				raw["fail_on_error"] = tt.failOnError
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := resourceData.Get("valid").(bool); got != tt.expectValid {
+				t.Errorf("expected valid %v, got %v", tt.expectValid, got)
+			}
+
+			message := resourceData.Get("validation_errors").(string)
+			if tt.expectMessage == "" && message != "" {
+				t.Errorf("expected empty validation_errors, got %q", message)
+			}
+			if tt.expectMessage != "" && !strings.Contains(message, tt.expectMessage) {
+				t.Errorf("expected validation_errors to contain %q, got %q", tt.expectMessage, message)
+			}
+
+			if validJSON := resourceData.Get("valid_json").(string); (validJSON != "") != tt.expectValidJSON {
+				t.Errorf("expected valid_json set=%v, got %q", tt.expectValidJSON, validJSON)
+			}
+
+			if resourceData.Id() == "" {
+				t.Errorf("expected resource ID to be set")
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ErrorsAttribute(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "test.schema.json")
+	schemaContent := `{
+		"type": "object",
+		"properties": {
+			"items": {"type": "array", "items": {"type": "string"}},
+			"port": {"type": "integer"}
+		}
+	}`
+	if err := os.WriteFile(schemaFile, []byte(schemaContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{"items": ["a", 2], "port": "80"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+		"document":      docFile,
+		"schema":        schemaFile,
+		"fail_on_error": false,
+	})
+
+	if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := resourceData.Get("errors.#").(int); got != 2 {
+		t.Fatalf("expected 2 errors, got %d", got)
+	}
+
+	expected := []struct {
+		documentPath string
+		schemaPath   string
+		value        string
+		valueType    string
+	}{
+		{"/items/1", "#/properties/items/items/type", "2", "number"},
+		{"/port", "#/properties/port/type", `"80"`, "string"},
+	}
+	for i, want := range expected {
+		prefix := fmt.Sprintf("errors.%d.", i)
+		if got := resourceData.Get(prefix + "document_path").(string); got != want.documentPath {
+			t.Errorf("errors[%d].document_path = %q, want %q", i, got, want.documentPath)
+		}
+		if got := resourceData.Get(prefix + "schema_path").(string); !strings.HasSuffix(got, want.schemaPath) {
+			t.Errorf("errors[%d].schema_path = %q, want suffix %q", i, got, want.schemaPath)
+		}
+		if got := resourceData.Get(prefix + "value").(string); got != want.value {
+			t.Errorf("errors[%d].value = %q, want %q", i, got, want.value)
+		}
+		if got := resourceData.Get(prefix + "value_type").(string); got != want.valueType {
+			t.Errorf("errors[%d].value_type = %q, want %q", i, got, want.valueType)
+		}
+		if got := resourceData.Get(prefix + "message").(string); got == "" {
+			t.Errorf("errors[%d].message is empty", i)
+		}
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_RemoteSchema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schemas/config.schema.json":
+			_, _ = w.Write([]byte(`{"type": "object", "properties": {"port": {"$ref": "types.schema.json#/$defs/port"}}}`))
+		case "/schemas/types.schema.json":
+			_, _ = w.Write([]byte(`{"$defs": {"port": {"type": "integer", "maximum": 65535}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tempDir := t.TempDir()
+	createDocFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name          string
+		schema        string
+		document      string
+		timeout       string
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:     "valid document against remote schema with relative ref",
+			schema:   server.URL + "/schemas/config.schema.json",
+			document: createDocFile("valid.json", `{"port": 8080}`),
+		},
+		{
+			name:          "invalid document against remote schema",
+			schema:        server.URL + "/schemas/config.schema.json",
+			document:      createDocFile("invalid.json", `{"port": 70000}`),
+			expectError:   true,
+			errorContains: "maximum",
+		},
+		{
+			name:          "missing remote schema",
+			schema:        server.URL + "/schemas/missing.json",
+			document:      createDocFile("any.json", `{}`),
+			expectError:   true,
+			errorContains: "failed to fetch schema",
+		},
+		{
+			name:          "invalid fetch timeout",
+			schema:        server.URL + "/schemas/config.schema.json",
+			document:      createDocFile("timeout.json", `{}`),
+			timeout:       "soon",
+			expectError:   true,
+			errorContains: "invalid schema_fetch_timeout",
+		},
+	}
+
+	config := &ProviderConfig{
+		DefaultErrorTemplate: "{{.FullMessage}}",
+		SchemaCacheDir:       filepath.Join(tempDir, "cache"),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"document": tt.document,
+				"schema":   tt.schema,
+			}
+			if tt.timeout != "" {
+				raw["schema_fetch_timeout"] = tt.timeout
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain %q, got %q", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resourceData.Get("matched_schema").(string) != tt.schema {
+				t.Errorf("expected matched_schema %q, got %q", tt.schema, resourceData.Get("matched_schema"))
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_StrictFormat(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	emailSchema := writeFile("email.schema.json", `{"type": "object", "properties": {"email": {"type": "string", "format": "email"}}}`)
+	typoSchema := writeFile("typo.schema.json", `{"type": "object", "properties": {"email": {"type": "string", "format": "e-mail"}}}`)
+	invalidDoc := writeFile("invalid.json", `{"email": "not-an-email"}`)
+	validDoc := writeFile("valid.json", `{"email": "dev@example.com"}`)
+
+	tests := []struct {
+		name          string
+		schemaFile    string
+		document      string
+		dataSource    bool
+		provider      bool
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:       "format is an annotation by default",
+			schemaFile: emailSchema,
+			document:   invalidDoc,
+		},
+		{
+			name:          "data source strict_format asserts format",
+			schemaFile:    emailSchema,
+			document:      invalidDoc,
+			dataSource:    true,
+			expectError:   true,
+			errorContains: "is not valid email",
+		},
+		{
+			name:          "provider strict_format asserts format",
+			schemaFile:    emailSchema,
+			document:      invalidDoc,
+			provider:      true,
+			expectError:   true,
+			errorContains: "is not valid email",
+		},
+		{
+			name:       "valid value passes strict format",
+			schemaFile: emailSchema,
+			document:   validDoc,
+			dataSource: true,
+		},
+		{
+			name:          "unknown format is a compile error",
+			schemaFile:    typoSchema,
+			document:      validDoc,
+			dataSource:    true,
+			expectError:   true,
+			errorContains: "unknown format(s) e-mail",
+		},
+		{
+			name:       "unknown format ignored without strict_format",
+			schemaFile: typoSchema,
+			document:   validDoc,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document":      tt.document,
+				"schema":        tt.schemaFile,
+				"strict_format": tt.dataSource,
+			})
+
+			config := &ProviderConfig{
+				DefaultErrorTemplate: "{{.FullMessage}}",
+				StrictFormat:         tt.provider,
+			}
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain %q, got %q", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_CustomFormats(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "employee.schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "properties": {"id": {"type": "string", "format": "employee-id"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}", StrictFormat: true}
+	if err := config.SetCustomFormats(map[string]interface{}{"employee-id": "^E[0-9]{6}$"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		document      string
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:     "matching value",
+			document: `{"id": "E123456"}`,
+		},
+		{
+			name:          "non-matching value names the format",
+			document:      `{"id": "X1"}`,
+			expectError:   true,
+			errorContains: "is not valid employee-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docFile := filepath.Join(tempDir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			if err := os.WriteFile(docFile, []byte(tt.document), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document": docFile,
+				"schema":   schemaFile,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain %q, got %q", tt.errorContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_Extract(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "test.schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	docFile := filepath.Join(tempDir, "doc.yaml")
+	docContent := `config:
+  servers:
+    - port: 8080
+      tags: {b: 2, a: 1}
+  "a/b": slash
+`
+	if err := os.WriteFile(docFile, []byte(docContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		extract       string
+		expected      string
+		errorContains string
+	}{
+		{
+			name:     "scalar",
+			extract:  "/config/servers/0/port",
+			expected: "8080",
+		},
+		{
+			name:     "object is encoded deterministically",
+			extract:  "/config/servers/0/tags",
+			expected: `{"a":1,"b":2}`,
+		},
+		{
+			name:     "escaped slash",
+			extract:  "/config/a~1b",
+			expected: `"slash"`,
+		},
+		{
+			name:          "unresolvable pointer",
+			extract:       "/config/servers/1/port",
+			errorContains: `extract: JSON pointer "/config/servers/1/port" does not resolve`,
+		},
+		{
+			name:          "invalid pointer",
+			extract:       "config",
+			errorContains: "must be empty or start with",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document": docFile,
+				"schema":   schemaFile,
+				"extract":  tt.extract,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := resourceData.Get("extracted_value").(string); got != tt.expected {
+				t.Errorf("extracted_value = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ReportDeprecations(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "config.schema.json")
+	schemaContent := `{
+		"type": "object",
+		"properties": {
+			"host": {"type": "string", "x-deprecated": "use endpoint"},
+			"endpoint": {"type": "string"},
+			"servers": {
+				"type": "array",
+				"items": {"properties": {"weight": {"type": "integer", "x-deprecated": true}}}
+			}
+		}
+	}`
+	if err := os.WriteFile(schemaFile, []byte(schemaContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	docFile := filepath.Join(tempDir, "config.json")
+	if err := os.WriteFile(docFile, []byte(`{"host": "a", "endpoint": "b", "servers": [{}, {"weight": 2}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		report   bool
+		expected []string
+	}{
+		{
+			name:   "reported when enabled",
+			report: true,
+			expected: []string{
+				`/host: property "host" is deprecated: use endpoint`,
+				`/servers/1/weight: property "weight" is deprecated`,
+			},
+		},
+		{
+			name:     "empty when disabled",
+			report:   false,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document":            docFile,
+				"schema":              schemaFile,
+				"report_deprecations": tt.report,
+			})
+
+			if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+				t.Fatalf("deprecations must not fail validation: %v", err)
+			}
+
+			raw := resourceData.Get("warnings").([]interface{})
+			got := make([]string, len(raw))
+			for i, w := range raw {
+				got[i] = w.(string)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("warnings = %q, want %q", got, tt.expected)
+			}
+			if !resourceData.Get("valid").(bool) {
+				t.Error("expected valid = true")
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_JSONL(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "event.schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	validFile := filepath.Join(tempDir, "valid.jsonl")
+	if err := os.WriteFile(validFile, []byte("{\"id\": 2}\n\n{\"id\": 1}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	invalidFile := filepath.Join(tempDir, "invalid.ndjson")
+	if err := os.WriteFile(invalidFile, []byte("{\"id\": 1}\n{\"id\": \"x\"}\n{broken\n{\"id\": 4}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}
+
+	t.Run("valid records", func(t *testing.T) {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document": validFile,
+			"schema":   schemaFile,
+		})
+		if err := dataSourceJsonschemaValidatorRead(resourceData, config); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := resourceData.Get("valid_json").(string); got != `[{"id":2},{"id":1}]` {
+			t.Errorf("valid_json = %q", got)
+		}
+	})
+
+	t.Run("errors report line numbers and a malformed line does not abort", func(t *testing.T) {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document": invalidFile,
+			"schema":   schemaFile,
+		})
+		err := dataSourceJsonschemaValidatorRead(resourceData, config)
+		if err == nil {
+			t.Fatal("expected validation error")
+		}
+		for _, want := range []string{"- line 2 at '/id':", "- line 3: invalid JSON:"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error missing %q:\n%s", want, err.Error())
+			}
+		}
+	})
+
+	t.Run("soft mode exposes line in errors", func(t *testing.T) {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document":      invalidFile,
+			"schema":        schemaFile,
+			"fail_on_error": false,
+		})
+		if err := dataSourceJsonschemaValidatorRead(resourceData, config); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := resourceData.Get("errors.#").(int); got != 2 {
+			t.Fatalf("expected 2 errors, got %d", got)
+		}
+		for i, line := range []int{2, 3} {
+			if got := resourceData.Get(fmt.Sprintf("errors.%d.line", i)).(int); got != line {
+				t.Errorf("errors[%d].line = %d, want %d", i, got, line)
+			}
+		}
+	})
+}
+
+func TestDataSourceJsonschemaValidatorRead_SchemaSHA256(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// The same schema in three representations, formatted differently
+	schemaFiles := map[string]string{
+		"schema.json":  `{"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}`,
+		"schema.json5": "{\n  // comment\n  properties: {name: {type: 'string'}},\n  required: ['name'],\n  type: 'object',\n}",
+		"schema.yaml":  "type: object\nrequired:\n  - name\nproperties:\n  name:\n    type: string\n",
+	}
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{"name": "a"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}
+	readHash := func(schemaContent, name string) string {
+		t.Helper()
+		schemaFile := filepath.Join(tempDir, name)
+		if err := os.WriteFile(schemaFile, []byte(schemaContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document": docFile,
+			"schema":   schemaFile,
+		})
+		if err := dataSourceJsonschemaValidatorRead(resourceData, config); err != nil {
+			t.Fatalf("unexpected error for %s: %v", name, err)
+		}
+		return resourceData.Get("schema_sha256").(string)
+	}
+
+	expected := hash(`{"properties":{"name":{"type":"string"}},"required":["name"],"type":"object"}`)
+	for name, content := range schemaFiles {
+		if got := readHash(content, name); got != expected {
+			t.Errorf("schema_sha256 for %s = %q, want %q", name, got, expected)
+		}
+	}
+
+	if got := readHash(`{"type": "object"}`, "changed.json"); got == expected {
+		t.Error("schema_sha256 must change when the schema changes")
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_DocumentSHA256(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same document in four representations, formatted differently
+	documentFiles := map[string]string{
+		"doc.json":  `{"name": "a", "tags": ["x", "y"], "port": 80}`,
+		"doc.json5": "{\n  // comment\n  port: 80,\n  tags: ['x', 'y'],\n  name: 'a',\n}",
+		"doc.yaml":  "tags:\n  - x\n  - y\nname: a\nport: 80\n",
+		"doc.toml":  "port = 80\nname = \"a\"\ntags = [\"x\", \"y\"]\n",
+	}
+
+	config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}
+	readHash := func(documentContent, name string) (string, string) {
+		t.Helper()
+		documentFile := filepath.Join(tempDir, name)
+		if err := os.WriteFile(documentFile, []byte(documentContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document":      documentFile,
+			"schema":        schemaFile,
+			"fail_on_error": false,
+		})
+		if err := dataSourceJsonschemaValidatorRead(resourceData, config); err != nil {
+			t.Fatalf("unexpected error for %s: %v", name, err)
+		}
+		return resourceData.Get("document_sha256").(string), resourceData.Get("valid_json").(string)
+	}
+
+	expected := hash(`{"name":"a","port":80,"tags":["x","y"]}`)
+	for name, content := range documentFiles {
+		got, validJSON := readHash(content, name)
+		if got != expected {
+			t.Errorf("document_sha256 for %s = %q, want %q", name, got, expected)
+		}
+		if got != hash(validJSON) {
+			t.Errorf("document_sha256 for %s is not the hash of valid_json %s", name, validJSON)
+		}
+	}
+
+	if got, _ := readHash(`{"name": "b", "tags": ["x", "y"], "port": 80}`, "changed.json"); got == expected {
+		t.Error("document_sha256 must change when the document changes")
+	}
+	if got, _ := readHash(`{"tags": []}`, "invalid.json"); got != hash(`{"tags":[]}`) {
+		t.Errorf("document_sha256 for an invalid document = %q, want the hash of its canonical JSON", got)
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_NamedErrorTemplate(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "test.schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "properties": {"port": {"type": "integer"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{"port": "80"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		template      string
+		errorContains string
+	}{
+		{
+			name:          "named template",
+			template:      "@detailed",
+			errorContains: "1 validation error(s) found:\n1. at '/port': got string, want integer at /port",
+		},
+		{
+			name:          "raw template",
+			template:      "{{.ErrorCount}} problem(s)",
+			errorContains: "1 problem(s)",
+		},
+		{
+			name:          "unknown name",
+			template:      "@fancy",
+			errorContains: `error_message_template: unknown error template "@fancy" (available: @basic,`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document":               docFile,
+				"schema":                 schemaFile,
+				"error_message_template": tt.template,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_DynamicRef(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// The generic tree's children use $dynamicRef, so when entered through strict-tree
+	// they resolve to strict-tree (dynamic scope) and reject unknown properties at every depth
+	schemaFile := filepath.Join(tempDir, "strict-tree.schema.json")
+	schemaContent := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "https://example.com/strict-tree",
+		"$defs": {
+			"tree": {
+				"$id": "https://example.com/tree",
+				"$dynamicAnchor": "node",
+				"type": "object",
+				"properties": {
+					"data": true,
+					"children": {"type": "array", "items": {"$dynamicRef": "#node"}}
+				}
+			},
+			"strictTree": {
+				"$id": "https://example.com/strict-tree",
+				"$dynamicAnchor": "node",
+				"$ref": "tree",
+				"unevaluatedProperties": false
+			}
+		}
+	}`
+	if err := os.WriteFile(schemaFile, []byte(schemaContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		document      string
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:     "valid nested tree",
+			document: `{"data": 1, "children": [{"data": 2, "children": [{"data": 3}]}]}`,
+		},
+		{
+			name:          "misspelled property at depth",
+			document:      `{"children": [{"children": [{"daat": 3}]}]}`,
+			expectError:   true,
+			errorContains: "/children/0/children/0",
+		},
+	}
+
+	for _, schemaVersion := range []string{"", "draft/2020-12"} {
+		for _, tt := range tests {
+			t.Run(tt.name+"/"+schemaVersion, func(t *testing.T) {
+				docFile := filepath.Join(tempDir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+				if err := os.WriteFile(docFile, []byte(tt.document), 0644); err != nil {
+					t.Fatal(err)
+				}
+
+				resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+					"document":       docFile,
+					"schema":         schemaFile,
+					"schema_version": schemaVersion,
+				})
+
+				err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+				if tt.expectError {
+					if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+						t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_Offline(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"type": "string"}`))
+	}))
+	defer server.Close()
+
+	tempDir := t.TempDir()
+
+	remoteRefSchema := filepath.Join(tempDir, "remote-ref.schema.json")
+	if err := os.WriteFile(remoteRefSchema, []byte(`{"properties": {"name": {"$ref": "`+server.URL+`/name.json"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	localNameSchema := filepath.Join(tempDir, "name.schema.json")
+	if err := os.WriteFile(localNameSchema, []byte(`{"type": "string"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{"name": "a"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		schema        string
+		refOverrides  map[string]interface{}
+		errorContains string
+	}{
+		{
+			name:          "remote $ref is rejected",
+			schema:        remoteRefSchema,
+			errorContains: "network access is disabled (provider offline = true); add \"" + server.URL + "/name.json\" to ref_overrides",
+		},
+		{
+			name:          "remote root schema is rejected",
+			schema:        server.URL + "/name.json",
+			errorContains: "network access is disabled",
+		},
+		{
+			name:   "overridden $ref still works",
+			schema: remoteRefSchema,
+			refOverrides: map[string]interface{}{
+				server.URL + "/name.json": localNameSchema,
+			},
+		},
+	}
+
+	config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}", Offline: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document":      docFile,
+				"schema":        tt.schema,
+				"ref_overrides": tt.refOverrides,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	if got := requests.Load(); got != 0 {
+		t.Errorf("offline mode made %d HTTP request(s)", got)
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_InlineContent(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaContent := `{"type": "object", "required": ["name"]}`
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(schemaContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	validDocFile := filepath.Join(tempDir, "valid.yaml")
+	if err := os.WriteFile(validDocFile, []byte("name: John\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		raw           map[string]interface{}
+		expectedJSON  string
+		errorContains string
+	}{
+		{
+			name:         "file schema, file document",
+			raw:          map[string]interface{}{"schema": schemaFile, "document": validDocFile},
+			expectedJSON: `{"name":"John"}`,
+		},
+		{
+			name:         "inline schema, file document",
+			raw:          map[string]interface{}{"schema_content": schemaContent, "document": validDocFile},
+			expectedJSON: `{"name":"John"}`,
+		},
+		{
+			name:         "file schema, inline document",
+			raw:          map[string]interface{}{"schema": schemaFile, "document_content": `{"name": "Jane"}`},
+			expectedJSON: `{"name":"Jane"}`,
+		},
+		{
+			name:         "inline schema, inline YAML document",
+			raw:          map[string]interface{}{"schema_content": "type: object\nrequired: [name]\n", "document_content": "name: Jim\n"},
+			expectedJSON: `{"name":"Jim"}`,
+		},
+		{
+			name:          "inline schema, invalid inline document",
+			raw:           map[string]interface{}{"schema_content": schemaContent, "document_content": `{}`},
+			errorContains: "missing property 'name'",
+		},
+		{
+			name:          "malformed schema content",
+			raw:           map[string]interface{}{"schema_content": `{"type": `, "document": validDocFile},
+			errorContains: "failed to parse schema_content",
+		},
+		{
+			name:          "malformed document content",
+			raw:           map[string]interface{}{"schema": schemaFile, "document_content": `{"name": `},
+			errorContains: "failed to parse document_content",
+		},
+	}
+
+	config := &ProviderConfig{
+		DefaultErrorTemplate: "{{.FullMessage}}",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, tt.raw)
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := resourceData.Get("valid_json").(string); got != tt.expectedJSON {
+				t.Errorf("valid_json = %s, want %s", got, tt.expectedJSON)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_RefOverridesMirror(t *testing.T) {
+	tempDir := t.TempDir()
+
+	mirrorDir := filepath.Join(tempDir, "mirror")
+	for name, content := range map[string]string{
+		"users/name.json":    `{"type": "string", "minLength": 1}`,
+		"common/v1/age.yaml": "type: integer\nminimum: 0\n",
+	} {
+		path := filepath.Join(mirrorDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	schemaContent := `{
+		"type": "object",
+		"properties": {
+			"name": {"$ref": "https://schemas.example.com/users/name.json"},
+			"age": {"$ref": "https://schemas.example.com/common/v1/age.yaml"}
+		}
+	}`
+	if err := os.WriteFile(schemaFile, []byte(schemaContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		document      string
+		refOverrides  map[string]interface{}
+		errorContains string
+	}{
+		{
+			name:         "both refs resolve under the mirror directory",
+			document:     `{"name": "John", "age": 42}`,
+			refOverrides: map[string]interface{}{"https://schemas.example.com/**": mirrorDir},
+		},
+		{
+			name:          "mirrored schemas are enforced",
+			document:      `{"name": "John", "age": -1}`,
+			refOverrides:  map[string]interface{}{"https://schemas.example.com/**": mirrorDir},
+			errorContains: "minimum",
+		},
+		{
+			name:     "exact override takes precedence over a pattern",
+			document: `{"name": "", "age": 42}`,
+			refOverrides: map[string]interface{}{
+				"https://schemas.example.com/**":              mirrorDir,
+				"https://schemas.example.com/users/name.json": filepath.Join(mirrorDir, "common/v1/age.yaml"),
+			},
+			errorContains: "want integer",
+		},
+		{
+			name:          "missing file under the mirror",
+			document:      `{"name": "John"}`,
+			refOverrides:  map[string]interface{}{"https://schemas.example.com/**": filepath.Join(mirrorDir, "users")},
+			errorContains: `failed to load`,
+		},
+		{
+			name:          "URLs outside the pattern still need an override",
+			document:      `{"name": "John"}`,
+			refOverrides:  map[string]interface{}{"https://schemas.example.com/users/**": filepath.Join(mirrorDir, "users")},
+			errorContains: "network access is disabled",
+		},
+	}
+
+	config := &ProviderConfig{
+		DefaultErrorTemplate: "{{.FullMessage}}",
+		Offline:              true,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docFile := filepath.Join(tempDir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			if err := os.WriteFile(docFile, []byte(tt.document), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document":      docFile,
+				"schema":        schemaFile,
+				"ref_overrides": tt.refOverrides,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ProviderRefOverrides(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	const userURL = "https://example.com/schemas/user.json"
+	schemaFile := writeFile("schema.json", `{"type": "object", "properties": {"user": {"$ref": "`+userURL+`"}}}`)
+	lenientUser := writeFile("lenient.json", `{"type": "object"}`)
+	strictUser := writeFile("strict.json", `{"type": "object", "required": ["name"]}`)
+	docFile := writeFile("document.json", `{"user": {}}`)
+
+	tests := []struct {
+		name             string
+		providerOverride map[string]string
+		resourceOverride map[string]interface{}
+		errorContains    string
+	}{
+		{
+			name:             "provider-level override applies",
+			providerOverride: map[string]string{userURL: strictUser},
+			errorContains:    "missing property 'name'",
+		},
+		{
+			name:             "data source override wins",
+			providerOverride: map[string]string{userURL: strictUser},
+			resourceOverride: map[string]interface{}{userURL: lenientUser},
+		},
+		{
+			name:          "no override anywhere",
+			errorContains: "network access is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &ProviderConfig{
+				DefaultErrorTemplate: "{{.FullMessage}}",
+				Offline:              true,
+				RefOverrides:         tt.providerOverride,
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document":      docFile,
+				"schema":        schemaFile,
+				"ref_overrides": tt.resourceOverride,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_EffectiveDraft(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	plainSchema := writeFile("plain.json", `{"type": "object"}`)
+	draft7Schema := writeFile("draft7.json", `{"$schema": "http://json-schema.org/draft-07/schema#", "type": "object"}`)
+	docFile := writeFile("document.json", `{}`)
+
+	tests := []struct {
+		name           string
+		raw            map[string]interface{}
+		providerConfig *ProviderConfig
+		want           string
+	}{
+		{
+			name: "fallback to draft 2020-12",
+			raw:  map[string]interface{}{"schema": plainSchema},
+			want: "draft/2020-12",
+		},
+		{
+			name:           "provider default",
+			raw:            map[string]interface{}{"schema": plainSchema},
+			providerConfig: &ProviderConfig{DefaultSchemaVersion: "draft-06"},
+			want:           "draft-06",
+		},
+		{
+			name:           "data source override beats provider default",
+			raw:            map[string]interface{}{"schema": plainSchema, "schema_version": "draft/2019-09"},
+			providerConfig: &ProviderConfig{DefaultSchemaVersion: "draft-06"},
+			want:           "draft/2019-09",
+		},
+		{
+			name:           "$schema beats every default",
+			raw:            map[string]interface{}{"schema": draft7Schema, "schema_version": "draft/2019-09"},
+			providerConfig: &ProviderConfig{DefaultSchemaVersion: "draft-06"},
+			want:           "draft-07",
+		},
+		{
+			name: "distinct drafts of several schemas",
+			raw:  map[string]interface{}{"schemas": []interface{}{plainSchema, draft7Schema, plainSchema}},
+			want: "draft/2020-12, draft-07",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.providerConfig
+			if config == nil {
+				config = &ProviderConfig{}
+			}
+			config.DefaultErrorTemplate = "{{.FullMessage}}"
+
+			tt.raw["document"] = docFile
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, tt.raw)
+			if err := dataSourceJsonschemaValidatorRead(resourceData, config); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := resourceData.Get("effective_draft").(string); got != tt.want {
+				t.Errorf("effective_draft = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_DraftFromSchemaKeyword(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// Both schemas only compile, and only behave as below, under their declared draft:
+	// a boolean exclusiveMaximum is draft-04 syntax, and array-form items with
+	// additionalItems is a draft-07 tuple (ignored as "items" by draft 2020-12)
+	draft4Schema := writeFile("draft4.json", `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type": "object",
+		"properties": {"port": {"type": "integer", "maximum": 65535, "exclusiveMaximum": true}}
+	}`)
+	draft7Schema := writeFile("draft7.json", `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "array",
+		"items": [{"type": "string"}],
+		"additionalItems": false
+	}`)
+
+	tests := []struct {
+		name          string
+		schema        string
+		document      string
+		wantDraft     string
+		errorContains string
+	}{
+		{name: "draft-04 valid", schema: draft4Schema, document: `{"port": 8080}`, wantDraft: "draft-04"},
+		{name: "draft-04 boolean exclusiveMaximum", schema: draft4Schema, document: `{"port": 65535}`, errorContains: "exclusiveMaximum"},
+		{name: "draft-07 valid tuple", schema: draft7Schema, document: `["a"]`, wantDraft: "draft-07"},
+		{name: "draft-07 additionalItems", schema: draft7Schema, document: `["a", "b"]`, errorContains: "additionalItem"},
+	}
+
+	// The provider default is always set; $schema must still take precedence
+	config := &ProviderConfig{
+		DefaultSchemaVersion: "draft/2020-12",
+		DefaultErrorTemplate: "{{.FullMessage}}",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docFile := writeFile(strings.ReplaceAll(tt.name, " ", "_")+".json", tt.document)
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document": docFile,
+				"schema":   tt.schema,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := resourceData.Get("effective_draft").(string); got != tt.wantDraft {
+				t.Errorf("effective_draft = %q, want %q", got, tt.wantDraft)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_SelfDescribing(t *testing.T) {
+	tempDir := t.TempDir()
+
+	documentFile := filepath.Join(tempDir, "payload.yaml")
+	if err := os.WriteFile(documentFile, []byte(`
+$schema: "#/schemas/user"
+name: John
+address: {city: Berlin}
+schemas:
+  user:
+    type: object
+    required: [name, address]
+    properties:
+      address: {$ref: "#/schemas/address"}
+  address:
+    type: object
+    required: [city]
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		raw           map[string]interface{}
+		errorContains string
+	}{
+		{
+			name: "bundled schema with local refs",
+			raw:  map[string]interface{}{"self_describing": true, "document": documentFile},
+		},
+		{
+			name:          "document violates its bundled schema",
+			raw:           map[string]interface{}{"self_describing": true, "document_content": `{"$schema": "#/schemas/user", "schemas": {"user": {"required": ["name"]}}}`},
+			errorContains: "missing property 'name'",
+		},
+		{
+			name:          "missing $schema",
+			raw:           map[string]interface{}{"self_describing": true, "document_content": `{"name": "John"}`},
+			errorContains: `self_describing: document has no "$schema" keyword`,
+		},
+		{
+			name:          "unresolved $schema",
+			raw:           map[string]interface{}{"self_describing": true, "document_content": `{"$schema": "#/schemas/user"}`},
+			errorContains: "does not resolve within the document",
+		},
+	}
+
+	config := &ProviderConfig{
+		DefaultErrorTemplate: "{{.FullMessage}}",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, tt.raw)
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := resourceData.Get("matched_schema").(string); got != selfDescribingSource {
+				t.Errorf("matched_schema = %q, want %q", got, selfDescribingSource)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_Discriminator(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	bucketSchema := writeFile("bucket.schema.json", `{"type": "object", "required": ["kind", "region"]}`)
+	queueSchema := writeFile("queue.schema.json", `{"type": "object", "required": ["kind", "retention"]}`)
+	versionSchema := writeFile("v2.schema.json", `{"type": "object", "required": ["version"]}`)
+	mapping := map[string]interface{}{"bucket": bucketSchema, "queue": queueSchema, "2": versionSchema}
+
+	tests := []struct {
+		name          string
+		document      string
+		field         string
+		wantSchema    string
+		errorContains string
+	}{
+		{name: "first mapping", document: `{"kind": "bucket", "region": "eu"}`, wantSchema: bucketSchema},
+		{name: "second mapping", document: "kind: queue\nretention: 7\n", wantSchema: queueSchema},
+		{
+			name:          "mapped schema rejects the document",
+			document:      `{"kind": "queue", "region": "eu"}`,
+			errorContains: "missing property 'retention'",
+		},
+		{name: "non-string value", document: `{"version": 2}`, field: "version", wantSchema: versionSchema},
+		{
+			name:          "unmapped value",
+			document:      `{"kind": "topic"}`,
+			errorContains: `discriminator: no schema in discriminator_map for kind = "topic" (mapped values: 2, bucket, queue)`,
+		},
+		{
+			name:          "missing property",
+			document:      `{"region": "eu"}`,
+			errorContains: `discriminator: document has no "kind" property`,
+		},
+		{
+			name:          "non-object document",
+			document:      `["bucket"]`,
+			errorContains: `discriminator: document must be an object to read property "kind"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.field
+			if field == "" {
+				field = "kind"
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document_content":  tt.document,
+				"discriminator":     field,
+				"discriminator_map": mapping,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := resourceData.Get("matched_schema").(string); got != tt.wantSchema {
+				t.Errorf("matched_schema = %q, want %q", got, tt.wantSchema)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ReportUnknownKeys(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{
+		"type": "object",
+		"properties": {
+			"server": {"type": "object", "additionalProperties": false, "properties": {"host": {"type": "string"}}}
+		}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	documentContent := `{"server": {"host": "example.com", "prot": 80, "tls": true}}`
+
+	tests := []struct {
+		name        string
+		unknownKeys bool
+		wantPaths   []string
+	}{
+		{name: "default", wantPaths: []string{"/server"}},
+		{name: "report_unknown_keys", unknownKeys: true, wantPaths: []string{"/server/prot", "/server/tls"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"schema":              schemaFile,
+				"document_content":    documentContent,
+				"report_unknown_keys": tt.unknownKeys,
+				"fail_on_error":       false,
+			})
+
+			if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var gotPaths []string
+			for _, e := range resourceData.Get("errors").([]interface{}) {
+				gotPaths = append(gotPaths, e.(map[string]interface{})["document_path"].(string))
+			}
+			if strings.Join(gotPaths, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Errorf("error paths = %v, want %v", gotPaths, tt.wantPaths)
+			}
+			if tt.unknownKeys && !strings.Contains(resourceData.Get("validation_errors").(string), "unexpected property 'tls'") {
+				t.Errorf("validation_errors = %q", resourceData.Get("validation_errors"))
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_DataKeyword(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{
+		"type": "object",
+		"properties": {
+			"max_replicas": {"type": "integer"},
+			"replicas": {"type": "integer", "maximum": {"$data": "1/max_replicas"}}
+		}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		enabled       bool
+		document      string
+		errorContains string
+	}{
+		{
+			name:     "within the sibling maximum",
+			enabled:  true,
+			document: `{"max_replicas": 5, "replicas": 5}`,
+		},
+		{
+			name:          "above the sibling maximum",
+			enabled:       true,
+			document:      `{"max_replicas": 5, "replicas": 6}`,
+			errorContains: "at '/replicas': maximum: got 6, want 5",
+		},
+		{
+			name:          "disabled",
+			document:      `{"max_replicas": 5, "replicas": 5}`,
+			errorContains: "failed to compile schema",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"schema":              schemaFile,
+				"document_content":    tt.document,
+				"enable_data_keyword": tt.enabled,
+			})
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ErrorsByPath(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{
+		"type": "object",
+		"required": ["name", "team"],
+		"properties": {
+			"name": {"type": "string", "minLength": 5, "pattern": "^[a-z]+$"},
+			"port": {"type": "integer"}
+		}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+		"schema":           schemaFile,
+		"document_content": `{"name": "AB", "port": "80"}`,
+		"fail_on_error":    false,
+	})
+	if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := resourceData.Get("errors_by_path").(map[string]interface{})
+	want := map[string]string{
+		"":      "missing property 'team'",
+		"/name": "'AB' does not match pattern '^[a-z]+$'; minLength: got 2, want 5",
+		"/port": "got string, want integer",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("errors_by_path = %v, want %v", got, want)
+	}
+	for path, message := range want {
+		if got[path] != message {
+			t.Errorf("errors_by_path[%q] = %q, want %q", path, got[path], message)
+		}
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_CanonicalFormat(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	document := `{"zebra": [1, {"b": true, "a": null}], "alpha": "first"}`
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{name: "default", expected: `{"alpha":"first","zebra":[1,{"a":null,"b":true}]}`},
+		{name: "compact", format: CanonicalFormatCompact, expected: `{"alpha":"first","zebra":[1,{"a":null,"b":true}]}`},
+		{
+			name:   "indent",
+			format: CanonicalFormatIndent,
+			expected: `{
+  "alpha": "first",
+  "zebra": [
+    1,
+    {
+      "a": null,
+      "b": true
+    }
+  ]
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"schema":           schemaFile,
+				"document_content": document,
+			}
+			if tt.format != "" {
+				raw["canonical_format"] = tt.format
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+			if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := resourceData.Get("valid_json").(string); got != tt.expected {
+				t.Errorf("valid_json =\n%s\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ValidYAML(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "required": ["port"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		filetype string
+		expected string
+	}{
+		{
+			name:     "JSON5 numbers",
+			document: `{server: {port: 8080, ratio: 0.5}, port: 42.0, tags: ['b', 'a']}`,
+			filetype: "json5",
+			expected: "port: 42\nserver:\n  port: 8080\n  ratio: 0.5\ntags:\n  - b\n  - a\n",
+		},
+		{
+			name:     "invalid document",
+			document: `{"name": "x"}`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"schema":           schemaFile,
+				"document_content": tt.document,
+				"force_filetype":   tt.filetype,
+				"fail_on_error":    false,
+			})
+			if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := resourceData.Get("valid_yaml").(string); got != tt.expected {
+				t.Errorf("valid_yaml =\n%s\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ValidTOML(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		document      string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "object root",
+			document: `{"server": {"port": 8080}, "name": "app"}`,
+			expected: "name = 'app'\n\n[server]\nport = 8080\n",
+		},
+		{name: "array root", document: `[1, 2]`, expectedError: "TOML requires a table at the document root, got an array"},
+		{name: "null value", document: `{"name": null}`, expectedError: "TOML has no null value, found one at '/name'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"schema":           schemaFile,
+				"document_content": tt.document,
+			})
+			if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := resourceData.Get("valid_toml").(string); got != tt.expected {
+				t.Errorf("valid_toml =\n%s\nwant\n%s", got, tt.expected)
+			}
+			if got := resourceData.Get("valid_toml_error").(string); got != tt.expectedError {
+				t.Errorf("valid_toml_error = %q, want %q", got, tt.expectedError)
+			}
+			if resourceData.Get("valid_json").(string) == "" {
+				t.Errorf("expected valid_json to be set")
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_RejectDuplicateKeys(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	documentFile := filepath.Join(tempDir, "document.json5")
+	if err := os.WriteFile(documentFile, []byte("{\n  server: {port: 80},\n  server: {port: 443},\n}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		raw           map[string]interface{}
+		errorContains string
+	}{
+		{
+			name:          "JSON content",
+			raw:           map[string]interface{}{"document_content": `{"a":1,"a":2}`, "reject_duplicate_keys": true},
+			errorContains: `failed to parse document_content: duplicate key "a" in object at '' (line 1, column 8)`,
+		},
+		{
+			name:          "nested JSON content",
+			raw:           map[string]interface{}{"document_content": `{"a": {"b": 1, "b": 2}}`, "reject_duplicate_keys": true},
+			errorContains: `duplicate key "b" in object at '/a'`,
+		},
+		{
+			name:          "JSON5 file",
+			raw:           map[string]interface{}{"document": documentFile, "reject_duplicate_keys": true},
+			errorContains: `duplicate key "server" in object at '' (line 3, column 3)`,
+		},
+		{
+			name: "last value kept by default",
+			raw:  map[string]interface{}{"document_content": `{"a":1,"a":2}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.raw["schema"] = schemaFile
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, tt.raw)
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := resourceData.Get("valid_json").(string); got != `{"a":2}` {
+				t.Errorf("valid_json = %q, want %q", got, `{"a":2}`)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_Context(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "user.schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{
+		"type": "object",
+		"properties": {
+			"id": {"type": "string", "readOnly": true},
+			"password": {"type": "string", "writeOnly": true},
+			"name": {"type": "string"},
+			"sessions": {"type": "array", "items": {"properties": {"created_at": {"readOnly": true}, "token": {"writeOnly": true}}}}
+		}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	document := `{"id": "u1", "password": "secret", "name": "a", "sessions": [{"created_at": "now", "token": "t"}]}`
+
+	tests := []struct {
+		name          string
+		context       string
+		document      string
+		forceFiletype string
+		failOnError   bool
+		wantPaths     []string
+		errorContains string
+	}{
+		{name: "no context", document: document},
+		{
+			name:      "write context rejects readOnly",
+			context:   "write",
+			document:  document,
+			wantPaths: []string{"/id", "/sessions/0/created_at"},
+		},
+		{
+			name:      "read context rejects writeOnly",
+			context:   "read",
+			document:  document,
+			wantPaths: []string{"/password", "/sessions/0/token"},
+		},
+		{
+			name:      "schema errors are reported alongside",
+			context:   "write",
+			document:  `{"id": "u1", "name": 1}`,
+			wantPaths: []string{"/id", "/name"},
+		},
+		{name: "allowed request body", context: "write", document: `{"name": "a", "password": "secret"}`},
+		{
+			name:          "fail_on_error",
+			context:       "write",
+			document:      document,
+			failOnError:   true,
+			errorContains: "at '/id': value is readOnly and not allowed in a write context",
+		},
+		{
+			name:          "JSONL is not supported",
+			context:       "read",
+			document:      "{\"name\": \"a\"}\n",
+			forceFiletype: "jsonl",
+			errorContains: "context is not supported for JSONL documents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"schema":           schemaFile,
+				"document_content": tt.document,
+				"context":          tt.context,
+				"force_filetype":   tt.forceFiletype,
+				"fail_on_error":    tt.failOnError,
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var gotPaths []string
+			for _, item := range resourceData.Get("errors").([]interface{}) {
+				gotPaths = append(gotPaths, item.(map[string]interface{})["document_path"].(string))
+			}
+			if !reflect.DeepEqual(gotPaths, tt.wantPaths) {
+				t.Errorf("error paths = %v, want %v", gotPaths, tt.wantPaths)
+			}
+			if valid := resourceData.Get("valid").(bool); valid != (len(tt.wantPaths) == 0) {
+				t.Errorf("valid = %v with errors at %v", valid, gotPaths)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_NormalizeNumbers(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(t *testing.T, content, filetype string, normalize bool) *schema.ResourceData {
+		t.Helper()
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"schema":           schemaFile,
+			"document_content": content,
+			"force_filetype":   filetype,
+		})
+		config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}", NormalizeNumbers: normalize}
+		if err := dataSourceJsonschemaValidatorRead(resourceData, config); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resourceData
+	}
+
+	tests := []struct {
+		name          string
+		first, second string
+		firstType     string
+		secondType    string
+		wantJSON      string
+		equalWithout  bool
+	}{
+		{name: "1 and 1.0", first: `{"n":1}`, second: `{"n":1.0}`, wantJSON: `{"n":1}`, equalWithout: true},
+		{name: "YAML integer and JSON float", first: "n: 1\n", firstType: "yaml", second: `{"n":1.0}`, wantJSON: `{"n":1}`, equalWithout: true},
+		{name: "negative zero", first: `{"n":-0.0}`, second: `{"n":0}`, wantJSON: `{"n":0}`},
+		{name: "YAML integer beyond 2^53", first: "n: 12345678901234567890\n", firstType: "yaml", second: `{"n":12345678901234567890}`, wantJSON: `{"n":12345678901234567000}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := read(t, tt.first, tt.firstType, true)
+			second := read(t, tt.second, tt.secondType, true)
+			if got := first.Get("valid_json").(string); got != tt.wantJSON {
+				t.Errorf("valid_json = %s, want %s", got, tt.wantJSON)
+			}
+			if first.Get("valid_json") != second.Get("valid_json") || first.Id() != second.Id() {
+				t.Errorf("normalized documents differ: %s (%s) vs %s (%s)", first.Get("valid_json"), first.Id(), second.Get("valid_json"), second.Id())
+			}
+
+			// Without the option only representations encoding/json already unifies match
+			if equal := read(t, tt.first, tt.firstType, false).Id() == read(t, tt.second, tt.secondType, false).Id(); equal != tt.equalWithout {
+				t.Errorf("IDs equal without normalize_numbers = %v, want %v", equal, tt.equalWithout)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_SortArraysAt(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// prefixItems makes validation depend on the order as written
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "properties": {"tags": {"type": "array", "prefixItems": [{"const": "web"}]}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(t *testing.T, content string, pointers ...interface{}) (*schema.ResourceData, error) {
+		t.Helper()
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"schema":           schemaFile,
+			"document_content": content,
+			"sort_arrays_at":   pointers,
+			"fail_on_error":    false,
+		})
+		err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+		return resourceData, err
+	}
+
+	first, err := read(t, `{"tags": ["web", "db", "api"], "ports": [443, 80]}`, "/tags", "/missing")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := read(t, `{"tags": ["web", "api", "db"], "ports": [443, 80]}`, "/tags", "/missing")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"ports":[443,80],"tags":["api","db","web"]}`
+	if got := first.Get("valid_json").(string); got != want {
+		t.Errorf("valid_json = %s, want %s", got, want)
+	}
+	if first.Get("valid_json") != second.Get("valid_json") || first.Id() != second.Id() {
+		t.Errorf("sorted documents differ: %s (%s) vs %s (%s)", first.Get("valid_json"), first.Id(), second.Get("valid_json"), second.Id())
+	}
+
+	// Validation sees the document as written, where "web" comes first
+	if !first.Get("valid").(bool) {
+		t.Errorf("valid = false, want prefixItems to pass on the unsorted array: %s", first.Get("validation_errors"))
+	}
+
+	if _, err := read(t, `{"tags": "web"}`, "/tags"); err == nil || !strings.Contains(err.Error(), `sort_arrays_at: JSON pointer "/tags" does not refer to an array`) {
+		t.Errorf("error = %v, want sort_arrays_at error for a non-array", err)
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_CollectComments(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{
+		"$comment": "service definition",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "$comment": "DNS label"},
+			"tls": {"type": "object", "$comment": "only when exposed"}
+		}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		content         string
+		filetype        string
+		collectComments bool
+		want            []map[string]interface{}
+	}{
+		{
+			name:            "comments on the values the document sets",
+			content:         `{"name": "api"}`,
+			collectComments: true,
+			want: []map[string]interface{}{
+				{"document_path": "", "schema_path": "file://" + filepath.ToSlash(schemaFile) + "#/$comment", "comment": "service definition", "line": 0},
+				{"document_path": "/name", "schema_path": "file://" + filepath.ToSlash(schemaFile) + "#/properties/name/$comment", "comment": "DNS label", "line": 0},
+			},
+		},
+		{
+			name:            "JSONL records carry their line",
+			content:         "{\"tls\": {}}\n{}\n",
+			filetype:        "jsonl",
+			collectComments: true,
+			want: []map[string]interface{}{
+				{"document_path": "", "schema_path": "file://" + filepath.ToSlash(schemaFile) + "#/$comment", "comment": "service definition", "line": 1},
+				{"document_path": "/tls", "schema_path": "file://" + filepath.ToSlash(schemaFile) + "#/properties/tls/$comment", "comment": "only when exposed", "line": 1},
+				{"document_path": "", "schema_path": "file://" + filepath.ToSlash(schemaFile) + "#/$comment", "comment": "service definition", "line": 2},
+			},
+		},
+		{
+			name:    "disabled by default",
+			content: `{"name": "api"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"schema":           schemaFile,
+				"document_content": tt.content,
+				"force_filetype":   tt.filetype,
+				"collect_comments": tt.collectComments,
+			})
+			if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []map[string]interface{}
+			for _, item := range resourceData.Get("annotations").([]interface{}) {
+				got = append(got, item.(map[string]interface{}))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("annotations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ValidateSchema(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "properties": {"port": {"type": "int"}}, "required": "port"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		validateSchema bool
+		errorTemplate  string
+		errorContains  string
+	}{
+		{
+			name:          "compile error without the check",
+			errorContains: "failed to compile schema",
+		},
+		{
+			name:           "meta-schema violations",
+			validateSchema: true,
+			errorContains:  "is not valid against its meta-schema: jsonschema validation failed with 'https://json-schema.org/draft/2020-12/schema#'\n- at '/properties/port/type': 'type' must be one of 'array', 'boolean', 'integer', 'null', 'number', 'object', 'string' or an array\n- at '/required': got string, want array",
+		},
+		{
+			name:           "data source error template",
+			validateSchema: true,
+			errorTemplate:  "{{.ErrorCount}} errors:{{range .Errors}} {{.DocumentPath}}{{end}}",
+			errorContains:  "is not valid against its meta-schema: 2 errors: /properties/port/type /required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"schema":                 schemaFile,
+				"document_content":       `{"port": 80}`,
+				"error_message_template": tt.errorTemplate,
+			})
+			config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}", ValidateSchema: tt.validateSchema}
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_BaseURI(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// main.json has no $id; common.json refers back to it by its logical URI
+	mainSchema := filepath.Join(tempDir, "main.json")
+	if err := os.WriteFile(mainSchema, []byte(`{
+		"type": "object",
+		"properties": {"service": {"$ref": "common.json"}},
+		"$defs": {"name": {"type": "string", "minLength": 3}}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "common.json"), []byte(`{
+		"$id": "https://example.com/schemas/common.json",
+		"type": "object",
+		"properties": {"name": {"$ref": "main.json#/$defs/name"}},
+		"required": ["name"]
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		baseURI       string
+		document      string
+		errorContains string
+	}{
+		{
+			name:          "without base_uri the back-reference is a remote URL",
+			document:      `{"service": {"name": "api"}}`,
+			errorContains: `failing loading "https://example.com/schemas/main.json": network access is disabled`,
+		},
+		{
+			name:     "valid document",
+			baseURI:  "https://example.com/schemas/main.json",
+			document: `{"service": {"name": "api"}}`,
+		},
+		{
+			name:          "constraint reached through both schemas",
+			baseURI:       "https://example.com/schemas/main.json",
+			document:      `{"service": {"name": "a"}}`,
+			errorContains: "minLength: got 1, want 3",
+		},
+		{
+			name:          "relative base_uri",
+			baseURI:       "schemas/main.json",
+			document:      `{}`,
+			errorContains: `invalid base_uri "schemas/main.json": must be an absolute URI without a fragment`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"schema":           mainSchema,
+				"document_content": tt.document,
+				"base_uri":         tt.baseURI,
+				"ref_overrides": map[string]interface{}{
+					"https://example.com/schemas/common.json": filepath.Join(tempDir, "common.json"),
+				},
+			})
+			config := &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}", Offline: true}
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_Draft3(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "legacy.json")
+	if err := os.WriteFile(schemaFile, []byte(`{
+		"$schema": "http://json-schema.org/draft-03/schema#",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "required": true},
+			"replicas": {"type": "integer", "divisibleBy": 2}
+		}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		schemaVersion string
+		document      string
+		errorContains string
+	}{
+		{
+			name:          "valid document",
+			schemaVersion: "draft-03",
+			document:      `{"name": "api", "replicas": 4}`,
+		},
+		{
+			name:          "required property",
+			schemaVersion: "draft-03",
+			document:      `{"replicas": 4}`,
+			errorContains: "missing property 'name'",
+		},
+		{
+			name:          "divisibleBy",
+			schemaVersion: "draft-03",
+			document:      `{"name": "api", "replicas": 3}`,
+			errorContains: "multipleOf: got 3, want 2",
+		},
+		{
+			name:          "draft-03 schema without the conversion",
+			document:      `{"name": "api"}`,
+			errorContains: "failed to compile schema",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"schema":           schemaFile,
+				"document_content": tt.document,
+				"schema_version":   tt.schemaVersion,
+			})
+			err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}", Offline: true})
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got := resourceData.Get("effective_draft"); got != "draft-04" {
+					t.Errorf("effective_draft = %v, want draft-04", got)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_CoerceTypes(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{
+		"type": "object",
+		"properties": {
+			"port": {"type": "integer"},
+			"enabled": {"type": "boolean"},
+			"name": {"type": "string"}
+		}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		content       string
+		filetype      string
+		coerceTypes   bool
+		wantJSON      string
+		wantCoercions []map[string]interface{}
+		errorContains string
+	}{
+		{
+			name:        "string to integer and boolean",
+			content:     "port: \"8080\"\nenabled: \"true\"\nname: \"42\"\n",
+			filetype:    "yaml",
+			coerceTypes: true,
+			wantJSON:    `{"enabled":true,"name":"42","port":8080}`,
+			wantCoercions: []map[string]interface{}{
+				{"document_path": "/enabled", "from": "true", "to": "true"},
+				{"document_path": "/port", "from": "8080", "to": "8080"},
+			},
+		},
+		{
+			name:          "disabled by default",
+			content:       `{"port": "8080"}`,
+			errorContains: "at '/port'",
+		},
+		{
+			name:          "unconvertible strings still fail",
+			content:       `{"port": "http", "enabled": "yes"}`,
+			coerceTypes:   true,
+			errorContains: "at '/enabled'",
+		},
+		{
+			name:          "JSONL is not supported",
+			content:       "{\"port\": \"8080\"}\n",
+			filetype:      "jsonl",
+			coerceTypes:   true,
+			errorContains: "coerce_types is not supported for JSONL documents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"schema":           schemaFile,
+				"document_content": tt.content,
+				"force_filetype":   tt.filetype,
+				"coerce_types":     tt.coerceTypes,
+			})
+			err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := resourceData.Get("valid_json").(string); got != tt.wantJSON {
+				t.Errorf("valid_json = %s, want %s", got, tt.wantJSON)
+			}
+			var got []map[string]interface{}
+			for _, item := range resourceData.Get("coercions").([]interface{}) {
+				got = append(got, item.(map[string]interface{}))
+			}
+			if !reflect.DeepEqual(got, tt.wantCoercions) {
+				t.Errorf("coercions = %v, want %v", got, tt.wantCoercions)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_IDUsesResolvedDraft(t *testing.T) {
+	tempDir := t.TempDir()
+
+	draft7File := filepath.Join(tempDir, "draft7.json")
+	if err := os.WriteFile(draft7File, []byte(`{"$schema": "http://json-schema.org/draft-07/schema#", "type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	plainFile := filepath.Join(tempDir, "plain.json")
+	if err := os.WriteFile(plainFile, []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	readID := func(schemaFile, schemaVersion string) string {
+		t.Helper()
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"schema":           schemaFile,
+			"document_content": `{"name": "a"}`,
+			"schema_version":   schemaVersion,
+		})
+		if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resourceData.Id()
+	}
+
+	if auto, explicit := readID(draft7File, ""), readID(draft7File, "draft-07"); auto != explicit {
+		t.Errorf("ID with draft-07 from $schema = %q, with schema_version = \"draft-07\" = %q, want equal", auto, explicit)
+	}
+	if auto, explicit := readID(plainFile, ""), readID(plainFile, "draft/2020-12"); auto != explicit {
+		t.Errorf("ID with the default draft = %q, with schema_version = \"draft/2020-12\" = %q, want equal", auto, explicit)
+	}
+	if auto, explicit := readID(plainFile, ""), readID(plainFile, "draft-07"); auto == explicit {
+		t.Error("ID must change when schema_version selects a different draft")
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_BinaryDocuments(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "properties": {"port": {"type": "integer"}}, "required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// {"name": "a", "port": 80, "tags": ["x", "y"]}
+	documentFiles := map[string][]byte{
+		"doc.msgpack": append([]byte{0x83, 0xa4}, "name\xa1a\xa4port\x50\xa4tags\x92\xa1x\xa1y"...),
+		"doc.cbor":    append([]byte{0xa3, 0x64}, "name\x61a\x64port\x18\x50\x64tags\x82\x61x\x61y"...),
+	}
+
+	for name, content := range documentFiles {
+		t.Run(name, func(t *testing.T) {
+			documentFile := filepath.Join(tempDir, name)
+			if err := os.WriteFile(documentFile, content, 0644); err != nil {
+				t.Fatal(err)
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document": documentFile,
+				"schema":   schemaFile,
+			})
+			if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got, want := resourceData.Get("valid_json").(string), `{"name":"a","port":80,"tags":["x","y"]}`; got != want {
+				t.Errorf("valid_json = %s, want %s", got, want)
+			}
+		})
+	}
+
+	t.Run("binary value", func(t *testing.T) {
+		// {"name": <2 bytes>}
+		documentFile := filepath.Join(tempDir, "binary.msgpack")
+		if err := os.WriteFile(documentFile, []byte{0x81, 0xa4, 'n', 'a', 'm', 'e', 0xc4, 0x02, 0xde, 0xad}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document": documentFile,
+			"schema":   schemaFile,
+		})
+		err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+		if err == nil || !strings.Contains(err.Error(), `binary value (2 byte(s)) at "/name" cannot be represented in JSON`) {
+			t.Errorf("error = %v, want binary value error", err)
+		}
+	})
+}
+
+func TestDataSourceJsonschemaValidatorRead_MaxErrors(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"additionalProperties": {"type": "integer"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A JSONL document is capped the same way, its errors labeled with the line
+	for _, tt := range []struct {
+		name    string
+		message string
+	}{
+		{name: "doc.json", message: "5: /a /b\n... and 3 more"},
+		{name: "doc.jsonl", message: "5: 1/a 1/b\n... and 3 more"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			docFile := filepath.Join(tempDir, tt.name)
+			if err := os.WriteFile(docFile, []byte(`{"e": "5", "d": "4", "c": "3", "b": "2", "a": "1"}`+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document":               docFile,
+				"schema":                 schemaFile,
+				"max_errors":             2,
+				"fail_on_error":          false,
+				"error_message_template": "{{.ErrorCount}}:{{range .Errors}} {{if .Line}}{{.Line}}{{end}}{{.DocumentPath}}{{end}}",
+			})
+			if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := resourceData.Get("validation_errors").(string); got != tt.message {
+				t.Errorf("validation_errors = %q, want %q", got, tt.message)
+			}
+			if got := resourceData.Get("errors.#").(int); got != 5 {
+				t.Errorf("errors has %d elements, want all 5", got)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_SuggestFixes(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{
+		"type": "object",
+		"required": ["name", "port"],
+		"properties": {
+			"name": {"type": "string"},
+			"port": {"type": "integer", "default": 8080},
+			"debug": {"type": "boolean", "default": false}
+		},
+		"additionalProperties": false
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{"debug": "yes", "extra": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(suggestFixes bool) *schema.ResourceData {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document":      docFile,
+			"schema":        schemaFile,
+			"suggest_fixes": suggestFixes,
+			"fail_on_error": false,
+		})
+		if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resourceData
+	}
+
+	// "name" has no default, so it is left out
+	want := `[{"op":"replace","path":"/debug","value":false},{"op":"remove","path":"/extra"},{"op":"add","path":"/port","value":8080}]`
+	if got := read(true).Get("fix_patch").(string); got != want {
+		t.Errorf("fix_patch = %s, want %s", got, want)
+	}
+	if got := read(false).Get("fix_patch").(string); got != "" {
+		t.Errorf("fix_patch = %q without suggest_fixes, want empty", got)
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ApplyDefaults(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{
+		"type": "object",
+		"properties": {
+			"replicas": {"type": "integer", "default": 1},
+			"server": {
+				"type": "object",
+				"properties": {
+					"port": {"type": "integer", "default": 8080},
+					"host": {"type": "string"}
+				}
+			},
+			"volumes": {"items": {"properties": {"readOnly": {"default": false}}}}
+		}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{"server": {"host": "0.0.0.0"}, "volumes": [{"name": "data"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(applyDefaults bool) *schema.ResourceData {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document":       docFile,
+			"schema":         schemaFile,
+			"apply_defaults": applyDefaults,
+		})
+		if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resourceData
+	}
+
+	want := `{"replicas":1,"server":{"host":"0.0.0.0","port":8080},"volumes":[{"name":"data","readOnly":false}]}`
+	withDefaults := read(true)
+	if got := withDefaults.Get("valid_json").(string); got != want {
+		t.Errorf("valid_json = %s, want %s", got, want)
+	}
+	if got := read(false).Get("valid_json").(string); got == want {
+		t.Error("valid_json has the defaults without apply_defaults")
+	}
+	if !strings.Contains(withDefaults.Get("valid_yaml").(string), "port: 8080") {
+		t.Errorf("valid_yaml = %q, want the defaults", withDefaults.Get("valid_yaml"))
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_InlineRefs(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"schemas/main.json":   `{"type": "object", "properties": {"port": {"$ref": "types.json#/$defs/port"}, "user": {"$ref": "https://example.com/schemas/user.json"}}}`,
+		"schemas/types.json":  `{"$defs": {"port": {"type": "integer", "maximum": 65535}}}`,
+		"schemas/escape.json": `{"properties": {"secret": {"$ref": "../secrets/key.json"}}}`,
+		"secrets/key.json":    `{"type": "string"}`,
+		"user.json":           `{"type": "object", "required": ["name"]}`,
+		"valid.json":          `{"port": 443, "user": {"name": "a"}}`,
+		"invalid.json":        `{"port": 70000, "user": {"name": "a"}}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read := func(raw map[string]interface{}) (*schema.ResourceData, error) {
+		raw["inline_refs"] = true
+		raw["allowed_ref_paths"] = []interface{}{filepath.Join(tempDir, "schemas", "*.json")}
+		raw["ref_overrides"] = map[string]interface{}{"https://example.com/schemas/user.json": filepath.Join(tempDir, "user.json")}
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+		return resourceData, dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+	}
+
+	t.Run("allowed refs are inlined", func(t *testing.T) {
+		resourceData, err := read(map[string]interface{}{
+			"document": filepath.Join(tempDir, "valid.json"),
+			"schema":   filepath.Join(tempDir, "schemas", "main.json"),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := resourceData.Get("valid_json").(string); got != `{"port":443,"user":{"name":"a"}}` {
+			t.Errorf("valid_json = %s", got)
+		}
+	})
+
+	t.Run("inlined definitions are enforced", func(t *testing.T) {
+		_, err := read(map[string]interface{}{
+			"document": filepath.Join(tempDir, "invalid.json"),
+			"schema":   filepath.Join(tempDir, "schemas", "main.json"),
+		})
+		if err == nil || !strings.Contains(err.Error(), "/port") {
+			t.Fatalf("expected a /port validation error, got %v", err)
+		}
+	})
+
+	t.Run("refs outside the allowed paths are rejected", func(t *testing.T) {
+		_, err := read(map[string]interface{}{
+			"document": filepath.Join(tempDir, "valid.json"),
+			"schema":   filepath.Join(tempDir, "schemas", "escape.json"),
+		})
+		if err == nil || !strings.Contains(err.Error(), "inline_refs") || !strings.Contains(err.Error(), "is not allowed") {
+			t.Fatalf("expected a not allowed error, got %v", err)
+		}
+	})
+
+	t.Run("inline content is rejected", func(t *testing.T) {
+		_, err := read(map[string]interface{}{
+			"document":       filepath.Join(tempDir, "valid.json"),
+			"schema_content": `{"type": "object"}`,
+		})
+		if err == nil || !strings.Contains(err.Error(), "is not a local file") {
+			t.Fatalf("expected a not a local file error, got %v", err)
+		}
+	})
+}
+
+func TestDataSourceJsonschemaValidatorRead_FileRefAllowlist(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"app/schemas/main.json":  `{"properties": {"port": {"$ref": "types.json#/$defs/port"}, "key": {"$ref": "../../etc/secrets.json"}}}`,
+		"app/schemas/types.json": `{"$defs": {"port": {"type": "integer"}}}`,
+		"app/doc.json":           `{"port": 443}`,
+		"etc/secrets.json":       `{"type": "string"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	schemaDir := filepath.Join(tempDir, "app", "schemas")
+
+	read := func(allowlist []interface{}) error {
+		raw := map[string]interface{}{
+			"document": filepath.Join(tempDir, "app", "doc.json"),
+			"schema":   filepath.Join(schemaDir, "main.json"),
+		}
+		if allowlist != nil {
+			raw["file_ref_allowlist"] = allowlist
+		}
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+		return dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+	}
+
+	// Compiled without the allowlist first, so a cached schema must not bypass it
+	if err := read(nil); err != nil {
+		t.Fatalf("unexpected error without file_ref_allowlist: %v", err)
+	}
+
+	err := read([]interface{}{filepath.Join(schemaDir, "*.json")})
+	want := `$ref to "` + filepath.Join(tempDir, "etc", "secrets.json") + `" is not allowed`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected error containing %q, got %v", want, err)
+	}
+
+	if err := read([]interface{}{filepath.Join(schemaDir, "*.json"), filepath.Join(tempDir, "etc", "*.json")}); err != nil {
+		t.Errorf("unexpected error with the directory allowed: %v", err)
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_RefBaseRedirect(t *testing.T) {
+	tempDir := t.TempDir()
+
+	mirrorDir := filepath.Join(tempDir, "mirror")
+	for name, content := range map[string]string{
+		"mirror/shared/port.json":    `{"type": "integer", "maximum": 65535}`,
+		"mirror/shared/v2/name.json": `{"type": "string", "minLength": 1}`,
+		"schema.json":                `{"properties": {"port": {"$ref": "https://json-schema.org/shared/port.json"}, "name": {"$ref": "https://json-schema.org/shared/v2/name.json"}}}`,
+		"valid.json":                 `{"port": 443, "name": "api"}`,
+		"invalid.json":               `{"port": 70000, "name": "api"}`,
+	} {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Offline, so a $ref the redirect misses fails instead of being fetched
+	read := func(document string, redirects map[string]interface{}) error {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document":          filepath.Join(tempDir, document),
+			"schema":            filepath.Join(tempDir, "schema.json"),
+			"ref_base_redirect": redirects,
+		})
+		return dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}", Offline: true})
+	}
+
+	if err := read("valid.json", map[string]interface{}{"https://json-schema.org/": mirrorDir}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := read("invalid.json", map[string]interface{}{"https://json-schema.org/": mirrorDir}); err == nil || !strings.Contains(err.Error(), "maximum") {
+		t.Errorf("expected a maximum error from the redirected schema, got %v", err)
+	}
+	if err := read("valid.json", map[string]interface{}{"https://json-schema.org/shared/v2/": filepath.Join(mirrorDir, "shared", "v2")}); err == nil || !strings.Contains(err.Error(), "network access is disabled") {
+		t.Errorf("expected the $ref outside the prefix to need the network, got %v", err)
+	}
+	if err := read("valid.json", map[string]interface{}{"json-schema.org/": mirrorDir}); err == nil || !strings.Contains(err.Error(), "is not an http:// or https:// URL prefix") {
+		t.Errorf("expected an invalid prefix error, got %v", err)
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_Each(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"user.schema.json": `{"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}, "age": {"type": "integer"}}}`,
+		"valid.json":       `[{"name": "a", "age": 1}, {"name": "b"}, {"age": 3, "name": "c"}]`,
+		"invalid.json":     `[{"name": "a"}, {"age": "two"}, {"name": "c"}]`,
+		"object.json":      `{"name": "a"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read := func(document string, extra map[string]interface{}) (*schema.ResourceData, error) {
+		raw := map[string]interface{}{
+			"document":      filepath.Join(tempDir, document),
+			"schema":        filepath.Join(tempDir, "user.schema.json"),
+			"each":          true,
+			"fail_on_error": false,
+		}
+		for key, value := range extra {
+			raw[key] = value
+		}
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+		return resourceData, dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+	}
+
+	valid, err := read("valid.json", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !valid.Get("valid").(bool) {
+		t.Errorf("valid = false, errors: %s", valid.Get("validation_errors"))
+	}
+	if got, want := valid.Get("valid_json").(string), `[{"age":1,"name":"a"},{"name":"b"},{"age":3,"name":"c"}]`; got != want {
+		t.Errorf("valid_json = %s, want %s", got, want)
+	}
+
+	invalid, err := read("invalid.json", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invalid.Get("valid").(bool) {
+		t.Fatal("valid = true, want element 2 to fail")
+	}
+	var paths []string
+	for _, item := range invalid.Get("errors").([]interface{}) {
+		paths = append(paths, item.(map[string]interface{})["document_path"].(string))
+	}
+	if want := []string{"/1", "/1/age"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("error paths = %v, want %v", paths, want)
+	}
+	if message := invalid.Get("validation_errors").(string); !strings.Contains(message, "at '/1/age'") || strings.Contains(message, "at '/0") || strings.Contains(message, "at '/2") {
+		t.Errorf("validation_errors = %q, want only element 2's errors", message)
+	}
+
+	if _, err := read("object.json", nil); err == nil || !strings.Contains(err.Error(), "document must be an array") {
+		t.Errorf("expected a non-array error, got %v", err)
+	}
+	if _, err := read("valid.json", map[string]interface{}{"apply_defaults": true}); err == nil || !strings.Contains(err.Error(), "apply_defaults is not supported with each") {
+		t.Errorf("expected an unsupported option error, got %v", err)
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_RequireKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"complete.json": `{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "web"}}`,
+		"partial.json":  `{"apiVersion": "v1", "metadata": {"kind": "nested"}}`,
+		"schema.json":   `{"type": "object", "properties": {"apiVersion": {"const": "v2"}}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read := func(document string, extra map[string]interface{}) (*schema.ResourceData, error) {
+		raw := map[string]interface{}{
+			"document":     filepath.Join(tempDir, document),
+			"require_keys": []interface{}{"apiVersion", "kind", "metadata"},
+		}
+		for key, value := range extra {
+			raw[key] = value
+		}
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+		return resourceData, dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+	}
+
+	complete, err := read("complete.json", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := complete.Get("valid_json").(string); got != `{"apiVersion":"v1","kind":"Service","metadata":{"name":"web"}}` {
+		t.Errorf("valid_json = %s", got)
+	}
+
+	_, err = read("partial.json", nil)
+	if err == nil || !strings.Contains(err.Error(), "at '': missing property 'kind'") {
+		t.Errorf("expected a missing key error, got %v", err)
+	}
+
+	partial, err := read("partial.json", map[string]interface{}{"fail_on_error": false})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if partial.Get("valid").(bool) {
+		t.Error("valid = true, want the missing key to fail")
+	}
+	if got := partial.Get("errors.0.schema_path").(string); got != "require_keys#/required" {
+		t.Errorf("errors.0.schema_path = %q, want require_keys#/required", got)
+	}
+
+	// The preflight fails before the schema is read, so a missing schema file is not an error
+	if _, err := read("partial.json", map[string]interface{}{"schema": filepath.Join(tempDir, "missing.json")}); err == nil || !strings.Contains(err.Error(), "missing property 'kind'") {
+		t.Errorf("expected the preflight error, got %v", err)
+	}
+	// With every key present the schema still runs
+	if _, err := read("complete.json", map[string]interface{}{"schema": filepath.Join(tempDir, "schema.json")}); err == nil || !strings.Contains(err.Error(), "/apiVersion") {
+		t.Errorf("expected the schema's error, got %v", err)
+	}
+	// Without a schema there are no defaults to apply, whatever the match mode
+	applied, err := read("complete.json", map[string]interface{}{"apply_defaults": true, "schema_match_mode": SchemaMatchModeAny})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := applied.Get("valid_json").(string); got != `{"apiVersion":"v1","kind":"Service","metadata":{"name":"web"}}` {
+		t.Errorf("valid_json = %s", got)
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_ErrorTemplatePrecedence(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaFile := filepath.Join(tempDir, "test.schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "properties": {"port": {"type": "integer"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	docFile := filepath.Join(tempDir, "doc.json")
+	if err := os.WriteFile(docFile, []byte(`{"port": "80"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := NewProviderConfig("", "PROVIDER: {{.ErrorCount}} error(s)", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+		want string
+	}{
+		{
+			name: "unset uses the provider template",
+			raw:  map[string]interface{}{},
+			want: "PROVIDER: 1 error(s)",
+		},
+		{
+			name: "empty uses the provider template",
+			raw:  map[string]interface{}{"error_message_template": ""},
+			want: "PROVIDER: 1 error(s)",
+		},
+		{
+			name: "data source template wins",
+			raw:  map[string]interface{}{"error_message_template": "DATA SOURCE: {{.ErrorCount}}"},
+			want: "DATA SOURCE: 1",
+		},
+		{
+			name: "use_raw_error skips the provider template",
+			raw:  map[string]interface{}{"use_raw_error": true},
+			want: "jsonschema validation failed with 'file://" + filepath.ToSlash(schemaFile) + "#'\n- at '/port': got string, want integer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"document": docFile,
+				"schema":   schemaFile,
+			}
+			for key, value := range tt.raw {
+				raw[key] = value
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+
+			err := dataSourceJsonschemaValidatorRead(resourceData, config)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_RedactValues(t *testing.T) {
+	tempDir := t.TempDir()
+	schemaPath := filepath.Join(tempDir, "secrets.schema.json")
+	documentPath := filepath.Join(tempDir, "secrets.json")
+	schemaJSON := `{
+		"type": "object",
+		"required": ["token"],
+		"properties": {
+			"password": {"type": "string", "pattern": "^[a-f0-9]{32}$"},
+			"pin": {"type": "integer", "maximum": 999},
+			"owner": {"type": "string", "format": "email"}
+		}
+	}`
+	if err := os.WriteFile(schemaPath, []byte(schemaJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(documentPath, []byte(`{"password": "hunter2", "pin": 4321, "owner": "not-an-email"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	secrets := []string{"hunter2", "4321", "not-an-email"}
+
+	read := func(dataSourceRedact, providerRedact bool) *schema.ResourceData {
+		t.Helper()
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document":               documentPath,
+			"schema":                 schemaPath,
+			"strict_format":          true,
+			"fail_on_error":          false,
+			"redact_values":          dataSourceRedact,
+			"error_message_template": "{{.Document}}\n{{.FullMessage}}\n{{range .Errors}}{{.Value}} {{.Message}}\n{{end}}",
+		})
+		if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}", RedactValues: providerRedact}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resourceData
+	}
+	// leaks returns the secrets found in the formatted error or the errors attribute
+	leaks := func(resourceData *schema.ResourceData) []string {
+		output := resourceData.Get("validation_errors").(string)
+		for _, item := range resourceData.Get("errors").([]interface{}) {
+			entry := item.(map[string]interface{})
+			output += "\n" + entry["value"].(string) + " " + entry["message"].(string)
+		}
+		var found []string
+		for _, secret := range secrets {
+			if strings.Contains(output, secret) {
+				found = append(found, secret)
+			}
+		}
+		return found
+	}
+
+	if found := leaks(read(false, false)); len(found) != len(secrets) {
+		t.Fatalf("without redaction the errors show %v, want every value %v", found, secrets)
+	}
+
+	for _, tt := range []struct {
+		name                             string
+		dataSourceRedact, providerRedact bool
+	}{
+		{name: "data source", dataSourceRedact: true},
+		{name: "provider", providerRedact: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := read(tt.dataSourceRedact, tt.providerRedact)
+			if found := leaks(resourceData); len(found) > 0 {
+				t.Errorf("redacted errors show %v:\n%s", found, resourceData.Get("validation_errors"))
+			}
+			message := resourceData.Get("validation_errors").(string)
+			for _, want := range []string{
+				"at '': missing property 'token'",
+				"at '/password': *** does not match pattern '^[a-f0-9]{32}$'",
+				"at '/pin': maximum: got ***, want 999",
+				"at '/owner': *** is not valid email",
+			} {
+				if !strings.Contains(message, want) {
+					t.Errorf("validation_errors = %q, want it to contain %q", message, want)
+				}
+			}
+			for _, item := range resourceData.Get("errors").([]interface{}) {
+				if value := item.(map[string]interface{})["value"].(string); value != "***" {
+					t.Errorf("errors value = %q, want \"***\"", value)
+				}
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_RedactPaths(t *testing.T) {
+	tempDir := t.TempDir()
+	schemaPath := filepath.Join(tempDir, "db.schema.json")
+	documentPath := filepath.Join(tempDir, "db.json")
+	schemaJSON := `{
+		"properties": {
+			"db": {
+				"properties": {
+					"host": {"type": "string", "pattern": "^[a-z]+$"},
+					"password": {"type": "string", "pattern": "^[a-f0-9]{32}$"}
+				}
+			}
+		}
+	}`
+	if err := os.WriteFile(schemaPath, []byte(schemaJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(documentPath, []byte(`{"db": {"host": "db.internal", "password": "hunter2"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+		"document":      documentPath,
+		"schema":        schemaPath,
+		"fail_on_error": false,
+		"redact_paths":  []interface{}{"/db/password"},
+	})
+	if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	message := resourceData.Get("validation_errors").(string)
+	if strings.Contains(message, "hunter2") {
+		t.Errorf("validation_errors shows the redacted password:\n%s", message)
+	}
+	for _, want := range []string{
+		"at '/db/host': 'db.internal' does not match pattern '^[a-z]+$'",
+		"at '/db/password': *** does not match pattern '^[a-f0-9]{32}$'",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("validation_errors = %q, want it to contain %q", message, want)
+		}
+	}
+
+	values := make(map[string]string)
+	for _, item := range resourceData.Get("errors").([]interface{}) {
+		entry := item.(map[string]interface{})
+		values[entry["document_path"].(string)] = entry["value"].(string)
+	}
+	if got := values["/db/host"]; got != `"db.internal"` {
+		t.Errorf("errors value at /db/host = %q, want it shown", got)
+	}
+	if got := values["/db/password"]; got != "***" {
+		t.Errorf("errors value at /db/password = %q, want \"***\"", got)
+	}
+
+	t.Run("document label", func(t *testing.T) {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document":               documentPath,
+			"schema":                 schemaPath,
+			"redact_paths":           []interface{}{"/db/password"},
+			"error_message_template": "{{.Document}}",
+		})
+		// {{.Document}} is the path, which has no values to redact
+		err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+		if err == nil || err.Error() != documentPath {
+			t.Errorf("error = %v, want the document path %q", err, documentPath)
+		}
+	})
+
+	t.Run("invalid pointer", func(t *testing.T) {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document":     documentPath,
+			"schema":       schemaPath,
+			"redact_paths": []interface{}{"db/password"},
+		})
+		err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+		if err == nil || !strings.Contains(err.Error(), "redact_paths") {
+			t.Errorf("error = %v, want a redact_paths error", err)
+		}
+	})
+}
+
+func TestDataSourceJsonschemaValidatorRead_ErrorTemplateValues(t *testing.T) {
+	tempDir := t.TempDir()
+	schemaPath := filepath.Join(tempDir, "port.schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"properties": {"port": {"enum": [80, 443]}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	documentPath := filepath.Join(tempDir, "port.yaml")
+	if err := os.WriteFile(documentPath, []byte("port: \"8080\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	template := "{{.Document}}|{{(index .Errors 0).ValueType}}|{{(index .Errors 0).Value}}"
+
+	for _, tt := range []struct {
+		name     string
+		raw      map[string]interface{}
+		document string
+	}{
+		{name: "document", raw: map[string]interface{}{"document": documentPath}, document: documentPath},
+		{name: "document_content", raw: map[string]interface{}{"document_content": "port: \"8080\"\n", "force_filetype": "yaml"}, document: "document_content"},
+		{name: "schemas", raw: map[string]interface{}{"document": documentPath, "schemas": []interface{}{schemaPath, schemaPath}}, document: documentPath},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{"schema": schemaPath, "error_message_template": template}
+			for key, value := range tt.raw {
+				raw[key] = value
+			}
+			if _, ok := raw["schemas"]; ok {
+				delete(raw, "schema")
+			}
+			resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+			err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+			// The value comes from the parsed document, the Document from its label
+			if want := tt.document + `|string|"8080"`; err == nil || err.Error() != want {
+				t.Errorf("error = %v, want %q", err, want)
+			}
+		})
+	}
+}
+
+func TestDataSourceJsonschemaValidatorRead_JSONEncodedHCLSchema(t *testing.T) {
+	// What jsonencode produces for the HCL object schema in the documentation: keys sorted,
+	// whole numbers without a fraction
+	schemaContent := `{"$schema":"https://json-schema.org/draft/2020-12/schema","additionalProperties":false,"properties":{"port":{"minimum":1,"type":"integer"}},"required":["port"],"type":"object"}`
+
+	for _, tt := range []struct {
+		document string
+		valid    bool
+	}{
+		{document: `{"port":8080}`, valid: true},
+		{document: `{"port":0}`, valid: false},
+		{document: `{"port":8080,"debug":true}`, valid: false},
+	} {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+			"document_content": tt.document,
+			"schema_content":   schemaContent,
+			"fail_on_error":    false,
+		})
+		if err := dataSourceJsonschemaValidatorRead(resourceData, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"}); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.document, err)
+		}
+		if got := resourceData.Get("valid").(bool); got != tt.valid {
+			t.Errorf("%s: valid = %v, want %v: %s", tt.document, got, tt.valid, resourceData.Get("validation_errors"))
+		}
+	}
+}
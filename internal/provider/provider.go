@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,17 +21,66 @@ func New(version string) func() *schema.Provider {
 					Type:        schema.TypeString,
 					Optional:    true,
 					Default:     "draft/2020-12",
-					Description: "Default JSON Schema version to use when not specified in schema document. Supported values: `draft-04`, `draft-06`, `draft-07`, `draft/2019-09`, `draft/2020-12`",
+					Description: "Default JSON Schema version to use when not specified in schema document. Supported values: `draft-03` (converted to draft-04), `draft-04`, `draft-06`, `draft-07`, `draft/2019-09`, `draft/2020-12`",
 				},
 				"error_message_template": {
 					Type:        schema.TypeString,
 					Optional:    true,
 					Default:     "{{.FullMessage}}",
-					Description: "Default error message template for validation failures. Can be overridden per data source. Available variables: {{.SchemaFile}}, {{.Document}}, {{.FullMessage}}, {{.Errors}}, {{.ErrorCount}}. Use {{range .Errors}} to iterate over individual errors.",
+					Description: "Default error message template for validation failures. Can be overridden per data source. Available variables: {{.SchemaFile}}, {{.Document}}, {{.FullMessage}}, {{.Errors}}, {{.ErrorCount}}. Use {{range .Errors}} to iterate over individual errors. A value like \"@detailed\" selects a built-in template by name.",
+				},
+				"strict_format": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Enable format assertion for all data sources so values violating `format` (`email`, `uri`, `date-time`, ...) fail validation. Unknown format names become a compile error.",
+				},
+				"custom_formats": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Map of custom `format` names to regular expressions (Go RE2 syntax), e.g. `employee-id = \"^E[0-9]{6}$\"`. String values using the format must match the regex. Formats are asserted when format assertion is active (`strict_format`, or draft-07 and earlier).",
+				},
+				"offline": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Disable network access: remote (`http://`, `https://`) schemas and `$ref`s are rejected instead of fetched. Use `ref_overrides` to map remote `$ref`s to local files.",
+				},
+				"ref_overrides": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Map of remote schema URLs to local file paths applied to every data source, like the data source argument of the same name. A data source's own `ref_overrides` win for the same URL.",
+				},
+				"normalize_numbers": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Encode every number of a validated document the same way whatever format it was written in, so semantically identical documents get the same `valid_json`, `valid_yaml`, `valid_toml` and ID. YAML and TOML integers are treated like JSON numbers (integers beyond 2^53 are rounded as JSON parsers do) and `-0` becomes `0`.",
+				},
+				"validate_schema": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Validate every schema against the meta-schema of its draft before compiling it. Violations are formatted with the error message template and located at their path in the schema, e.g. `at '/properties/port/type': 'type' must be one of ... or an array`, instead of failing to compile with the library's nested message.",
+				},
+				"redact_values": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Keep document values out of the validation errors of every data source, for documents that hold secrets, like the data source argument of the same name.",
+				},
+				"schema_cache_dir": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Directory where remote (http:// or https://) schemas are cached. Defaults to `terraform-provider-jsonschema` under the user cache directory.",
 				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"jsonschema_validator": dataSourceJsonschemaValidator(),
+				"jsonschema_validator":       dataSourceJsonschemaValidator(),
+				"jsonschema_validator_batch": dataSourceJsonschemaValidatorBatch(),
+				"jsonschema_validator_map":   dataSourceJsonschemaValidatorMap(),
 			},
 			ConfigureContextFunc: providerConfigure,
 		}
@@ -53,5 +104,39 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diag.FromErr(err)
 	}
 
+	config.StrictFormat = d.Get("strict_format").(bool)
+
+	if customFormats, ok := d.Get("custom_formats").(map[string]interface{}); ok {
+		if err := config.SetCustomFormats(customFormats); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+
+	config.Offline = d.Get("offline").(bool)
+	config.NormalizeNumbers = d.Get("normalize_numbers").(bool)
+	config.ValidateSchema = d.Get("validate_schema").(bool)
+	config.RedactValues = d.Get("redact_values").(bool)
+
+	if refOverrides, ok := d.Get("ref_overrides").(map[string]interface{}); ok {
+		config.RefOverrides = make(map[string]string, len(refOverrides))
+		for remoteURL, localPath := range refOverrides {
+			config.RefOverrides[remoteURL], _ = localPath.(string)
+		}
+	}
+
+	config.SchemaCacheDir = d.Get("schema_cache_dir").(string)
+	if config.SchemaCacheDir == "" {
+		config.SchemaCacheDir = defaultSchemaCacheDir()
+	}
+
 	return config, diags
 }
+
+// defaultSchemaCacheDir returns the default remote schema cache location ("" disables caching)
+func defaultSchemaCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "terraform-provider-jsonschema")
+}
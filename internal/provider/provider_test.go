@@ -113,6 +113,67 @@ func TestProviderConfigureFunction(t *testing.T) {
 			expectError:   true,
 			errorContains: "unsupported JSON Schema version",
 		},
+		{
+			name: "valid custom formats",
+			configData: map[string]interface{}{
+				"schema_version":         "draft/2020-12",
+				"error_message_template": "",
+				"custom_formats": map[string]interface{}{
+					"employee-id": "^E[0-9]{6}$",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid custom format regex",
+			configData: map[string]interface{}{
+				"schema_version":         "draft/2020-12",
+				"error_message_template": "",
+				"custom_formats": map[string]interface{}{
+					"employee-id": "^E[0-9{6}$",
+				},
+			},
+			expectError:   true,
+			errorContains: `invalid regex for format "employee-id"`,
+		},
+		{
+			name: "offline mode",
+			configData: map[string]interface{}{
+				"schema_version":         "draft/2020-12",
+				"error_message_template": "",
+				"offline":                true,
+			},
+			expectError: false,
+		},
+		{
+			name: "normalize numbers",
+			configData: map[string]interface{}{
+				"schema_version":         "draft/2020-12",
+				"error_message_template": "",
+				"normalize_numbers":      true,
+			},
+			expectError: false,
+		},
+		{
+			name: "validate schema",
+			configData: map[string]interface{}{
+				"schema_version":         "draft/2020-12",
+				"error_message_template": "",
+				"validate_schema":        true,
+			},
+			expectError: false,
+		},
+		{
+			name: "provider-level ref_overrides",
+			configData: map[string]interface{}{
+				"schema_version":         "draft/2020-12",
+				"error_message_template": "",
+				"ref_overrides": map[string]interface{}{
+					"https://example.com/user.json": "./schemas/user.json",
+				},
+			},
+			expectError: false,
+		},
 		{
 			name: "empty configuration (should use defaults)",
 			configData: map[string]interface{}{
@@ -185,6 +246,31 @@ func TestProviderConfigureFunction(t *testing.T) {
 			if config.DefaultErrorTemplate != expectedErrorTemplate {
 				t.Errorf("expected error template %q, got %q", expectedErrorTemplate, config.DefaultErrorTemplate)
 			}
+
+			expectedOffline, _ := tt.configData["offline"].(bool)
+			if config.Offline != expectedOffline {
+				t.Errorf("expected offline %v, got %v", expectedOffline, config.Offline)
+			}
+
+			expectedNormalize, _ := tt.configData["normalize_numbers"].(bool)
+			if config.NormalizeNumbers != expectedNormalize {
+				t.Errorf("expected normalize_numbers %v, got %v", expectedNormalize, config.NormalizeNumbers)
+			}
+
+			expectedValidateSchema, _ := tt.configData["validate_schema"].(bool)
+			if config.ValidateSchema != expectedValidateSchema {
+				t.Errorf("expected validate_schema %v, got %v", expectedValidateSchema, config.ValidateSchema)
+			}
+
+			expectedOverrides, _ := tt.configData["ref_overrides"].(map[string]interface{})
+			if len(config.RefOverrides) != len(expectedOverrides) {
+				t.Errorf("expected %d ref_overrides, got %v", len(expectedOverrides), config.RefOverrides)
+			}
+			for remoteURL, localPath := range expectedOverrides {
+				if config.RefOverrides[remoteURL] != localPath {
+					t.Errorf("expected ref_overrides[%q] = %q, got %q", remoteURL, localPath, config.RefOverrides[remoteURL])
+				}
+			}
 		})
 	}
 }
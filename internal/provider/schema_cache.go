@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// compiledSchemas is shared by every data source read in the provider process.
+// Terraform reads data sources in parallel, so it is guarded by a mutex.
+var compiledSchemas = &schemaCache{entries: make(map[string]*jsonschema.Schema)}
+
+// schemaCache holds compiled schemas keyed by schemaCacheKey. A compiled schema is
+// immutable, so the same instance can validate documents from concurrent reads.
+type schemaCache struct {
+	mu      sync.RWMutex
+	entries map[string]*jsonschema.Schema
+}
+
+func (c *schemaCache) get(key string) (*jsonschema.Schema, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	compiled, ok := c.entries[key]
+	return compiled, ok
+}
+
+func (c *schemaCache) put(key string, compiled *jsonschema.Schema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = compiled
+}
+
+func (c *schemaCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*jsonschema.Schema)
+}
+
+// schemaCacheInputs is everything that affects how a schema compiles
+type schemaCacheInputs struct {
+	schemaURL     string
+	schemaJSON    []byte
+	draft         *jsonschema.Draft
+	overridesJSON []byte            // deterministic JSON of the registered ref overrides
+	mirrors       map[string]string // "**" patterns -> local directories
+	strictFormat  bool
+	dataKeyword   bool
+	offline       bool
+	formats       []*jsonschema.Format
+	fileAllowlist []string // file_ref_allowlist patterns, so an unrestricted compile isn't reused
+}
+
+// schemaCacheKey combines the schema's content hash (as in schema_sha256), the draft
+// and a hash of the ref overrides. The URL is included because relative $refs resolve
+// against it; custom formats are compared by identity since their regex isn't kept.
+func schemaCacheKey(in schemaCacheInputs) string {
+	mirrorKeys := make([]string, 0, len(in.mirrors))
+	for pattern := range in.mirrors {
+		mirrorKeys = append(mirrorKeys, pattern+"="+in.mirrors[pattern])
+	}
+	sort.Strings(mirrorKeys)
+
+	formats := make([]string, 0, len(in.formats))
+	for _, format := range in.formats {
+		formats = append(formats, fmt.Sprintf("%s@%p", format.Name, format))
+	}
+
+	return hash(strings.Join([]string{
+		in.schemaURL,
+		hash(string(in.schemaJSON)),
+		in.draft.String(),
+		hash(string(in.overridesJSON)),
+		strings.Join(mirrorKeys, ","),
+		fmt.Sprintf("strict_format=%t,data_keyword=%t,offline=%t", in.strictFormat, in.dataKeyword, in.offline),
+		strings.Join(formats, ","),
+		strings.Join(in.fileAllowlist, ","),
+	}, "\n"))
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
+)
+
+func TestCompileSchema_Cache(t *testing.T) {
+	compiledSchemas.reset()
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	const remoteURL = "https://example.com/name.json"
+	schemaPath := writeFile("schema.json", `{"type": "object", "properties": {"name": {"$ref": "`+remoteURL+`"}}}`)
+	stringOverride := writeFile("string.json", `{"type": "string"}`)
+	integerOverride := writeFile("integer.json", `{"type": "integer"}`)
+
+	compile := func(t *testing.T, raw map[string]interface{}, schemaVersion string) interface{} {
+		t.Helper()
+		raw["schema"] = schemaPath
+		d := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, raw)
+		compiled, _, err := compileSchema(d, &ProviderConfig{}, &validator.RemoteFetcher{}, schemaPath, nil, schemaVersion)
+		if err != nil {
+			t.Fatalf("compileSchema() error = %v", err)
+		}
+		return compiled
+	}
+	withOverride := func(path string) map[string]interface{} {
+		return map[string]interface{}{"ref_overrides": map[string]interface{}{remoteURL: path}}
+	}
+
+	first := compile(t, withOverride(stringOverride), "")
+	if again := compile(t, withOverride(stringOverride), ""); again != first {
+		t.Error("expected identical inputs to reuse the compiled schema")
+	}
+	if other := compile(t, withOverride(integerOverride), ""); other == first {
+		t.Error("expected different ref overrides to compile a new schema")
+	}
+	if other := compile(t, withOverride(stringOverride), "draft-07"); other == first {
+		t.Error("expected a different draft to compile a new schema")
+	}
+
+	// Changing the schema file changes schema_sha256 and so the key
+	writeFile("schema.json", `{"type": "object", "properties": {"name": {"$ref": "`+remoteURL+`"}}, "required": ["name"]}`)
+	if other := compile(t, withOverride(stringOverride), ""); other == first {
+		t.Error("expected a changed schema file to compile a new schema")
+	}
+}
+
+func TestCompileSchema_CacheConcurrentReads(t *testing.T) {
+	compiledSchemas.reset()
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object", "required": ["name"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	documentPath := filepath.Join(dir, "document.json")
+	if err := os.WriteFile(documentPath, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d := schema.TestResourceDataRaw(t, dataSourceJsonschemaValidator().Schema, map[string]interface{}{
+				"document": documentPath,
+				"schema":   schemaPath,
+			})
+			errs <- dataSourceJsonschemaValidatorRead(d, &ProviderConfig{DefaultErrorTemplate: "{{.FullMessage}}"})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err == nil {
+			t.Error("expected validation error from every concurrent read")
+		}
+	}
+}
+
+func BenchmarkCompileSchema(b *testing.B) {
+	dir := b.TempDir()
+	properties := ""
+	for i := 0; i < 50; i++ {
+		properties += fmt.Sprintf(`"field%d": {"type": "string", "pattern": "^[a-z]+[0-9]*$", "maxLength": 64},`, i)
+	}
+	schemaPath := filepath.Join(dir, "schema.json")
+	content := `{"type": "object", "properties": {` + properties + `"id": {"type": "integer"}}}`
+	if err := os.WriteFile(schemaPath, []byte(content), 0644); err != nil {
+		b.Fatal(err)
+	}
+
+	d := dataSourceJsonschemaValidator().TestResourceData()
+	if err := d.Set("schema", schemaPath); err != nil {
+		b.Fatal(err)
+	}
+	compile := func(b *testing.B) {
+		if _, _, err := compileSchema(d, &ProviderConfig{}, &validator.RemoteFetcher{}, schemaPath, nil, ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			compiledSchemas.reset()
+			compile(b)
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		compiledSchemas.reset()
+		for i := 0; i < b.N; i++ {
+			compile(b)
+		}
+	})
+}
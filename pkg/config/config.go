@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
+
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
 )
 
 // Config represents the complete configuration for jsonschema-validator
@@ -21,6 +24,38 @@ type Config struct {
 	// ErrorTemplate is a custom error message template using Go template syntax
 	// Matches Terraform provider's "error_message_template" field
 	ErrorTemplate string `koanf:"error_template" json:"errorTemplate" yaml:"error_template" toml:"error_template" mapstructure:"error_template"`
+
+	// RefOverrides maps remote $ref URLs to local file paths for every schema
+	// A schema's own ref_overrides take precedence for the same URL
+	RefOverrides map[string]string `koanf:"ref_overrides" json:"refOverrides" yaml:"ref_overrides" toml:"ref_overrides" mapstructure:"ref_overrides"`
+
+	// ForbidDuplicateKeys rejects JSON/JSON5 documents that repeat an object key
+	// Standard parsers silently keep the last value, which can hide mistakes
+	ForbidDuplicateKeys bool `koanf:"forbid_duplicate_keys" json:"forbidDuplicateKeys" yaml:"forbid_duplicate_keys" toml:"forbid_duplicate_keys" mapstructure:"forbid_duplicate_keys"`
+
+	// NoNetwork rejects remote (http/https) $refs that are not covered by ref_overrides
+	// Mirrors the Terraform provider's "offline" setting
+	NoNetwork bool `koanf:"no_network" json:"noNetwork" yaml:"no_network" toml:"no_network" mapstructure:"no_network"`
+
+	// ValidateSchema checks every schema against its draft's meta-schema before compiling it
+	// Mirrors the Terraform provider's "validate_schema" setting
+	ValidateSchema bool `koanf:"validate_schema" json:"validateSchema" yaml:"validate_schema" toml:"validate_schema" mapstructure:"validate_schema"`
+
+	// MaxErrors caps the errors shown in a document's error message, lowest paths first
+	// Mirrors the Terraform provider's "max_errors" field; 0 shows all
+	MaxErrors int `koanf:"max_errors" json:"maxErrors" yaml:"max_errors" toml:"max_errors" mapstructure:"max_errors"`
+
+	// FetchTimeout bounds each HTTP(S) request for a remote document or $ref, e.g. "10s"
+	// Mirrors the Terraform provider's "schema_fetch_timeout" field; empty means 30s
+	FetchTimeout string `koanf:"fetch_timeout" json:"fetchTimeout" yaml:"fetch_timeout" toml:"fetch_timeout" mapstructure:"fetch_timeout"`
+
+	// FailFast stops at the first invalid document, skipping the remaining documents
+	// and schemas; the results so far are still reported
+	FailFast bool `koanf:"fail_fast" json:"failFast" yaml:"fail_fast" toml:"fail_fast" mapstructure:"fail_fast"`
+
+	// Jobs is how many documents of a schema are validated concurrently
+	// Results are still reported in document order; 0 or 1 validates one at a time
+	Jobs int `koanf:"jobs" json:"jobs" yaml:"jobs" toml:"jobs" mapstructure:"jobs"`
 }
 
 // SchemaConfig represents a single schema with its document mappings
@@ -36,7 +71,7 @@ type SchemaConfig struct {
 
 	// ForceFiletype overrides automatic file type detection for documents
 	// Matches Terraform provider's "force_filetype" field
-	// Valid values: "json", "json5", "yaml", "toml"
+	// Valid values: "json", "jsonc", "json5", "yaml", "toml", "jsonl", "msgpack", "cbor"
 	// Empty string means auto-detect from file extension
 	ForceFiletype string `koanf:"force_filetype" json:"forceFiletype" yaml:"force_filetype" toml:"force_filetype" mapstructure:"force_filetype"`
 
@@ -60,16 +95,40 @@ func (c *Config) Validate() error {
 	if len(c.Schemas) == 0 {
 		return fmt.Errorf("no schemas configured")
 	}
+	if c.MaxErrors < 0 {
+		return fmt.Errorf("max_errors must be 0 or more, got %d", c.MaxErrors)
+	}
+	if _, err := c.GetFetchTimeout(); err != nil {
+		return err
+	}
+	if c.Jobs < 0 {
+		return fmt.Errorf("jobs must be 0 or more, got %d", c.Jobs)
+	}
 
 	for i, schema := range c.Schemas {
 		if err := schema.Validate(); err != nil {
 			return fmt.Errorf("schema[%d]: %w", i, err)
 		}
+		if _, err := schema.GetEffectiveErrorTemplate(c.ErrorTemplate); err != nil {
+			return fmt.Errorf("schema[%d]: error_template: %w", i, err)
+		}
 	}
 
 	return nil
 }
 
+// GetFetchTimeout returns FetchTimeout as a duration, or the default when it is empty
+func (c *Config) GetFetchTimeout() (time.Duration, error) {
+	if c.FetchTimeout == "" {
+		return validator.DefaultFetchTimeout, nil
+	}
+	timeout, err := time.ParseDuration(c.FetchTimeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid fetch_timeout %q: must be a positive duration such as \"30s\"", c.FetchTimeout)
+	}
+	return timeout, nil
+}
+
 // Validate checks if a schema configuration is valid
 func (s *SchemaConfig) Validate() error {
 	if s.Path == "" {
@@ -80,7 +139,10 @@ func (s *SchemaConfig) Validate() error {
 		return fmt.Errorf("at least one document is required")
 	}
 
-	// Check if schema file exists
+	// Check if schema file exists; "-" is standard input
+	if s.Path == "-" {
+		return nil
+	}
 	if _, err := os.Stat(s.Path); err != nil {
 		return fmt.Errorf("schema file %q: %w", s.Path, err)
 	}
@@ -99,11 +161,14 @@ func (s *SchemaConfig) GetEffectiveSchemaVersion(globalVersion string) string {
 
 // GetEffectiveErrorTemplate returns the error template to use
 // Priority: schema-level > global-level > empty (use default)
-func (s *SchemaConfig) GetEffectiveErrorTemplate(globalTemplate string) string {
+// A "@name" reference (e.g. "@detailed") is expanded to the common template of that
+// name, as the Terraform provider's error_message_template does
+func (s *SchemaConfig) GetEffectiveErrorTemplate(globalTemplate string) (string, error) {
+	errorTemplate := globalTemplate
 	if s.ErrorTemplate != "" {
-		return s.ErrorTemplate
+		errorTemplate = s.ErrorTemplate
 	}
-	return globalTemplate
+	return validator.ResolveErrorTemplate(errorTemplate)
 }
 
 // GetEffectiveForceFiletype returns the force filetype to use
@@ -134,8 +199,8 @@ func (s *SchemaConfig) ExpandDocumentGlobs() ([]string, error) {
 	var expanded []string
 
 	for _, pattern := range s.Documents {
-		// Check if pattern contains glob characters
-		if !containsGlobChars(pattern) {
+		// Check if pattern contains glob characters; a URL's "?" starts its query
+		if !containsGlobChars(pattern) || validator.IsRemoteURL(pattern) {
 			// Not a glob pattern, add as-is
 			expanded = append(expanded, pattern)
 			continue
@@ -159,6 +224,26 @@ func (s *SchemaConfig) ExpandDocumentGlobs() ([]string, error) {
 	return expanded, nil
 }
 
+// UnmatchedDocumentGlobs returns the glob patterns in document paths that match no files
+// ExpandDocumentGlobs skips these silently; callers that need to report them use this
+func (s *SchemaConfig) UnmatchedDocumentGlobs() []string {
+	var unmatched []string
+
+	for _, pattern := range s.Documents {
+		if !containsGlobChars(pattern) || validator.IsRemoteURL(pattern) {
+			continue
+		}
+
+		// Invalid patterns are reported by ExpandDocumentGlobs
+		matches, err := filepath.Glob(pattern)
+		if err == nil && len(matches) == 0 {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+
+	return unmatched
+}
+
 // containsGlobChars checks if a string contains glob pattern characters
 func containsGlobChars(s string) bool {
 	for _, ch := range s {
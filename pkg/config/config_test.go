@@ -3,7 +3,11 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -33,6 +37,32 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative max_errors",
+			config: &Config{
+				MaxErrors: -1,
+				Schemas: []SchemaConfig{
+					{
+						Path:      "-",
+						Documents: []string{"test.json"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown named error template",
+			config: &Config{
+				ErrorTemplate: "@nope",
+				Schemas: []SchemaConfig{
+					{
+						Path:      "-",
+						Documents: []string{"test.json"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -75,6 +105,14 @@ func TestSchemaConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "schema from stdin",
+			schema: &SchemaConfig{
+				Path:      "-",
+				Documents: []string{"test.json"},
+			},
+			wantErr: false,
+		},
 		{
 			name: "empty path",
 			schema: &SchemaConfig{
@@ -156,6 +194,7 @@ func TestSchemaConfig_GetEffectiveErrorTemplate(t *testing.T) {
 		schemaTemplate string
 		globalTemplate string
 		want           string
+		errorContains  string
 	}{
 		{
 			name:           "schema level takes precedence",
@@ -175,6 +214,22 @@ func TestSchemaConfig_GetEffectiveErrorTemplate(t *testing.T) {
 			globalTemplate: "",
 			want:           "",
 		},
+		{
+			name:           "schema level named template",
+			schemaTemplate: "@detailed",
+			globalTemplate: "global template",
+			want:           validator.CommonErrorTemplates["detailed"],
+		},
+		{
+			name:           "global named template",
+			globalTemplate: "@with_path",
+			want:           validator.CommonErrorTemplates["with_path"],
+		},
+		{
+			name:           "unknown named template",
+			schemaTemplate: "@detail",
+			errorContains:  `unknown error template "@detail" (available: @basic, @detailed`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -182,7 +237,17 @@ func TestSchemaConfig_GetEffectiveErrorTemplate(t *testing.T) {
 			s := &SchemaConfig{
 				ErrorTemplate: tt.schemaTemplate,
 			}
-			if got := s.GetEffectiveErrorTemplate(tt.globalTemplate); got != tt.want {
+			got, err := s.GetEffectiveErrorTemplate(tt.globalTemplate)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetEffectiveErrorTemplate() error = %v", err)
+			}
+			if got != tt.want {
 				t.Errorf("GetEffectiveErrorTemplate() = %v, want %v", got, tt.want)
 			}
 		})
@@ -346,6 +411,12 @@ func TestSchemaConfig_ExpandDocumentGlobs(t *testing.T) {
 			wantCount: 0, // No error, just no matches
 			wantErr:   false,
 		},
+		{
+			name:      "URL with a query is not a glob",
+			documents: []string{"https://config.internal/service.json?env=prod"},
+			wantCount: 1,
+			wantErr:   false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -365,6 +436,48 @@ func TestSchemaConfig_ExpandDocumentGlobs(t *testing.T) {
 	}
 }
 
+func TestSchemaConfig_UnmatchedDocumentGlobs(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "config.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		documents []string
+		want      []string
+	}{
+		{
+			name:      "matching glob",
+			documents: []string{filepath.Join(tempDir, "*.json")},
+			want:      nil,
+		},
+		{
+			name:      "plain path is never reported",
+			documents: []string{filepath.Join(tempDir, "missing.json")},
+			want:      nil,
+		},
+		{
+			name: "glob without matches",
+			documents: []string{
+				filepath.Join(tempDir, "*.json"),
+				filepath.Join(tempDir, "*.yaml"),
+			},
+			want: []string{filepath.Join(tempDir, "*.yaml")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SchemaConfig{Documents: tt.documents}
+			got := s.UnmatchedDocumentGlobs()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmatchedDocumentGlobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestContainsGlobChars(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// expandEnv replaces ${VAR} and ${VAR:-default} references in the path-like
+// fields of the configuration: schema paths, document patterns and ref_overrides
+// targets. Error templates are left alone. References to unset variables without
+// a default are collected and reported together.
+func (c *Config) expandEnv() error {
+	var syntaxErr error
+	undefined := make(map[string]bool)
+	expand := func(s string) string {
+		expanded, missing, err := expandEnvRefs(s)
+		if err != nil {
+			if syntaxErr == nil {
+				syntaxErr = err
+			}
+			return s
+		}
+		for _, name := range missing {
+			undefined[name] = true
+		}
+		return expanded
+	}
+
+	for target, path := range c.RefOverrides {
+		c.RefOverrides[target] = expand(path)
+	}
+	for i := range c.Schemas {
+		schema := &c.Schemas[i]
+		schema.Path = expand(schema.Path)
+		for j, document := range schema.Documents {
+			schema.Documents[j] = expand(document)
+		}
+		for target, path := range schema.RefOverrides {
+			schema.RefOverrides[target] = expand(path)
+		}
+	}
+
+	if syntaxErr != nil {
+		return fmt.Errorf("expanding environment variables: %w", syntaxErr)
+	}
+	if len(undefined) > 0 {
+		names := make([]string, 0, len(undefined))
+		for name := range undefined {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("undefined environment variable(s) in configuration: %s", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// rebasePaths makes the relative path-like fields of the configuration (schema paths,
+// document patterns and ref_overrides targets) relative to dir instead of the working
+// directory, for a configuration file found in dir. "-" (stdin) is left alone.
+func (c *Config) rebasePaths(dir string) {
+	rebase := func(path string) string {
+		if path == "" || path == "-" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+
+	for target, path := range c.RefOverrides {
+		c.RefOverrides[target] = rebase(path)
+	}
+	for i := range c.Schemas {
+		schema := &c.Schemas[i]
+		schema.Path = rebase(schema.Path)
+		for j, document := range schema.Documents {
+			schema.Documents[j] = rebase(document)
+		}
+		for target, path := range schema.RefOverrides {
+			schema.RefOverrides[target] = rebase(path)
+		}
+	}
+}
+
+// expandEnvRefs replaces ${VAR} and ${VAR:-default} in s. The default is used when VAR is
+// unset or empty; an unset VAR without a default is returned in missing and expands
+// to the empty string. A "$" not followed by "{" is kept as-is.
+func expandEnvRefs(s string) (expanded string, missing []string, err error) {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String(), missing, nil
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", nil, fmt.Errorf("unterminated ${ in %q", s)
+		}
+		end += start
+
+		b.WriteString(s[:start])
+		name, def, hasDefault := strings.Cut(s[start+2:end], ":-")
+		value, ok := os.LookupEnv(name)
+		switch {
+		case hasDefault && value == "":
+			value = def
+		case !ok:
+			missing = append(missing, name)
+		}
+		b.WriteString(value)
+		s = s[end+1:]
+	}
+}
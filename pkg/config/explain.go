@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Explain describes each field of cfg, the configuration this loader produced, with
+// the source that last set it, one "key = value (from source)" line per field in
+// declaration order, e.g.
+//
+//	schema_version = draft/2020-12 (from env JSONSCHEMA_VALIDATOR_SCHEMA_VERSION)
+//
+// Lists and maps are written as compact JSON. Fields no source set come "from default".
+func (l *Loader) Explain(cfg *Config) (string, error) {
+	var b strings.Builder
+	value := reflect.ValueOf(*cfg)
+	for i := 0; i < value.NumField(); i++ {
+		key := value.Type().Field(i).Tag.Get("koanf")
+		if key == "" {
+			continue
+		}
+		formatted, err := formatConfigValue(value.Field(i).Interface())
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", key, err)
+		}
+		source, ok := l.sources[key]
+		if !ok {
+			source = "default"
+		}
+		fmt.Fprintf(&b, "%s = %s (from %s)\n", key, formatted, source)
+	}
+	return b.String(), nil
+}
+
+// formatConfigValue writes a string as it is (quoted when empty or multi-line), a
+// bool as true/false and anything else as JSON with the configuration file's keys,
+// leaving out unset fields of the objects inside
+func formatConfigValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if v == "" || strings.ContainsAny(v, "\n\"") {
+			return strconv.Quote(v), nil
+		}
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+
+	// The yaml tags match the configuration keys; the json tags are camelCase
+	encoded, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal(encoded, &generic); err != nil {
+		return "", err
+	}
+	compact, err := json.Marshal(omitUnset(generic))
+	if err != nil {
+		return "", err
+	}
+	return string(compact), nil
+}
+
+// omitUnset removes empty strings, lists and maps and false values from the objects
+// in value, so a schema entry only shows the fields it sets
+func omitUnset(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = omitUnset(item)
+			if isUnset(v[key]) {
+				delete(v, key)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = omitUnset(item)
+		}
+	}
+	return value
+}
+
+func isUnset(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// profilesKey holds the named profiles; it is the only top-level key that is not a
+// Config field
+const profilesKey = "profiles"
+
+// checkUnknownKeys reports keys of a loaded configuration file that no Config or
+// SchemaConfig field reads, such as a misspelled "schema_versoin", which would
+// otherwise be ignored. The keys of every profile are checked like top-level keys.
+func checkUnknownKeys(raw map[string]interface{}) error {
+	var unknown []string
+	checkConfigKeys(raw, "", true, &unknown)
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown configuration key(s): %s", strings.Join(unknown, ", "))
+}
+
+// checkConfigKeys appends the unknown keys of a top-level (or profile) mapping to
+// unknown, each prefixed with prefix
+func checkConfigKeys(raw map[string]interface{}, prefix string, allowProfiles bool, unknown *[]string) {
+	known := koanfKeys(reflect.TypeOf(Config{}))
+	if allowProfiles {
+		known = append(known, profilesKey)
+	}
+	for key, value := range raw {
+		switch {
+		case key == profilesKey && allowProfiles:
+			profiles, _ := value.(map[string]interface{})
+			for name, profile := range profiles {
+				if profile, ok := profile.(map[string]interface{}); ok {
+					checkConfigKeys(profile, prefix+profilesKey+"."+name+".", false, unknown)
+				}
+			}
+		case key == "schemas":
+			schemas, _ := value.([]interface{})
+			schemaKnown := koanfKeys(reflect.TypeOf(SchemaConfig{}))
+			for i, schema := range schemas {
+				entry, _ := schema.(map[string]interface{})
+				for schemaKey := range entry {
+					if !slices.Contains(schemaKnown, schemaKey) {
+						*unknown = append(*unknown, describeUnknownKey(fmt.Sprintf("%sschemas[%d].%s", prefix, i, schemaKey), schemaKey, schemaKnown))
+					}
+				}
+			}
+		case !slices.Contains(known, key):
+			*unknown = append(*unknown, describeUnknownKey(prefix+key, key, known))
+		}
+	}
+}
+
+// koanfKeys returns the koanf tag of every field of a struct type
+func koanfKeys(t reflect.Type) []string {
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("koanf"); tag != "" {
+			keys = append(keys, tag)
+		}
+	}
+	return keys
+}
+
+// describeUnknownKey names an unknown key by its path, suggesting the known key
+// closest to it when the difference looks like a typo
+func describeUnknownKey(path, key string, known []string) string {
+	best, bestDistance := "", len(key)
+	for _, candidate := range known {
+		if distance := editDistance(key, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	// At most two edits, and never most of the key
+	if best != "" && bestDistance <= 2 && bestDistance*2 < len(key) {
+		return fmt.Sprintf("%q (did you mean %q?)", path, best)
+	}
+	return fmt.Sprintf("%q", path)
+}
+
+// editDistance is the Damerau-Levenshtein (optimal string alignment) distance, so a
+// swap of adjacent letters ("versoin") counts as one edit
+func editDistance(a, b string) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
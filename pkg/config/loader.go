@@ -28,6 +28,13 @@ var unmarshalConf = koanf.UnmarshalConf{
 type Loader struct {
 	k         *koanf.Koanf
 	envPrefix string
+	profile   string
+	sources   map[string]string // top-level key -> the source that last set it
+
+	noConfigSearch bool
+	projectDir     string // directory of a project config found above the working directory
+
+	userHomeDir func() (string, error) // os.UserHomeDir, replaced in tests
 }
 
 // NewLoader creates a new configuration loader with default environment prefix
@@ -35,6 +42,9 @@ func NewLoader() *Loader {
 	return &Loader{
 		k:         koanf.New("."),
 		envPrefix: "JSONSCHEMA_VALIDATOR_",
+		sources:   make(map[string]string),
+
+		userHomeDir: os.UserHomeDir,
 	}
 }
 
@@ -44,40 +54,99 @@ func (l *Loader) SetEnvPrefix(prefix string) {
 	l.envPrefix = prefix
 }
 
+// SetProfile selects a named profile from the "profiles" section of the config file
+// Takes precedence over the <prefix>PROFILE environment variable
+func (l *Loader) SetProfile(name string) {
+	l.profile = name
+}
+
+// DisableConfigSearch limits project config discovery to the current directory
+// instead of also searching its parent directories
+func (l *Loader) DisableConfigSearch() {
+	l.noConfigSearch = true
+}
+
 // Load loads configuration from all available sources in priority order:
 // 1. Command-line flags (highest priority)
 // 2. Environment variables (customizable prefix, default: JSONSCHEMA_VALIDATOR_*)
-// 3. .jsonschema-validator.yaml in current directory
-// 4. pyproject.toml section [tool.jsonschema-validator]
-// 5. Default values (lowest priority)
+// 3. Selected profile from the "profiles" section (if any)
+// 4. .jsonschema-validator.yaml in current directory or the nearest parent that has one
+// 5. pyproject.toml section [tool.jsonschema-validator]
+// 6. package.json field "jsonschema-validator"
+// 7. ~/.jsonschema-validator.yaml, only when none of 4-6 configure the project
+// 8. Default values (lowest priority)
 func (l *Loader) Load(flags *flag.FlagSet) (*Config, error) {
 	// 1. Load defaults (lowest priority)
 	if err := l.loadDefaults(); err != nil {
 		return nil, fmt.Errorf("loading defaults: %w", err)
 	}
 
-	// 2. Load from pyproject.toml (if exists)
-	if err := l.loadPyprojectTOML(); err != nil {
-		// Optional, don't fail if not found
-		if !os.IsNotExist(err) {
+	projectConfig, err := l.findProjectConfig()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("loading project config: %w", err)
+	}
+
+	// package.json and pyproject.toml sections are project config too
+	packageConfig, err := readPackageJSON()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("loading package.json config: %w", err)
+	}
+	pyprojectConfig, err := readPyprojectTOML()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("loading pyproject.toml config: %w", err)
+	}
+
+	// 2. Load from ~/.jsonschema-validator.yaml (if exists and there is no project config)
+	if projectConfig == "" && packageConfig == nil && pyprojectConfig == nil {
+		if err := l.loadHomeConfig(); err != nil {
+			// Optional, don't fail if not found
+			if !os.IsNotExist(err) {
+				return nil, fmt.Errorf("loading home config: %w", err)
+			}
+		}
+	}
+
+	// 3. Load from package.json (if exists)
+	if packageConfig != nil {
+		if err := l.mergeSection(packageConfig, `package.json "jsonschema-validator"`); err != nil {
+			return nil, fmt.Errorf("loading package.json config: %w", err)
+		}
+	}
+
+	// 4. Load from pyproject.toml (if exists)
+	if pyprojectConfig != nil {
+		if err := l.mergeSection(pyprojectConfig, "pyproject.toml [tool.jsonschema-validator]"); err != nil {
 			return nil, fmt.Errorf("loading pyproject.toml config: %w", err)
 		}
 	}
 
-	// 3. Load from .jsonschema-validator.yaml (if exists)
-	if err := l.loadProjectConfig(); err != nil {
-		// Optional, don't fail if not found
-		if !os.IsNotExist(err) {
+	// 5. Load from .jsonschema-validator.yaml (if exists)
+	if projectConfig != "" {
+		if err := l.loadSource(filepath.Join(l.projectDir, filepath.Base(projectConfig)), file.Provider(projectConfig), yaml.Parser()); err != nil {
 			return nil, fmt.Errorf("loading project config: %w", err)
 		}
 	}
 
-	// 4. Load from environment variables
+	// Apply the selected profile on top of the file-based configuration
+	if err := l.applyProfile(); err != nil {
+		return nil, err
+	}
+
+	// Only the files are checked; flags and environment variables carry other keys
+	// and string values
+	if err := checkUnknownKeys(l.k.Raw()); err != nil {
+		return nil, err
+	}
+	if err := checkConfigSchema(l.k.Raw()); err != nil {
+		return nil, err
+	}
+
+	// 6. Load from environment variables
 	if err := l.loadEnvVars(); err != nil {
 		return nil, fmt.Errorf("loading environment variables: %w", err)
 	}
 
-	// 5. Load from command-line flags (highest priority)
+	// 7. Load from command-line flags (highest priority)
 	if flags != nil {
 		if err := l.loadFlags(flags); err != nil {
 			return nil, fmt.Errorf("loading flags: %w", err)
@@ -90,6 +159,13 @@ func (l *Loader) Load(flags *flag.FlagSet) (*Config, error) {
 		return nil, fmt.Errorf("unmarshaling config: %w", err)
 	}
 
+	if err := cfg.expandEnv(); err != nil {
+		return nil, err
+	}
+	if l.projectDir != "" {
+		cfg.rebasePaths(l.projectDir)
+	}
+
 	return &cfg, nil
 }
 
@@ -105,27 +181,43 @@ func (l *Loader) LoadFromFile(path string) (*Config, error) {
 
 	switch ext {
 	case ".yaml", ".yml":
-		if err := l.k.Load(file.Provider(path), yaml.Parser()); err != nil {
+		if err := l.loadSource(path, file.Provider(path), yaml.Parser()); err != nil {
 			return nil, fmt.Errorf("loading config file %q: %w", path, err)
 		}
 	case ".toml":
-		if err := l.k.Load(file.Provider(path), toml.Parser()); err != nil {
+		if err := l.loadSource(path, file.Provider(path), toml.Parser()); err != nil {
 			return nil, fmt.Errorf("loading config file %q: %w", path, err)
 		}
 	case ".json":
-		if err := l.k.Load(file.Provider(path), json.Parser()); err != nil {
+		if err := l.loadSource(path, file.Provider(path), json.Parser()); err != nil {
 			return nil, fmt.Errorf("loading config file %q: %w", path, err)
 		}
 	default:
 		return nil, fmt.Errorf("unsupported config file format: %s", ext)
 	}
 
+	// Apply the selected profile on top of the file configuration
+	if err := l.applyProfile(); err != nil {
+		return nil, err
+	}
+
+	if err := checkUnknownKeys(l.k.Raw()); err != nil {
+		return nil, fmt.Errorf("config file %q: %w", path, err)
+	}
+	if err := checkConfigSchema(l.k.Raw()); err != nil {
+		return nil, fmt.Errorf("config file %q: %w", path, err)
+	}
+
 	// Unmarshal into Config struct
 	var cfg Config
 	if err := l.k.UnmarshalWithConf("", &cfg, unmarshalConf); err != nil {
 		return nil, fmt.Errorf("unmarshaling config: %w", err)
 	}
 
+	if err := cfg.expandEnv(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
@@ -135,55 +227,151 @@ func (l *Loader) loadDefaults() error {
 		"schema_version": "", // Empty means use schema's $schema field
 		"schemas":        []interface{}{},
 		"error_template": "", // Empty means use default formatting
+
+		"forbid_duplicate_keys": false,
+		"no_network":            false,
+		"validate_schema":       false,
+		"max_errors":            0,  // 0 shows every error
+		"fetch_timeout":         "", // Empty means 30s
+		"fail_fast":             false,
+		"jobs":                  1,
+	}
+
+	return l.loadSource("default", confmap.Provider(defaults, "."), nil)
+}
+
+// projectConfigNames are the project config file names, in order of preference
+var projectConfigNames = []string{
+	".jsonschema-validator.yaml",
+	".jsonschema-validator.yml",
+	".jsonschema.yaml",
+	".jsonschema.yml",
+	"jsonschema-validator.yaml",
+	"jsonschema-validator.yml",
+}
+
+// findProjectConfig returns the path of .jsonschema-validator.yaml in the current
+// directory or, like git, the nearest parent directory that has one, recording the
+// directory of a parent's file in projectDir. The search stops below the home
+// directory, whose file is the home config.
+func (l *Loader) findProjectConfig() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
 	}
+	home, _ := l.userHomeDir()
+
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		for _, name := range projectConfigNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			if dir != wd {
+				if l.projectDir, err = filepath.Rel(wd, dir); err != nil {
+					l.projectDir = dir
+				}
+			}
+			return path, nil
+		}
 
-	return l.k.Load(confmap.Provider(defaults, "."), nil)
+		parent := filepath.Dir(dir)
+		if l.noConfigSearch || parent == dir || (home != "" && parent == home) {
+			return "", os.ErrNotExist
+		}
+	}
 }
 
-// loadProjectConfig loads configuration from .jsonschema-validator.yaml in current directory
-func (l *Loader) loadProjectConfig() error {
-	// Try multiple file names in order of preference
-	candidates := []string{
-		".jsonschema-validator.yaml",
-		".jsonschema-validator.yml",
-		".jsonschema.yaml",
-		".jsonschema.yml",
-		"jsonschema-validator.yaml",
-		"jsonschema-validator.yml",
-	}
-
-	for _, name := range candidates {
-		if _, err := os.Stat(name); err == nil {
-			return l.k.Load(file.Provider(name), yaml.Parser())
+// loadHomeConfig loads configuration from .jsonschema-validator.yaml in the user's
+// home directory. Its relative paths are relative to the working directory.
+func (l *Loader) loadHomeConfig() error {
+	home, err := l.userHomeDir()
+	if err != nil {
+		return os.ErrNotExist
+	}
+
+	for _, name := range []string{".jsonschema-validator.yaml", ".jsonschema-validator.yml"} {
+		path := filepath.Join(home, name)
+		if _, err := os.Stat(path); err == nil {
+			return l.loadSource(filepath.Join("~", name), file.Provider(path), yaml.Parser())
 		}
 	}
 
 	return os.ErrNotExist
 }
 
-// loadPyprojectTOML loads configuration from pyproject.toml [tool.jsonschema-validator]
-func (l *Loader) loadPyprojectTOML() error {
+// readPyprojectTOML returns the [tool.jsonschema-validator] section of pyproject.toml,
+// or os.ErrNotExist when there is no file or section
+func readPyprojectTOML() (*koanf.Koanf, error) {
 	const configFile = "pyproject.toml"
 
 	if _, err := os.Stat(configFile); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Load the entire TOML file
 	tempK := koanf.New(".")
 	if err := tempK.Load(file.Provider(configFile), toml.Parser()); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Extract only the [tool.jsonschema-validator] section
 	toolConfig := tempK.Cut("tool.jsonschema-validator")
 	if toolConfig == nil || toolConfig.Raw() == nil {
 		// No jsonschema-validator section found
-		return os.ErrNotExist
+		return nil, os.ErrNotExist
+	}
+	return toolConfig, nil
+}
+
+// readPackageJSON returns the "jsonschema-validator" field of package.json, or
+// os.ErrNotExist when there is no file or field
+func readPackageJSON() (*koanf.Koanf, error) {
+	const configFile = "package.json"
+
+	if _, err := os.Stat(configFile); err != nil {
+		return nil, err
+	}
+
+	// Load the entire JSON file
+	tempK := koanf.New(".")
+	if err := tempK.Load(file.Provider(configFile), json.Parser()); err != nil {
+		return nil, err
+	}
+
+	// Extract only the "jsonschema-validator" field
+	fieldConfig := tempK.Cut("jsonschema-validator")
+	if len(fieldConfig.Raw()) == 0 {
+		// No jsonschema-validator field found
+		return nil, os.ErrNotExist
+	}
+	return fieldConfig, nil
+}
+
+// mergeSection merges a section read from a project file into the main config
+func (l *Loader) mergeSection(section *koanf.Koanf, source string) error {
+	l.recordSource(section, source)
+	return l.k.Merge(section)
+}
+
+// applyProfile merges the selected profile over the base configuration
+// The profile name comes from SetProfile or the <prefix>PROFILE environment variable
+func (l *Loader) applyProfile() error {
+	name := l.profile
+	if name == "" {
+		name = os.Getenv(l.envPrefix + "PROFILE")
+	}
+	if name == "" {
+		return nil
+	}
+
+	profile := l.k.Cut("profiles." + name)
+	if len(profile.Raw()) == 0 {
+		return fmt.Errorf("profile %q not found in configuration", name)
 	}
 
-	// Merge the tool section into main config
-	return l.k.Merge(toolConfig)
+	l.recordSource(profile, fmt.Sprintf("profile %q", name))
+	return l.k.Merge(profile)
 }
 
 // loadEnvVars loads configuration from environment variables
@@ -197,12 +385,36 @@ func (l *Loader) loadEnvVars() error {
 	return l.k.Load(env.Provider(l.envPrefix, ".", func(s string) string {
 		// Convert JSONSCHEMA_VALIDATOR_SCHEMA_VERSION to schema_version
 		// Just remove prefix and lowercase - underscores stay as-is
-		s = strings.TrimPrefix(s, l.envPrefix)
-		s = strings.ToLower(s)
-		return s
+		key := strings.ToLower(strings.TrimPrefix(s, l.envPrefix))
+		l.sources[key] = "env " + s
+		return key
 	}), nil)
 }
 
+// loadSource loads a configuration source, recording it as the origin of every
+// top-level key it sets
+func (l *Loader) loadSource(source string, provider koanf.Provider, parser koanf.Parser) error {
+	loaded := koanf.New(".")
+	if err := loaded.Load(provider, parser); err != nil {
+		return err
+	}
+	l.recordSource(loaded, source)
+	return l.k.Merge(loaded)
+}
+
+// recordSource records source as the origin of every top-level key of loaded
+func (l *Loader) recordSource(loaded *koanf.Koanf, source string) {
+	for key := range loaded.Raw() {
+		l.sources[key] = source
+	}
+}
+
+// SetSource records the origin of a top-level key set outside the loader, e.g. by a
+// command-line flag applied to the loaded Config
+func (l *Loader) SetSource(key, source string) {
+	l.sources[key] = source
+}
+
 // loadFlags loads configuration from command-line flags
 func (l *Loader) loadFlags(flags *flag.FlagSet) error {
 	return l.k.Load(posflag.Provider(flags, ".", l.k), nil)
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	flag "github.com/spf13/pflag"
@@ -231,6 +233,149 @@ documents = ["test.json"]
 	}
 }
 
+func TestLoader_LoadPackageJSON(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	packageJSON := `{
+  "name": "test-project",
+  "version": "1.0.0",
+  "scripts": {"validate": "jsonschema-validator"},
+  "jsonschema-validator": {
+    "schema_version": "draft-07",
+    "schemas": [
+      {"path": "test.schema.json", "documents": ["test.json", "config/*.json"]}
+    ]
+  }
+}`
+	if err := os.WriteFile("package.json", []byte(packageJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := NewLoader()
+	cfg, err := loader.Load(nil)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.SchemaVersion != "draft-07" {
+		t.Errorf("schema_version = %q, want %q", cfg.SchemaVersion, "draft-07")
+	}
+	if len(cfg.Schemas) != 1 || cfg.Schemas[0].Path != "test.schema.json" || len(cfg.Schemas[0].Documents) != 2 {
+		t.Fatalf("schemas = %+v, want the package.json entry", cfg.Schemas)
+	}
+	explained, err := loader.Explain(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(explained, `schema_version = draft-07 (from package.json "jsonschema-validator")`) {
+		t.Errorf("Explain() = %s, want package.json as the source", explained)
+	}
+
+	// pyproject.toml and the project config take precedence, in that order
+	if err := os.WriteFile("pyproject.toml", []byte("[tool.jsonschema-validator]\nschema_version = \"draft/2019-09\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err = NewLoader().Load(nil); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.SchemaVersion != "draft/2019-09" || len(cfg.Schemas) != 1 {
+		t.Errorf("with pyproject.toml: schema_version = %q, schemas = %d, want draft/2019-09 and the package.json schema", cfg.SchemaVersion, len(cfg.Schemas))
+	}
+	if err := os.WriteFile(".jsonschema-validator.yaml", []byte("schema_version: draft/2020-12\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err = NewLoader().Load(nil); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.SchemaVersion != "draft/2020-12" {
+		t.Errorf("with .jsonschema-validator.yaml: schema_version = %q, want draft/2020-12", cfg.SchemaVersion)
+	}
+}
+
+func TestLoader_PackageJSONWithoutField(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("package.json", []byte(`{"name": "test-project"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewLoader().Load(nil)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if len(cfg.Schemas) != 0 {
+		t.Errorf("schemas = %+v, want none", cfg.Schemas)
+	}
+}
+
+func TestLoader_HomeConfig(t *testing.T) {
+	home := t.TempDir()
+	if err := os.WriteFile(filepath.Join(home, ".jsonschema-validator.yaml"), []byte("schema_version: draft-07\nno_network: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	project := filepath.Join(home, "src", "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(project)
+
+	load := func() (*Loader, *Config) {
+		t.Helper()
+		loader := NewLoader()
+		loader.userHomeDir = func() (string, error) { return home, nil }
+		cfg, err := loader.Load(nil)
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		return loader, cfg
+	}
+
+	// Without a project config the home config applies
+	loader, cfg := load()
+	if cfg.SchemaVersion != "draft-07" || !cfg.NoNetwork {
+		t.Errorf("without a project config: schema_version = %q, no_network = %v, want the home config", cfg.SchemaVersion, cfg.NoNetwork)
+	}
+	explained, err := loader.Explain(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "(from " + filepath.Join("~", ".jsonschema-validator.yaml") + ")"; !strings.Contains(explained, want) {
+		t.Errorf("Explain() = %s, want a source of %s", explained, want)
+	}
+
+	// package.json and pyproject.toml configure the project as well, and replace it the same way
+	for _, projectFile := range []struct{ name, content string }{
+		{name: "package.json", content: `{"name": "app", "jsonschema-validator": {"schema_version": "draft/2019-09"}}`},
+		{name: "pyproject.toml", content: "[tool.jsonschema-validator]\nschema_version = \"draft/2019-09\"\n"},
+	} {
+		if err := os.WriteFile(projectFile.name, []byte(projectFile.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, cfg = load()
+		if cfg.SchemaVersion != "draft/2019-09" || cfg.NoNetwork {
+			t.Errorf("with %s: schema_version = %q, no_network = %v, want only the %s config", projectFile.name, cfg.SchemaVersion, cfg.NoNetwork, projectFile.name)
+		}
+		if err := os.Remove(projectFile.name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A package.json without the field does not configure the project
+	if err := os.WriteFile("package.json", []byte(`{"name": "app"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, cfg = load(); !cfg.NoNetwork {
+		t.Errorf("with an unrelated package.json: no_network = false, want the home config")
+	}
+
+	// A project config replaces it entirely, including keys it does not set
+	if err := os.WriteFile(".jsonschema-validator.yaml", []byte("schema_version: draft/2020-12\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, cfg = load()
+	if cfg.SchemaVersion != "draft/2020-12" || cfg.NoNetwork {
+		t.Errorf("with a project config: schema_version = %q, no_network = %v, want only the project config", cfg.SchemaVersion, cfg.NoNetwork)
+	}
+}
+
 func TestLoader_LoadEnvVars(t *testing.T) {
 	// Set environment variables
 	os.Setenv("JSONSCHEMA_VALIDATOR_SCHEMA_VERSION", "draft/2019-09")
@@ -247,6 +392,18 @@ func TestLoader_LoadEnvVars(t *testing.T) {
 	}
 }
 
+func TestLoader_MaxErrorsFromEnv(t *testing.T) {
+	t.Setenv("JSONSCHEMA_VALIDATOR_MAX_ERRORS", "2")
+
+	cfg, err := NewLoader().Load(nil)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.MaxErrors != 2 {
+		t.Errorf("max_errors = %d, want 2", cfg.MaxErrors)
+	}
+}
+
 func TestLoader_CustomEnvPrefix(t *testing.T) {
 	// Set environment variables with custom prefix
 	os.Setenv("MY_APP_SCHEMA_VERSION", "draft/2020-12")
@@ -469,3 +626,445 @@ func TestLoader_ConfigPriority(t *testing.T) {
 		t.Errorf("schema_version = %q, want %q (env var should override file)", cfg.SchemaVersion, "draft/2020-12")
 	}
 }
+
+func TestLoader_Profiles(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `
+schema_version: "draft-07"
+error_template: "Base: {{.FullMessage}}"
+schemas:
+  - path: "base.schema.json"
+    documents: ["base.json"]
+profiles:
+  dev:
+    schema_version: "draft/2019-09"
+  prod:
+    schema_version: "draft/2020-12"
+    schemas:
+      - path: "prod.schema.json"
+        documents: ["prod/*.json"]
+`
+
+	if err := os.WriteFile(configFile, []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		profile           string
+		envProfile        string
+		wantSchemaVersion string
+		wantSchemaPath    string
+		wantErr           bool
+	}{
+		{
+			name:              "no profile uses base config",
+			wantSchemaVersion: "draft-07",
+			wantSchemaPath:    "base.schema.json",
+		},
+		{
+			name:              "profile overrides only its own fields",
+			profile:           "dev",
+			wantSchemaVersion: "draft/2019-09",
+			wantSchemaPath:    "base.schema.json",
+		},
+		{
+			name:              "profile replaces schemas list",
+			profile:           "prod",
+			wantSchemaVersion: "draft/2020-12",
+			wantSchemaPath:    "prod.schema.json",
+		},
+		{
+			name:              "profile selected via environment variable",
+			envProfile:        "prod",
+			wantSchemaVersion: "draft/2020-12",
+			wantSchemaPath:    "prod.schema.json",
+		},
+		{
+			name:              "explicit profile takes precedence over environment variable",
+			profile:           "dev",
+			envProfile:        "prod",
+			wantSchemaVersion: "draft/2019-09",
+			wantSchemaPath:    "base.schema.json",
+		},
+		{
+			name:    "unknown profile",
+			profile: "staging",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.envProfile != "" {
+				t.Setenv("JSONSCHEMA_VALIDATOR_PROFILE", tt.envProfile)
+			}
+
+			loader := NewLoader()
+			loader.SetProfile(tt.profile)
+
+			cfg, err := loader.LoadFromFile(configFile)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("LoadFromFile() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadFromFile() failed: %v", err)
+			}
+
+			if cfg.SchemaVersion != tt.wantSchemaVersion {
+				t.Errorf("schema_version = %q, want %q", cfg.SchemaVersion, tt.wantSchemaVersion)
+			}
+			if cfg.ErrorTemplate != "Base: {{.FullMessage}}" {
+				t.Errorf("error_template = %q, want base value", cfg.ErrorTemplate)
+			}
+			if len(cfg.Schemas) != 1 || cfg.Schemas[0].Path != tt.wantSchemaPath {
+				t.Errorf("schemas = %+v, want single schema %q", cfg.Schemas, tt.wantSchemaPath)
+			}
+		})
+	}
+}
+
+func TestLoader_ForbidDuplicateKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `
+forbid_duplicate_keys: true
+schemas:
+  - path: "test.schema.json"
+    documents: ["test.json"]
+`
+
+	if err := os.WriteFile(configFile, []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewLoader().LoadFromFile(configFile)
+	if err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+
+	if !cfg.ForbidDuplicateKeys {
+		t.Error("forbid_duplicate_keys = false, want true")
+	}
+}
+
+func TestLoader_GlobalRefOverrides(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `
+ref_overrides:
+  "https://example.com/common.json": "./common.json"
+schemas:
+  - path: "a.schema.json"
+    documents: ["a.json"]
+  - path: "b.schema.json"
+    documents: ["b.json"]
+    ref_overrides:
+      "https://example.com/b.json": "./b.json"
+`
+
+	if err := os.WriteFile(configFile, []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewLoader().LoadFromFile(configFile)
+	if err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+
+	if cfg.RefOverrides["https://example.com/common.json"] != "./common.json" {
+		t.Errorf("ref_overrides = %v, want common.json override", cfg.RefOverrides)
+	}
+	if len(cfg.Schemas[0].RefOverrides) != 0 {
+		t.Errorf("schemas[0].ref_overrides = %v, want none", cfg.Schemas[0].RefOverrides)
+	}
+	if len(cfg.Schemas[1].RefOverrides) != 1 {
+		t.Errorf("schemas[1].ref_overrides = %v, want 1 item", cfg.Schemas[1].RefOverrides)
+	}
+}
+
+func TestLoader_EnvExpansion(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("JSV_TEST_SCHEMA_DIR", "/opt/schemas")
+	t.Setenv("JSV_TEST_EMPTY", "")
+
+	tests := []struct {
+		name          string
+		config        string
+		wantPath      string
+		wantDocument  string
+		wantOverride  string
+		errorContains string
+	}{
+		{
+			name: "schema path from env var",
+			config: `
+schemas:
+  - path: "${JSV_TEST_SCHEMA_DIR}/app.schema.json"
+    documents: ["app.json"]
+`,
+			wantPath:     "/opt/schemas/app.schema.json",
+			wantDocument: "app.json",
+		},
+		{
+			name: "defaults for unset and empty variables",
+			config: `
+schemas:
+  - path: "${JSV_TEST_UNSET:-./schemas}/app.schema.json"
+    documents: ["${JSV_TEST_EMPTY:-configs}/*.json"]
+    ref_overrides:
+      "https://example.com/common.json": "${JSV_TEST_SCHEMA_DIR}/common.json"
+`,
+			wantPath:     "./schemas/app.schema.json",
+			wantDocument: "configs/*.json",
+			wantOverride: "/opt/schemas/common.json",
+		},
+		{
+			name: "undefined variables are listed",
+			config: `
+schemas:
+  - path: "${JSV_TEST_UNSET_B}/app.schema.json"
+    documents: ["${JSV_TEST_UNSET_A}/app.json"]
+`,
+			errorContains: "undefined environment variable(s) in configuration: JSV_TEST_UNSET_A, JSV_TEST_UNSET_B",
+		},
+		{
+			name: "unterminated reference",
+			config: `
+schemas:
+  - path: "${JSV_TEST_SCHEMA_DIR/app.schema.json"
+    documents: ["app.json"]
+`,
+			errorContains: "unterminated ${",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(tempDir, fmt.Sprintf("config%d.yaml", i))
+			if err := os.WriteFile(configFile, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := NewLoader().LoadFromFile(configFile)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadFromFile() failed: %v", err)
+			}
+
+			schema := cfg.Schemas[0]
+			if schema.Path != tt.wantPath {
+				t.Errorf("path = %q, want %q", schema.Path, tt.wantPath)
+			}
+			if schema.Documents[0] != tt.wantDocument {
+				t.Errorf("documents[0] = %q, want %q", schema.Documents[0], tt.wantDocument)
+			}
+			if got := schema.RefOverrides["https://example.com/common.json"]; got != tt.wantOverride {
+				t.Errorf("ref_overrides target = %q, want %q", got, tt.wantOverride)
+			}
+		})
+	}
+}
+
+func TestLoader_UnknownKeys(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		content       string
+		errorContains []string
+	}{
+		{
+			name: "misspelled top-level key",
+			file: "config.yaml",
+			content: `
+schema_versoin: "draft-07"
+schemas:
+  - path: "test.schema.json"
+    documents: ["test.json"]
+`,
+			errorContains: []string{`unknown configuration key(s): "schema_versoin" (did you mean "schema_version"?)`},
+		},
+		{
+			name: "misspelled schema key",
+			file: "config.yaml",
+			content: `
+schemas:
+  - path: "a.schema.json"
+    documents: ["a.json"]
+  - path: "b.schema.json"
+    documnets: ["b.json"]
+`,
+			errorContains: []string{`"schemas[1].documnets" (did you mean "documents"?)`},
+		},
+		{
+			name: "misspelled profile key and a key with no close match",
+			file: "config.toml",
+			content: `
+color = "auto"
+
+[profiles.prod]
+no_netwrok = true
+`,
+			errorContains: []string{`"color", "profiles.prod.no_netwrok" (did you mean "no_network"?)`},
+		},
+		{
+			name:    "JSON",
+			file:    "config.json",
+			content: `{"schemaVersion": "draft-07"}`,
+			errorContains: []string{
+				`config file`,
+				`"schemaVersion"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(configFile, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := NewLoader().LoadFromFile(configFile)
+			if err == nil {
+				t.Fatal("LoadFromFile() succeeded, want an unknown key error")
+			}
+			for _, want := range tt.errorContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("LoadFromFile() error = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+
+	t.Run("discovered project config", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		if err := os.WriteFile(".jsonschema-validator.yaml", []byte("forbid_duplicate_key: true\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := NewLoader().Load(nil)
+		if err == nil || !strings.Contains(err.Error(), `"forbid_duplicate_key" (did you mean "forbid_duplicate_keys"?)`) {
+			t.Fatalf("Load() error = %v, want the unknown key", err)
+		}
+	})
+}
+
+func TestLoader_Explain(t *testing.T) {
+	t.Chdir(t.TempDir())
+	configContent := `
+schema_version: "draft-07"
+error_template: "@detailed"
+schemas:
+  - path: "config.schema.json"
+    documents: ["config.json"]
+profiles:
+  ci:
+    no_network: true
+`
+	if err := os.WriteFile(".jsonschema-validator.yaml", []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("JSONSCHEMA_VALIDATOR_SCHEMA_VERSION", "draft/2020-12")
+
+	loader := NewLoader()
+	loader.SetProfile("ci")
+	cfg, err := loader.Load(nil)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	cfg.ValidateSchema = true
+	loader.SetSource("validate_schema", "flag --validate-schema")
+
+	got, err := loader.Explain(cfg)
+	if err != nil {
+		t.Fatalf("Explain() failed: %v", err)
+	}
+	want := `schema_version = draft/2020-12 (from env JSONSCHEMA_VALIDATOR_SCHEMA_VERSION)
+schemas = [{"documents":["config.json"],"path":"config.schema.json"}] (from .jsonschema-validator.yaml)
+error_template = @detailed (from .jsonschema-validator.yaml)
+ref_overrides = {} (from default)
+forbid_duplicate_keys = false (from default)
+no_network = true (from profile "ci")
+validate_schema = true (from flag --validate-schema)
+max_errors = 0 (from default)
+fetch_timeout = "" (from default)
+fail_fast = false (from default)
+jobs = 1 (from default)
+`
+	if got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLoader_ProjectConfigInParentDirectory(t *testing.T) {
+	root := t.TempDir()
+	configContent := `
+schema_version: "draft-07"
+ref_overrides:
+  "https://example.com/common.json": "./schemas/common.json"
+schemas:
+  - path: "schemas/config.schema.json"
+    documents: ["configs/*.yaml", "/etc/app.yaml", "-"]
+`
+	if err := os.WriteFile(filepath.Join(root, ".jsonschema-validator.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	child := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(child, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(child)
+
+	loader := NewLoader()
+	cfg, err := loader.Load(nil)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.SchemaVersion != "draft-07" {
+		t.Errorf("schema_version = %q, want the parent directory's draft-07", cfg.SchemaVersion)
+	}
+	up := filepath.Join("..", "..")
+	if want := filepath.Join(up, "schemas", "config.schema.json"); cfg.Schemas[0].Path != want {
+		t.Errorf("path = %q, want %q", cfg.Schemas[0].Path, want)
+	}
+	wantDocuments := []string{filepath.Join(up, "configs", "*.yaml"), "/etc/app.yaml", "-"}
+	for i, want := range wantDocuments {
+		if cfg.Schemas[0].Documents[i] != want {
+			t.Errorf("documents[%d] = %q, want %q", i, cfg.Schemas[0].Documents[i], want)
+		}
+	}
+	if got, want := cfg.RefOverrides["https://example.com/common.json"], filepath.Join(up, "schemas", "common.json"); got != want {
+		t.Errorf("ref_overrides target = %q, want %q", got, want)
+	}
+	explained, err := loader.Explain(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "(from " + filepath.Join(up, ".jsonschema-validator.yaml") + ")"; !strings.Contains(explained, want) {
+		t.Errorf("Explain() = %s, want a source of %s", explained, want)
+	}
+
+	// The search stops at the current directory when disabled
+	loader = NewLoader()
+	loader.DisableConfigSearch()
+	cfg, err = loader.Load(nil)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.SchemaVersion != "" || len(cfg.Schemas) != 0 {
+		t.Errorf("Load() with search disabled = %+v, want the defaults", cfg)
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	validator "github.com/binlab/terraform-provider-jsonschema/pkg/jsonschema"
+)
+
+// configSchema is the JSON Schema of a configuration file, kept in sync with the
+// Config and SchemaConfig fields by TestConfigSchema_CoversEveryKey
+//
+//go:embed config.schema.json
+var configSchema []byte
+
+// configSchemaURL names the configuration schema in its validation errors
+const configSchemaURL = "config.schema.json"
+
+// Schema returns the JSON Schema describing a configuration file, for editors and
+// for --print-config-schema
+func Schema() []byte {
+	return bytes.Clone(configSchema)
+}
+
+// compiledConfigSchema compiles the embedded schema once
+var compiledConfigSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	schemaData, err := validator.ParseJSON(configSchema)
+	if err != nil {
+		return nil, err
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(configSchemaURL, schemaData); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(configSchemaURL)
+})
+
+// checkConfigSchema validates a loaded configuration against the configuration
+// schema, so that a wrongly typed value such as `jobs: "four"` is reported with its
+// path instead of being coerced or failing to unmarshal. Unknown keys are reported
+// by checkUnknownKeys first, with suggestions.
+func checkConfigSchema(raw map[string]interface{}) error {
+	schema, err := compiledConfigSchema()
+	if err != nil {
+		return fmt.Errorf("compiling configuration schema: %w", err)
+	}
+	err = schema.Validate(raw)
+	if err == nil {
+		return nil
+	}
+
+	var messages []string
+	for _, detail := range validator.ExtractValidationErrors(err, raw) {
+		messages = append(messages, detail.Message)
+	}
+	return fmt.Errorf("invalid configuration: %s", strings.Join(messages, "; "))
+}
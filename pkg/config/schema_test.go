@@ -0,0 +1,145 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestConfigSchema_CoversEveryKey(t *testing.T) {
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       struct {
+			Schema struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schema"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(Schema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	sortedKeys := func(properties map[string]json.RawMessage) []string {
+		keys := make([]string, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	wantConfig := append(koanfKeys(reflect.TypeOf(Config{})), profilesKey)
+	sort.Strings(wantConfig)
+	if got := sortedKeys(schema.Properties); !slices.Equal(got, wantConfig) {
+		t.Errorf("schema properties = %v, want %v", got, wantConfig)
+	}
+	wantSchema := koanfKeys(reflect.TypeOf(SchemaConfig{}))
+	sort.Strings(wantSchema)
+	if got := sortedKeys(schema.Defs.Schema.Properties); !slices.Equal(got, wantSchema) {
+		t.Errorf("schema entry properties = %v, want %v", got, wantSchema)
+	}
+}
+
+func TestLoader_ConfigSchema(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		content       string
+		errorContains []string // nil when the file is valid
+	}{
+		{
+			name: "every setting",
+			file: "config.yaml",
+			content: `
+schema_version: "draft-07"
+error_template: "@detailed"
+ref_overrides:
+  "https://example.com/common.json": "./common.json"
+forbid_duplicate_keys: true
+no_network: true
+validate_schema: true
+max_errors: 5
+fetch_timeout: "10s"
+fail_fast: true
+jobs: 4
+schemas:
+  - path: "test.schema.json"
+    documents: ["test.json", "configs/*.yaml"]
+    force_filetype: "yaml"
+    schema_version: "draft/2020-12"
+    error_template: "{{.FullMessage}}"
+    ref_overrides:
+      "https://example.com/other.json": "./other.json"
+profiles:
+  ci:
+    fail_fast: false
+    jobs: 8
+`,
+		},
+		{
+			name: "wrong types",
+			file: "config.yaml",
+			content: `
+jobs: "four"
+no_network: "yes"
+schemas:
+  - path: "test.schema.json"
+    documents: "test.json"
+`,
+			errorContains: []string{
+				"invalid configuration",
+				"at '/jobs': got string, want integer",
+				"at '/no_network': got string, want boolean",
+				"at '/schemas/0/documents': got string, want array",
+			},
+		},
+		{
+			name: "negative number and unknown file type in TOML",
+			file: "config.toml",
+			content: `
+max_errors = -1
+
+[[schemas]]
+path = "test.schema.json"
+documents = ["test.json"]
+force_filetype = "xml"
+`,
+			errorContains: []string{"config file", "/max_errors", "/schemas/0/force_filetype"},
+		},
+		{
+			name:          "profile value",
+			file:          "config.json",
+			content:       `{"profiles": {"ci": {"jobs": 1.5}}}`,
+			errorContains: []string{"/profiles/ci/jobs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(configFile, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := NewLoader().LoadFromFile(configFile)
+			if tt.errorContains == nil {
+				if err != nil {
+					t.Fatalf("LoadFromFile() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("LoadFromFile() succeeded, want a configuration schema error")
+			}
+			for _, want := range tt.errorContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("LoadFromFile() error = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package jsonschema
+
+import (
+	errors2 "errors"
+	"slices"
+	"sort"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/message"
+)
+
+// AccessContext is the direction a document travels through an API, which decides
+// whether its readOnly or its writeOnly values are disallowed
+type AccessContext string
+
+const (
+	// AccessRead is a document read from an API (e.g. a response); writeOnly values are disallowed
+	AccessRead AccessContext = "read"
+	// AccessWrite is a document sent to an API (e.g. a request body); readOnly values are disallowed
+	AccessWrite AccessContext = "write"
+)
+
+// CheckAccess walks document alongside the compiled schema and reports every value whose
+// schema is annotated readOnly (in a write context) or writeOnly (in a read context).
+// Properties, patternProperties, additionalProperties, array items, $ref and allOf are
+// followed, as are the anyOf, oneOf and if/then/else branches the value satisfies.
+//
+// It returns nil when no value is disallowed. Otherwise the validation error has one
+// cause per value, located at its path, so it formats like any other validation error.
+func CheckAccess(schema *jsonschema.Schema, document interface{}, context AccessContext) *jsonschema.ValidationError {
+	var causes []*jsonschema.ValidationError
+	reported := make(map[string]bool) // document paths already reported through another schema
+	walkApplicable(schema, document, func(s *jsonschema.Schema, path []string) {
+		pointer := joinJSONPointer(path)
+		if keyword := disallowedKeyword(s, context); keyword != "" && !reported[pointer] {
+			reported[pointer] = true
+			causes = append(causes, &jsonschema.ValidationError{
+				SchemaURL:        s.Location,
+				InstanceLocation: slices.Clone(path),
+				ErrorKind:        &accessViolation{keyword: keyword, context: context},
+			})
+		}
+	})
+	if len(causes) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(causes, func(i, j int) bool {
+		return joinJSONPointer(causes[i].InstanceLocation) < joinJSONPointer(causes[j].InstanceLocation)
+	})
+	return &jsonschema.ValidationError{
+		SchemaURL:        schema.Location,
+		InstanceLocation: []string{},
+		ErrorKind:        &kind.Schema{Location: schema.Location},
+		Causes:           causes,
+	}
+}
+
+// JoinValidationErrors adds the causes of extra (e.g. from CheckAccess) to err, the error
+// returned by validating the same document against the same schema. Either may be nil.
+func JoinValidationErrors(err error, extra *jsonschema.ValidationError) error {
+	if extra == nil {
+		return err
+	}
+	if err == nil {
+		return extra
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors2.As(err, &validationErr) {
+		return err
+	}
+	causes := validationErr.Causes
+	if len(causes) == 0 {
+		causes = []*jsonschema.ValidationError{validationErr}
+	}
+	joined := *validationErr
+	joined.Causes = append(slices.Clone(causes), extra.Causes...)
+	return &joined
+}
+
+// disallowedKeyword returns "readOnly" or "writeOnly" if s disallows its value in
+// context, or "" if the value is allowed
+func disallowedKeyword(s *jsonschema.Schema, context AccessContext) string {
+	switch {
+	case context == AccessWrite && s.ReadOnly:
+		return "readOnly"
+	case context == AccessRead && s.WriteOnly:
+		return "writeOnly"
+	default:
+		return ""
+	}
+}
+
+// accessViolation reports a readOnly value in a write context or a writeOnly value in a read context
+type accessViolation struct {
+	keyword string
+	context AccessContext
+}
+
+func (k *accessViolation) KeywordPath() []string {
+	return []string{k.keyword}
+}
+
+func (k *accessViolation) LocalizedString(p *message.Printer) string {
+	return p.Sprintf("value is %s and not allowed in a %s context", k.keyword, string(k.context))
+}
@@ -0,0 +1,167 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestCheckAccess(t *testing.T) {
+	const apiSchema = `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "string", "readOnly": true},
+			"password": {"type": "string", "writeOnly": true},
+			"name": {"type": "string"},
+			"owner": {"$ref": "#/$defs/user"},
+			"members": {"type": "array", "items": {"$ref": "#/$defs/user"}}
+		},
+		"$defs": {
+			"user": {
+				"type": "object",
+				"properties": {
+					"created_at": {"type": "string", "readOnly": true},
+					"token": {"type": "string", "writeOnly": true}
+				}
+			}
+		}
+	}`
+
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		context  AccessContext
+		want     []string
+	}{
+		{
+			name:     "write context rejects readOnly",
+			schema:   apiSchema,
+			document: `{"id": "1", "password": "secret", "name": "a"}`,
+			context:  AccessWrite,
+			want:     []string{"at '/id': value is readOnly and not allowed in a write context"},
+		},
+		{
+			name:     "read context rejects writeOnly",
+			schema:   apiSchema,
+			document: `{"id": "1", "password": "secret", "name": "a"}`,
+			context:  AccessRead,
+			want:     []string{"at '/password': value is writeOnly and not allowed in a read context"},
+		},
+		{
+			name:     "nested objects and array items through refs",
+			schema:   apiSchema,
+			document: `{"owner": {"created_at": "now"}, "members": [{"token": "t"}, {"created_at": "now", "token": "t"}]}`,
+			context:  AccessWrite,
+			want: []string{
+				"at '/members/1/created_at': value is readOnly and not allowed in a write context",
+				"at '/owner/created_at': value is readOnly and not allowed in a write context",
+			},
+		},
+		{
+			name:     "nested writeOnly in a read context",
+			schema:   apiSchema,
+			document: `{"owner": {"created_at": "now", "token": "t"}, "members": [{"token": "t"}]}`,
+			context:  AccessRead,
+			want: []string{
+				"at '/members/0/token': value is writeOnly and not allowed in a read context",
+				"at '/owner/token': value is writeOnly and not allowed in a read context",
+			},
+		},
+		{
+			name:     "allowed document",
+			schema:   apiSchema,
+			document: `{"name": "a", "password": "secret"}`,
+			context:  AccessWrite,
+		},
+		{
+			name:     "only the matching oneOf branch applies",
+			schema:   `{"oneOf": [{"properties": {"kind": {"const": "a"}, "id": {"readOnly": true}}, "required": ["kind"]}, {"properties": {"kind": {"const": "b"}}, "required": ["kind"]}]}`,
+			document: `{"kind": "b", "id": "1"}`,
+			context:  AccessWrite,
+		},
+		{
+			name:     "patternProperties, additionalProperties and tuple items",
+			schema:   `{"properties": {"tags": {"prefixItems": [{"readOnly": true}]}}, "patternProperties": {"^x-": {"readOnly": true}}, "additionalProperties": {"properties": {"etag": {"readOnly": true}}}}`,
+			document: `{"tags": ["a", "b"], "x-trace": "1", "extra": {"etag": "e"}}`,
+			context:  AccessWrite,
+			want: []string{
+				"at '/extra/etag': value is readOnly and not allowed in a write context",
+				"at '/tags/0': value is readOnly and not allowed in a write context",
+				"at '/x-trace': value is readOnly and not allowed in a write context",
+			},
+		},
+		{
+			name:     "recursive ref terminates and reports once",
+			schema:   `{"$ref": "#/$defs/node", "$defs": {"node": {"$ref": "#/$defs/node", "properties": {"child": {"$ref": "#/$defs/node"}, "id": {"readOnly": true}}}}}`,
+			document: `{"child": {"child": {"id": 1}}}`,
+			context:  AccessWrite,
+			want:     []string{"at '/child/child/id': value is readOnly and not allowed in a write context"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaData, err := ParseJSON([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			document, err := ParseJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+			compiler := jsonschema.NewCompiler()
+			if err := compiler.AddResource("file:///api.schema.json", schemaData); err != nil {
+				t.Fatal(err)
+			}
+			schema, err := compiler.Compile("file:///api.schema.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			if err := CheckAccess(schema, document, tt.context); err != nil {
+				for _, detail := range ExtractValidationErrors(err, document) {
+					got = append(got, detail.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckAccess() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinValidationErrors(t *testing.T) {
+	schemaData, err := ParseJSON([]byte(`{"properties": {"id": {"readOnly": true}, "port": {"type": "integer"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("file:///api.schema.json", schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("file:///api.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	document := map[string]interface{}{"id": "1", "port": "80"}
+	joined := JoinValidationErrors(schema.Validate(document), CheckAccess(schema, document, AccessWrite))
+	details := ExtractValidationErrors(joined, document)
+	if len(details) != 2 || details[0].DocumentPath != "/id" || details[1].DocumentPath != "/port" {
+		t.Fatalf("joined errors = %+v, want /id and /port", details)
+	}
+	if !strings.Contains(joined.Error(), "value is readOnly") {
+		t.Errorf("Error() = %q, want the readOnly violation", joined.Error())
+	}
+
+	if err := JoinValidationErrors(nil, nil); err != nil {
+		t.Errorf("JoinValidationErrors(nil, nil) = %v, want nil", err)
+	}
+	if err := JoinValidationErrors(nil, CheckAccess(schema, document, AccessWrite)); err == nil {
+		t.Error("JoinValidationErrors(nil, violation) = nil, want the violation")
+	}
+}
@@ -0,0 +1,380 @@
+package jsonschema
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+// ParseCBOR parses a CBOR (RFC 8949) document into the same values JSON decodes to,
+// with the conversions and errors of ParseMsgpack. Date-time tags (0 and 1) become
+// RFC 3339 strings and other tags are dropped in favour of their content; bignums,
+// undefined and unassigned simple values are rejected.
+func ParseCBOR(data []byte) (interface{}, error) {
+	decoder := &cborDecoder{data: data}
+	result, err := decoder.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CBOR: %w", err)
+	}
+	if decoder.offset < len(data) {
+		return nil, fmt.Errorf("parsing CBOR: %d byte(s) of unexpected data after the document", len(data)-decoder.offset)
+	}
+
+	normalized, err := normalizeBinary(result, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CBOR: %w", err)
+	}
+	return normalized, nil
+}
+
+// CBOR major types
+const (
+	cborUnsigned = iota
+	cborNegative
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+// cborMaxDepth bounds the nesting of arrays, maps and tags so a crafted document
+// cannot exhaust the stack
+const cborMaxDepth = 1000
+
+// errCBORBreak is returned for the "break" stop code that ends an indefinite-length item
+var errCBORBreak = errors.New("unexpected break")
+
+// cborUndefined is the decoded "undefined" simple value, kept distinct from null so
+// normalizeBinary can reject it
+type cborUndefined struct{}
+
+type cborDecoder struct {
+	data   []byte
+	offset int
+}
+
+// decode reads one data item
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > cborMaxDepth {
+		return nil, fmt.Errorf("nesting deeper than %d levels at offset %d", cborMaxDepth, d.offset)
+	}
+	start := d.offset
+	initial, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	major, info := initial>>5, initial&0x1f
+
+	if major == cborSimple {
+		return d.decodeSimple(info, start)
+	}
+	if info == 31 {
+		return d.decodeIndefinite(major, depth, start)
+	}
+	arg, err := d.readArgument(info, start)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		return arg, nil
+	case cborNegative:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("negative integer at offset %d is out of range", start)
+		}
+		return -1 - int64(arg), nil
+	case cborBytes:
+		return d.readBytes(arg)
+	case cborText:
+		return d.readText(arg, start)
+	case cborArray:
+		if arg > uint64(len(d.data)-d.offset) {
+			return nil, fmt.Errorf("array of %d item(s) at offset %d exceeds the data", arg, start)
+		}
+		out := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, item)
+		}
+		return out, nil
+	case cborMap:
+		if arg > uint64(len(d.data)-d.offset)/2 {
+			return nil, fmt.Errorf("map of %d pair(s) at offset %d exceeds the data", arg, start)
+		}
+		out := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			if err := d.decodePair(out, depth); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	default: // cborTag
+		return d.decodeTag(arg, depth, start)
+	}
+}
+
+// decodeIndefinite reads an indefinite-length string, array or map, up to its break
+func (d *cborDecoder) decodeIndefinite(major byte, depth int, start int) (interface{}, error) {
+	switch major {
+	case cborBytes, cborText:
+		var chunks []byte
+		for !d.atBreak() {
+			chunk, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch c := chunk.(type) {
+			case []byte:
+				if major != cborBytes {
+					return nil, fmt.Errorf("byte string chunk in text string at offset %d", start)
+				}
+				chunks = append(chunks, c...)
+			case string:
+				if major != cborText {
+					return nil, fmt.Errorf("text string chunk in byte string at offset %d", start)
+				}
+				chunks = append(chunks, c...)
+			default:
+				return nil, fmt.Errorf("invalid chunk in indefinite-length string at offset %d", start)
+			}
+		}
+		if major == cborText {
+			return string(chunks), nil
+		}
+		return chunks, nil
+	case cborArray:
+		out := []interface{}{}
+		for !d.atBreak() {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, item)
+		}
+		return out, nil
+	case cborMap:
+		out := map[interface{}]interface{}{}
+		for !d.atBreak() {
+			if err := d.decodePair(out, depth); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("invalid indefinite length for major type %d at offset %d", major, start)
+	}
+}
+
+// atBreak consumes the break stop code if it is next; a missing break surfaces as
+// the end-of-data error of the following decode
+func (d *cborDecoder) atBreak() bool {
+	if d.offset < len(d.data) && d.data[d.offset] == 0xff {
+		d.offset++
+		return true
+	}
+	return false
+}
+
+// decodePair reads one key/value pair into m
+func (d *cborDecoder) decodePair(m map[interface{}]interface{}, depth int) error {
+	start := d.offset
+	key, err := d.decode(depth + 1)
+	if err != nil {
+		return err
+	}
+	if !isScalarKey(key) {
+		return fmt.Errorf("map key of type %T at offset %d cannot be represented in JSON", key, start)
+	}
+	value, err := d.decode(depth + 1)
+	if err != nil {
+		return err
+	}
+	if _, dup := m[key]; dup {
+		return fmt.Errorf("duplicate map key %v at offset %d", key, start)
+	}
+	m[key] = value
+	return nil
+}
+
+// decodeTag reads the content of a tag, converting standard date-times
+func (d *cborDecoder) decodeTag(tag uint64, depth int, start int) (interface{}, error) {
+	content, err := d.decode(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case 0: // RFC 3339 date-time string
+		s, ok := content.(string)
+		if !ok {
+			return nil, fmt.Errorf("tag 0 at offset %d must hold a text string", start)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			return nil, fmt.Errorf("tag 0 at offset %d: invalid date-time %q", start, s)
+		}
+		return s, nil
+	case 1: // Seconds since the epoch
+		switch v := content.(type) {
+		case uint64:
+			if v > math.MaxInt64 {
+				return nil, fmt.Errorf("tag 1 at offset %d is out of range", start)
+			}
+			return time.Unix(int64(v), 0), nil
+		case int64:
+			return time.Unix(v, 0), nil
+		case float64:
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil, fmt.Errorf("tag 1 at offset %d is not a finite number", start)
+			}
+			seconds, fraction := math.Modf(v)
+			return time.Unix(int64(seconds), int64(fraction*1e9)), nil
+		default:
+			return nil, fmt.Errorf("tag 1 at offset %d must hold a number", start)
+		}
+	case 2, 3:
+		return nil, fmt.Errorf("bignum (tag %d) at offset %d is not supported", tag, start)
+	default:
+		return content, nil
+	}
+}
+
+// decodeSimple reads a major type 7 item: false, true, null, undefined or a float
+func (d *cborDecoder) decodeSimple(info byte, start int) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22:
+		return nil, nil
+	case 23:
+		return cborUndefined{}, nil
+	case 25:
+		b, err := d.read(2)
+		if err != nil {
+			return nil, err
+		}
+		return halfToFloat64(binary.BigEndian.Uint16(b)), nil
+	case 26:
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 27:
+		b, err := d.read(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	case 31:
+		return nil, fmt.Errorf("%w at offset %d", errCBORBreak, start)
+	default:
+		return nil, fmt.Errorf("unsupported simple value %d at offset %d", info, start)
+	}
+}
+
+// halfToFloat64 converts an IEEE 754 half-precision float
+func halfToFloat64(h uint16) float64 {
+	exponent := int(h>>10) & 0x1f
+	mantissa := float64(h & 0x3ff)
+	var value float64
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, -24)
+	case 31:
+		if mantissa == 0 {
+			value = math.Inf(1)
+		} else {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	if h&0x8000 != 0 {
+		return -value
+	}
+	return value
+}
+
+// readArgument reads the argument that follows the initial byte
+func (d *cborDecoder) readArgument(info byte, start int) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		b, err := d.read(1)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(b[0]), nil
+	case info == 25:
+		b, err := d.read(2)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case info == 26:
+		b, err := d.read(4)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	case info == 27:
+		b, err := d.read(8)
+		if err != nil {
+			return 0, err
+		}
+		return binary.BigEndian.Uint64(b), nil
+	default:
+		return 0, fmt.Errorf("invalid additional information %d at offset %d", info, start)
+	}
+}
+
+func (d *cborDecoder) readBytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.offset) {
+		return nil, fmt.Errorf("unexpected end of data at offset %d", len(d.data))
+	}
+	b, err := d.read(int(n))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), b...), nil
+}
+
+func (d *cborDecoder) readText(n uint64, start int) (string, error) {
+	b, err := d.readBytes(n)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", fmt.Errorf("text string at offset %d is not valid UTF-8", start)
+	}
+	return string(b), nil
+}
+
+func (d *cborDecoder) readByte() (byte, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// read returns the next n bytes of the data
+func (d *cborDecoder) read(n int) ([]byte, error) {
+	if n > len(d.data)-d.offset {
+		return nil, fmt.Errorf("unexpected end of data at offset %d", len(d.data))
+	}
+	b := d.data[d.offset : d.offset+n]
+	d.offset += n
+	return b, nil
+}
@@ -0,0 +1,111 @@
+package jsonschema
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	data, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q) error = %v", s, err)
+	}
+	return data
+}
+
+// The encodings are from RFC 8949 Appendix A
+func TestParseCBOR(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+		want interface{}
+	}{
+		{"zero", "00", int64(0)},
+		{"small unsigned", "17", int64(23)},
+		{"one-byte unsigned", "1818", int64(24)},
+		{"eight-byte unsigned", "1b 000000e8d4a51000", int64(1000000000000)},
+		{"max uint64", "1b ffffffffffffffff", uint64(18446744073709551615)},
+		{"negative", "3903e7", int64(-1000)},
+		{"half float", "f93e00", float64(1.5)},
+		{"half float subnormal", "f90001", float64(5.960464477539063e-08)},
+		{"single float", "fa47c35000", float64(100000)},
+		{"double float", "fb 3ff199999999999a", float64(1.1)},
+		{"false", "f4", false},
+		{"true", "f5", true},
+		{"null", "f6", nil},
+		{"text", "6449455446", "IETF"},
+		{"unicode text", "62c3bc", "ü"},
+		{"date-time tag", "c0 74 323031332d30332d32315432303a30343a30305a", "2013-03-21T20:04:00Z"},
+		{"epoch tag", "c1 1a 514b67b0", "2013-03-21T20:04:00Z"},
+		{"epoch tag with fraction", "c1 fb 41d452d9ec200000", "2013-03-21T20:04:00.5Z"},
+		{"URI tag", "d820 76 687474703a2f2f7777772e6578616d706c652e636f6d", "http://www.example.com"},
+		{"array", "83 01 02 03", []interface{}{int64(1), int64(2), int64(3)}},
+		{"empty map", "a0", map[string]interface{}{}},
+		{"map", "a2 6161 01 6162 82 02 03", map[string]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}}},
+		{"integer keys", "a2 01 02 03 04", map[string]interface{}{"1": int64(2), "3": int64(4)}},
+		{"indefinite text", "7f 657374726561 646d696e67 ff", "streaming"},
+		{"indefinite array", "9f 01 82 02 03 9f 04 05 ff ff", []interface{}{int64(1), []interface{}{int64(2), int64(3)}, []interface{}{int64(4), int64(5)}}},
+		{"indefinite map", "bf 6346756e f5 63416d74 21 ff", map[string]interface{}{"Fun": true, "Amt": int64(-2)}},
+		{"self-described", "d9d9f7 a1 6161 01", map[string]interface{}{"a": int64(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCBOR(mustDecodeHex(t, tt.hex))
+			if err != nil {
+				t.Fatalf("ParseCBOR() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCBOR() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCBOR_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		wantErr string
+	}{
+		{"byte string", "a1 6466696c65 42 dead", `binary value (2 byte(s)) at "/file" cannot be represented in JSON`},
+		{"indefinite byte string", "81 5f 41 01 41 02 ff", `binary value (2 byte(s)) at "/0" cannot be represented in JSON`},
+		{"byte string key", "a1 4101 01", "map key of type []uint8 at offset 1 cannot be represented in JSON"},
+		{"array key", "a1 80 01", "map key of type []interface {} at offset 1 cannot be represented in JSON"},
+		{"duplicate key", "a2 6161 01 6161 02", "duplicate map key a at offset 4"},
+		{"undefined", "81 f7", `undefined value at "/0" cannot be represented in JSON`},
+		{"infinity", "a1 6178 f97c00", `number +Inf at "/x" cannot be represented in JSON`},
+		{"bignum", "c2 49 010000000000000000", "bignum (tag 2) at offset 0 is not supported"},
+		{"invalid date-time", "c0 63 616263", `tag 0 at offset 0: invalid date-time "abc"`},
+		{"simple value", "f0", "unsupported simple value 16 at offset 0"},
+		{"stray break", "81 ff", "unexpected break at offset 1"},
+		{"invalid UTF-8", "62 c328", "text string at offset 0 is not valid UTF-8"},
+		{"truncated", "a1 6161", "unexpected end of data at offset 3"},
+		{"unterminated indefinite array", "9f 01", "unexpected end of data at offset 2"},
+		{"oversized length", "9b 00000000ffffffff", "array of 4294967295 item(s) at offset 0 exceeds the data"},
+		{"empty", "", "unexpected end of data at offset 0"},
+		{"trailing data", "01 02", "1 byte(s) of unexpected data after the document"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCBOR(mustDecodeHex(t, tt.hex))
+			if err == nil {
+				t.Fatal("ParseCBOR() error = nil, want error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseCBOR() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCBOR_DeepNesting(t *testing.T) {
+	data := append([]byte(strings.Repeat("\x81", cborMaxDepth+1)), 0x00)
+	if _, err := ParseCBOR(data); err == nil || !strings.Contains(err.Error(), "nesting deeper than") {
+		t.Errorf("ParseCBOR() error = %v, want nesting error", err)
+	}
+}
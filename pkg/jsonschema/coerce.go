@@ -0,0 +1,123 @@
+package jsonschema
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// Coercion records a string document value converted to the type its schema requires
+type Coercion struct {
+	DocumentPath string      // JSON Pointer to the value ("" for the root)
+	From         string      // The string as written
+	To           interface{} // The converted value: a bool, int64 or float64
+}
+
+// jsonNumberPattern is the JSON number grammar; looser spellings such as "0x10",
+// "1_000" or "Inf" are left as strings
+var jsonNumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// CoerceTypes returns a copy of document in which string values are converted to the
+// boolean, integer or number their schema's "type" requires, e.g. "true" for
+// {"type": "boolean"} or "8080" for {"type": "integer"}, with one Coercion per value
+// sorted by path. Subschemas are followed as in CheckAccess.
+//
+// Only unambiguous values are converted: every "type" that applies to the value must
+// exclude "string" and together allow exactly one of boolean, integer or number, and
+// the string must be "true"/"false", a decimal integer or a JSON number respectively.
+// Values under anyOf/oneOf branches are left alone, as a string never satisfies the
+// branch that would convert it.
+func CoerceTypes(schema *jsonschema.Schema, document interface{}) (interface{}, []Coercion) {
+	// The types every applicable schema allows, by document path
+	allowed := make(map[string][]string)
+	paths := make(map[string][]string)
+	walkApplicable(schema, document, func(s *jsonschema.Schema, path []string) {
+		if s.Types == nil || s.Types.IsEmpty() {
+			return
+		}
+		types := s.Types.ToStrings()
+		// Every integer is a number
+		if slices.Contains(types, "number") && !slices.Contains(types, "integer") {
+			types = append(types, "integer")
+		}
+		pointer := joinJSONPointer(path)
+		if previous, ok := allowed[pointer]; ok {
+			types = slices.DeleteFunc(types, func(t string) bool { return !slices.Contains(previous, t) })
+		}
+		allowed[pointer] = types
+		paths[pointer] = slices.Clone(path)
+	})
+
+	var coercions []Coercion
+	for pointer, types := range allowed {
+		tokens := paths[pointer]
+		value, _, _ := lookupPath(document, tokens)
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if to, ok := coerceString(s, types); ok {
+			coercions = append(coercions, Coercion{DocumentPath: pointer, From: s, To: to})
+		}
+	}
+	if len(coercions) == 0 {
+		return document, nil
+	}
+	sort.Slice(coercions, func(i, j int) bool { return coercions[i].DocumentPath < coercions[j].DocumentPath })
+
+	coerced := sortKeys(document)
+	for _, coercion := range coercions {
+		coerced = setPath(coerced, paths[coercion.DocumentPath], coercion.To)
+	}
+	return coerced, coercions
+}
+
+// coerceString converts s to the single non-string type types allows, if there is one
+func coerceString(s string, types []string) (interface{}, bool) {
+	slices.Sort(types)
+	switch {
+	case slices.Equal(types, []string{"boolean"}):
+		switch s {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	case slices.Equal(types, []string{"integer"}):
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil && jsonNumberPattern.MatchString(s) {
+			return n, true
+		}
+	case slices.Equal(types, []string{"integer", "number"}):
+		if jsonNumberPattern.MatchString(s) {
+			if n, err := strconv.ParseFloat(s, 64); err == nil {
+				return n, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// setPath replaces the value at tokens in data, a copy made by sortKeys, and returns
+// data (or value itself for the root)
+func setPath(data interface{}, tokens []string, value interface{}) interface{} {
+	if len(tokens) == 0 {
+		return value
+	}
+	parent, _, ok := lookupPath(data, tokens[:len(tokens)-1])
+	if !ok {
+		return data
+	}
+	last := tokens[len(tokens)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[last] = value
+	case []interface{}:
+		if i, ok := arrayIndex(last, len(p)); ok {
+			p[i] = value
+		}
+	}
+	return data
+}
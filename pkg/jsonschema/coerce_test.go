@@ -0,0 +1,126 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestCoerceTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    string
+		document  string
+		want      string
+		coercions []Coercion
+	}{
+		{
+			name:      "string to integer",
+			schema:    `{"properties": {"port": {"type": "integer"}}}`,
+			document:  `{"port": "8080"}`,
+			want:      `{"port":8080}`,
+			coercions: []Coercion{{DocumentPath: "/port", From: "8080", To: int64(8080)}},
+		},
+		{
+			name:      "string to boolean",
+			schema:    `{"properties": {"enabled": {"type": "boolean"}, "debug": {"type": "boolean"}}}`,
+			document:  `{"enabled": "true", "debug": "false"}`,
+			want:      `{"debug":false,"enabled":true}`,
+			coercions: []Coercion{{DocumentPath: "/debug", From: "false", To: false}, {DocumentPath: "/enabled", From: "true", To: true}},
+		},
+		{
+			name:      "string to number in array items through $ref",
+			schema:    `{"properties": {"ratios": {"type": "array", "items": {"$ref": "#/$defs/ratio"}}}, "$defs": {"ratio": {"type": "number"}}}`,
+			document:  `{"ratios": ["0.5", 1, "2e3"]}`,
+			want:      `{"ratios":[0.5,1,2000]}`,
+			coercions: []Coercion{{DocumentPath: "/ratios/0", From: "0.5", To: 0.5}, {DocumentPath: "/ratios/2", From: "2e3", To: 2000.0}},
+		},
+		{
+			name:      "number and integer together mean integer",
+			schema:    `{"properties": {"n": {"type": "number"}}, "allOf": [{"properties": {"n": {"type": "integer"}}}]}`,
+			document:  `{"n": "3"}`,
+			want:      `{"n":3}`,
+			coercions: []Coercion{{DocumentPath: "/n", From: "3", To: int64(3)}},
+		},
+		{
+			name:     "a type that allows strings is left alone",
+			schema:   `{"properties": {"port": {"type": ["integer", "string"]}}}`,
+			document: `{"port": "8080"}`,
+			want:     `{"port":"8080"}`,
+		},
+		{
+			name:     "more than one target type is ambiguous",
+			schema:   `{"properties": {"v": {"type": ["boolean", "integer"]}}}`,
+			document: `{"v": "1"}`,
+			want:     `{"v":"1"}`,
+		},
+		{
+			name:     "strings that don't parse are left alone",
+			schema:   `{"properties": {"a": {"type": "integer"}, "b": {"type": "boolean"}, "c": {"type": "number"}, "d": {"type": "integer"}}}`,
+			document: `{"a": "1.5", "b": "yes", "c": "0x10", "d": "007"}`,
+			want:     `{"a":"1.5","b":"yes","c":"0x10","d":"007"}`,
+		},
+		{
+			name:     "anyOf branches are not used",
+			schema:   `{"properties": {"port": {"anyOf": [{"type": "integer"}, {"type": "null"}]}}}`,
+			document: `{"port": "8080"}`,
+			want:     `{"port":"8080"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaData, err := ParseJSON([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			document, err := ParseJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+			compiler := jsonschema.NewCompiler()
+			if err := compiler.AddResource("file:///config.schema.json", schemaData); err != nil {
+				t.Fatal(err)
+			}
+			schema, err := compiler.Compile("file:///config.schema.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			coerced, coercions := CoerceTypes(schema, document)
+			got, err := MarshalDeterministicString(coerced)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("CoerceTypes() document = %s, want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(coercions, tt.coercions) {
+				t.Errorf("CoerceTypes() coercions = %+v, want %+v", coercions, tt.coercions)
+			}
+			if original, _ := MarshalDeterministicString(document); original != tt.want && len(tt.coercions) == 0 {
+				t.Errorf("document changed without coercions: %s", original)
+			}
+		})
+	}
+
+	t.Run("input is not modified", func(t *testing.T) {
+		compiler := jsonschema.NewCompiler()
+		if err := compiler.AddResource("file:///config.schema.json", map[string]interface{}{"type": "integer"}); err != nil {
+			t.Fatal(err)
+		}
+		schema, err := compiler.Compile("file:///config.schema.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		document := []interface{}{"1"}
+		if coerced, _ := CoerceTypes(schema, "42"); coerced != int64(42) {
+			t.Errorf("CoerceTypes(root) = %#v, want 42", coerced)
+		}
+		CoerceTypes(schema, document)
+		if document[0] != "1" {
+			t.Errorf("input modified: %v", document)
+		}
+	})
+}
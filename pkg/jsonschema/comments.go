@@ -0,0 +1,46 @@
+package jsonschema
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// Annotation is a "$comment" found in a subschema that applies to a document value
+type Annotation struct {
+	DocumentPath string // JSON Pointer to the document value ("" for the root)
+	SchemaPath   string // schema URL with JSON Pointer fragment of the "$comment" keyword
+	Comment      string
+}
+
+// CollectComments walks document alongside the compiled schema, following the same
+// subschemas as CheckAccess, and returns the "$comment" of every subschema that applies
+// to a value the document sets. Comments under properties the document leaves out, and
+// in anyOf/oneOf branches it does not satisfy, are not collected. The result is sorted
+// by document path, then schema path.
+func CollectComments(schema *jsonschema.Schema, document interface{}) []Annotation {
+	var annotations []Annotation
+	walkApplicable(schema, document, func(s *jsonschema.Schema, path []string) {
+		if s.Comment == "" {
+			return
+		}
+		location := s.Location
+		if !strings.Contains(location, "#") {
+			location += "#"
+		}
+		annotations = append(annotations, Annotation{
+			DocumentPath: joinJSONPointer(path),
+			SchemaPath:   location + "/$comment",
+			Comment:      s.Comment,
+		})
+	})
+
+	sort.SliceStable(annotations, func(i, j int) bool {
+		if annotations[i].DocumentPath != annotations[j].DocumentPath {
+			return annotations[i].DocumentPath < annotations[j].DocumentPath
+		}
+		return annotations[i].SchemaPath < annotations[j].SchemaPath
+	})
+	return annotations
+}
@@ -0,0 +1,54 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestCollectComments(t *testing.T) {
+	schemaData, err := ParseJSON([]byte(`{
+		"$comment": "service definition",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "$comment": "DNS label"},
+			"port": {"$ref": "#/$defs/port"},
+			"tls": {"type": "object", "$comment": "only when exposed"},
+			"backend": {"oneOf": [
+				{"type": "string", "$comment": "a URL"},
+				{"type": "object", "$comment": "a service reference"}
+			]}
+		},
+		"$defs": {
+			"port": {"type": "integer", "$comment": "IANA registered"}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("file:///service.schema.json", schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("file:///service.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	document := map[string]interface{}{"name": "api", "port": float64(443), "backend": "https://example.com"}
+	got := CollectComments(schema, document)
+	want := []Annotation{
+		{DocumentPath: "", SchemaPath: "file:///service.schema.json#/$comment", Comment: "service definition"},
+		{DocumentPath: "/backend", SchemaPath: "file:///service.schema.json#/properties/backend/oneOf/0/$comment", Comment: "a URL"},
+		{DocumentPath: "/name", SchemaPath: "file:///service.schema.json#/properties/name/$comment", Comment: "DNS label"},
+		{DocumentPath: "/port", SchemaPath: "file:///service.schema.json#/$defs/port/$comment", Comment: "IANA registered"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectComments() = %+v, want %+v", got, want)
+	}
+
+	if got := CollectComments(schema, "not an object"); len(got) != 1 || got[0].Comment != "service definition" {
+		t.Errorf("CollectComments(string) = %+v, want only the root comment", got)
+	}
+}
@@ -0,0 +1,315 @@
+package jsonschema
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/message"
+)
+
+// DataKeyword is the ajv-style keyword that takes a keyword's value from the
+// document being validated, e.g. {"maximum": {"$data": "1/limit"}}
+const DataKeyword = "$data"
+
+// DataKeywords lists the keywords whose value may be a $data reference
+var DataKeywords = []string{
+	"const", "enum", "exclusiveMaximum", "exclusiveMinimum", "maxItems", "maxLength",
+	"maxProperties", "maximum", "minItems", "minLength", "minProperties", "minimum",
+	"multipleOf", "pattern", "required", "uniqueItems",
+}
+
+// dataRulesKeyword holds the $data references hoisted by RewriteDataRefs
+const dataRulesKeyword = "x-data-rules"
+
+// dataVocabularyURL identifies the vocabulary returned by DataVocabulary
+const dataVocabularyURL = "https://github.com/binlab/terraform-provider-jsonschema/vocab/data"
+
+// Subschema locations that leave the instance unchanged or descend into a
+// property by name; any other location starts a new, unrooted chain
+var (
+	schemaMapKeywords    = []string{"patternProperties", "$defs", "definitions", "dependentSchemas", "dependencies"}
+	schemaSingleKeywords = []string{"items", "additionalItems", "additionalProperties", "contains", "propertyNames", "not", "if", "then", "else", "unevaluatedItems", "unevaluatedProperties"}
+	schemaArrayKeywords  = []string{"anyOf", "oneOf", "prefixItems", "items"}
+)
+
+// RewriteDataRefs prepares schema for DataVocabulary by replacing every
+// {"<keyword>": {"$data": pointer}} with a rule on the schema of the instance the
+// pointer is resolved from. schema is modified in place.
+//
+// Pointers are relative JSON Pointers ("1/limit": the sibling "limit") or, from the
+// root schema, absolute JSON Pointers ("/limits/port"). A relative pointer can only
+// go up through "properties" (and "allOf", which keeps the instance); the nearest
+// items, anyOf, $defs, etc. above a $data is as far as it reaches.
+func RewriteDataRefs(schema interface{}) error {
+	root, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return rewriteDataRefs(root, nil, nil, []map[string]interface{}{root}, true)
+}
+
+// rewriteDataRefs walks node at schema location ptr; instPath is the instance path of
+// node below hosts[0], and hosts[i] is the schema applied at instPath[:i]
+func rewriteDataRefs(node map[string]interface{}, ptr, instPath []string, hosts []map[string]interface{}, rooted bool) error {
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPtr := append(append([]string(nil), ptr...), key)
+
+		if ref, ok := dataRef(node[key]); ok && slices.Contains(DataKeywords, key) {
+			if err := hoistDataRef(key, ref, childPtr, instPath, hosts, rooted); err != nil {
+				return err
+			}
+			delete(node, key)
+			continue
+		}
+
+		switch {
+		case key == "properties":
+			for name, sub := range schemaMembers(node[key]) {
+				subPtr := append(append([]string(nil), childPtr...), name)
+				subInst := append(append([]string(nil), instPath...), name)
+				if err := rewriteDataRefs(sub, subPtr, subInst, append(append([]map[string]interface{}(nil), hosts...), sub), rooted); err != nil {
+					return err
+				}
+			}
+		case key == "allOf":
+			if branches, ok := node[key].([]interface{}); ok {
+				for i, branch := range branches {
+					if sub, ok := branch.(map[string]interface{}); ok {
+						if err := rewriteDataRefs(sub, append(append([]string(nil), childPtr...), strconv.Itoa(i)), instPath, hosts, rooted); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		case slices.Contains(schemaMapKeywords, key):
+			for name, sub := range schemaMembers(node[key]) {
+				if err := rewriteDataRefs(sub, append(append([]string(nil), childPtr...), name), nil, []map[string]interface{}{sub}, false); err != nil {
+					return err
+				}
+			}
+		default:
+			if sub, ok := node[key].(map[string]interface{}); ok && slices.Contains(schemaSingleKeywords, key) {
+				if err := rewriteDataRefs(sub, childPtr, nil, []map[string]interface{}{sub}, false); err != nil {
+					return err
+				}
+			}
+			if branches, ok := node[key].([]interface{}); ok && slices.Contains(schemaArrayKeywords, key) {
+				for i, branch := range branches {
+					if sub, ok := branch.(map[string]interface{}); ok {
+						if err := rewriteDataRefs(sub, append(append([]string(nil), childPtr...), strconv.Itoa(i)), nil, []map[string]interface{}{sub}, false); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hoistDataRef records one $data reference as a rule on the schema it resolves from
+func hoistDataRef(keyword, ref string, ptr, instPath []string, hosts []map[string]interface{}, rooted bool) error {
+	location := "#" + joinJSONPointer(ptr)
+
+	var (
+		host       map[string]interface{}
+		sourcePath []string
+		dataPath   []string
+	)
+	if strings.HasPrefix(ref, "/") || ref == "" {
+		if !rooted {
+			return fmt.Errorf("%s: absolute $data pointer %q is only supported below the root schema's properties", location, ref)
+		}
+		tokens, err := ParseJSONPointer(ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", location, err)
+		}
+		host, sourcePath, dataPath = hosts[0], instPath, tokens
+	} else {
+		up, rest, err := parseRelativePointer(ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", location, err)
+		}
+		if up > len(instPath) {
+			return fmt.Errorf("%s: $data pointer %q goes up %d level(s), but only %d are reachable through properties", location, ref, up, len(instPath))
+		}
+		host = hosts[len(instPath)-up]
+		sourcePath = instPath[len(instPath)-up:]
+		dataPath = rest
+	}
+
+	rules, _ := host[dataRulesKeyword].([]interface{})
+	host[dataRulesKeyword] = append(rules, map[string]interface{}{
+		"keyword":  keyword,
+		"instance": joinJSONPointer(sourcePath),
+		"data":     joinJSONPointer(dataPath),
+		"schema":   location,
+	})
+	return nil
+}
+
+// parseRelativePointer splits a relative JSON Pointer ("2/a/b") into its
+// up-count and the unescaped tokens that follow
+func parseRelativePointer(ref string) (int, []string, error) {
+	digits, rest := ref, ""
+	if i := strings.Index(ref, "/"); i >= 0 {
+		digits, rest = ref[:i], ref[i:]
+	}
+	up, err := strconv.Atoi(digits)
+	if err != nil || up < 0 || (len(digits) > 1 && digits[0] == '0') {
+		return 0, nil, fmt.Errorf("invalid $data pointer %q: must be a JSON Pointer or a relative JSON Pointer such as \"1/limit\"", ref)
+	}
+	tokens, err := ParseJSONPointer(rest)
+	if err != nil {
+		return 0, nil, err
+	}
+	return up, tokens, nil
+}
+
+// dataRef returns the pointer of a {"$data": pointer} value
+func dataRef(value interface{}) (string, bool) {
+	obj, ok := value.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return "", false
+	}
+	ref, ok := obj[DataKeyword].(string)
+	return ref, ok
+}
+
+// schemaMembers returns the object-valued members of a map of schemas by name
+func schemaMembers(value interface{}) map[string]map[string]interface{} {
+	members := make(map[string]map[string]interface{})
+	if obj, ok := value.(map[string]interface{}); ok {
+		for name, sub := range obj {
+			if subObj, ok := sub.(map[string]interface{}); ok {
+				members[name] = subObj
+			}
+		}
+	}
+	return members
+}
+
+// DataVocabulary returns the vocabulary that enforces rules left by RewriteDataRefs.
+// Register it with Compiler.RegisterVocabulary and enable Compiler.AssertVocabs.
+func DataVocabulary() *jsonschema.Vocabulary {
+	return &jsonschema.Vocabulary{
+		URL:     dataVocabularyURL,
+		Compile: compileDataRules,
+	}
+}
+
+// dataRule is one hoisted $data reference; paths are relative to the host instance
+type dataRule struct {
+	keyword  string
+	instance []string
+	data     []string
+	pointer  string // the original $data keyword's location, for errors
+}
+
+type dataRules struct {
+	rules   []dataRule
+	schemas sync.Map // "<keyword> <JSON value>" -> *jsonschema.Schema
+}
+
+func compileDataRules(_ *jsonschema.CompilerContext, obj map[string]interface{}) (jsonschema.SchemaExt, error) {
+	raw, ok := obj[dataRulesKeyword].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	ext := &dataRules{}
+	for _, item := range raw {
+		rule, _ := item.(map[string]interface{})
+		keyword, _ := rule["keyword"].(string)
+		instancePointer, _ := rule["instance"].(string)
+		dataPointer, _ := rule["data"].(string)
+		location, _ := rule["schema"].(string)
+
+		instance, err := ParseJSONPointer(instancePointer)
+		if err != nil {
+			return nil, err
+		}
+		data, err := ParseJSONPointer(dataPointer)
+		if err != nil {
+			return nil, err
+		}
+		ext.rules = append(ext.rules, dataRule{keyword: keyword, instance: instance, data: data, pointer: location})
+	}
+	return ext, nil
+}
+
+// Validate applies each rule with the keyword value read from the document. As in
+// ajv, a rule is skipped when its instance or its $data value is absent.
+func (e *dataRules) Validate(ctx *jsonschema.ValidatorContext, v interface{}) {
+	for _, rule := range e.rules {
+		instance, _, ok := lookupPath(v, rule.instance)
+		if !ok {
+			continue
+		}
+		value, _, ok := lookupPath(v, rule.data)
+		if !ok {
+			continue
+		}
+
+		schema, err := e.keywordSchema(rule.keyword, value)
+		if err != nil {
+			ctx.AddError(&invalidDataValue{keyword: rule.keyword, pointer: rule.pointer, value: value})
+			continue
+		}
+		if err := ctx.Validate(schema, instance, rule.instance); err != nil {
+			ctx.AddErr(err)
+		}
+	}
+}
+
+// keywordSchema compiles {keyword: value} once per distinct value
+func (e *dataRules) keywordSchema(keyword string, value interface{}) (*jsonschema.Schema, error) {
+	encoded, err := MarshalDeterministicString(value)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := keyword + " " + encoded
+	if cached, ok := e.schemas.Load(cacheKey); ok {
+		return cached.(*jsonschema.Schema), nil
+	}
+
+	const url = "urn:jsonschema-data"
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	if err := compiler.AddResource(url, map[string]interface{}{keyword: value}); err != nil {
+		return nil, err
+	}
+	schema, err := compiler.Compile(url)
+	if err != nil {
+		return nil, err
+	}
+	e.schemas.Store(cacheKey, schema)
+	return schema, nil
+}
+
+// invalidDataValue reports a $data reference that resolved to a value the keyword rejects
+type invalidDataValue struct {
+	keyword string
+	pointer string
+	value   interface{}
+}
+
+func (k *invalidDataValue) KeywordPath() []string {
+	return []string{dataRulesKeyword}
+}
+
+func (k *invalidDataValue) LocalizedString(p *message.Printer) string {
+	encoded, _ := MarshalDeterministicString(k.value)
+	return p.Sprintf("$data for %s at %s resolved to %s, which is not a valid %s value", k.keyword, k.pointer, encoded, k.keyword)
+}
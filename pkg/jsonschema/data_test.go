@@ -0,0 +1,145 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func compileWithData(t *testing.T, schemaJSON string) (*jsonschema.Schema, error) {
+	t.Helper()
+	schemaData, err := ParseJSON([]byte(schemaJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RewriteDataRefs(schemaData); err != nil {
+		return nil, err
+	}
+
+	const url = "file:///data.schema.json"
+	compiler := jsonschema.NewCompiler()
+	compiler.RegisterVocabulary(DataVocabulary())
+	compiler.AssertVocabs()
+	if err := compiler.AddResource(url, schemaData); err != nil {
+		t.Fatal(err)
+	}
+	return compiler.Compile(url)
+}
+
+func TestDataKeyword(t *testing.T) {
+	const limits = `{
+		"type": "object",
+		"properties": {
+			"limit": {"type": "integer"},
+			"value": {"type": "integer", "maximum": {"$data": "1/limit"}},
+			"replicas": {"minimum": {"$data": "/min_replicas"}},
+			"servers": {
+				"type": "array",
+				"items": {
+					"properties": {
+						"max_port": {"type": "integer"},
+						"port": {"maximum": {"$data": "1/max_port"}}
+					}
+				}
+			}
+		}
+	}`
+
+	tests := []struct {
+		name          string
+		schema        string
+		document      string
+		errorContains string
+		errorPath     string
+	}{
+		{
+			name:     "maximum from sibling passes",
+			schema:   limits,
+			document: `{"limit": 10, "value": 10}`,
+		},
+		{
+			name:          "maximum from sibling fails",
+			schema:        limits,
+			document:      `{"limit": 10, "value": 11}`,
+			errorContains: "maximum",
+			errorPath:     "/value",
+		},
+		{
+			name:     "absent $data value is ignored",
+			schema:   limits,
+			document: `{"value": 1000}`,
+		},
+		{
+			name:          "absolute pointer",
+			schema:        limits,
+			document:      `{"min_replicas": 3, "replicas": 2}`,
+			errorContains: "minimum",
+			errorPath:     "/replicas",
+		},
+		{
+			name:          "each array item uses its own sibling",
+			schema:        limits,
+			document:      `{"servers": [{"max_port": 100, "port": 80}, {"max_port": 50, "port": 80}]}`,
+			errorContains: "maximum",
+			errorPath:     "/servers/1/port",
+		},
+		{
+			name:          "$data value of the wrong type",
+			schema:        limits,
+			document:      `{"limit": "ten", "value": 5}`,
+			errorContains: `$data for maximum at #/properties/value/maximum resolved to "ten"`,
+		},
+		{
+			name:          "relative pointer above items",
+			schema:        `{"properties": {"n": {}, "list": {"items": {"maximum": {"$data": "2/n"}}}}}`,
+			errorContains: "goes up 2 level(s), but only 0 are reachable",
+		},
+		{
+			name:          "absolute pointer inside $defs",
+			schema:        `{"$defs": {"port": {"maximum": {"$data": "/max"}}}}`,
+			errorContains: "absolute $data pointer \"/max\" is only supported below the root schema's properties",
+		},
+		{
+			name:          "invalid pointer",
+			schema:        `{"properties": {"a": {"maximum": {"$data": "x/limit"}}}}`,
+			errorContains: "invalid $data pointer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := compileWithData(t, tt.schema)
+			if tt.document == "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("compile error = %v", err)
+			}
+
+			document, err := ParseJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = schema.Validate(document)
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Fatalf("unexpected validation error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+			if tt.errorPath != "" {
+				details := ExtractValidationErrors(err, document)
+				if len(details) != 1 || details[0].DocumentPath != tt.errorPath {
+					t.Errorf("expected one error at %q, got %+v", tt.errorPath, details)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package jsonschema
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// maxDefaultRounds bounds how many levels of defaults ApplyDefaults fills in, so a
+// recursive schema whose defaults create the objects it has defaults for can't loop
+const maxDefaultRounds = 32
+
+// ApplyDefaults returns a copy of document in which every property missing from an
+// object is set to the "default" of its schema (or of the schema its $ref points to).
+// Subschemas are followed as in CheckAccess, so objects nested in properties and array
+// items get their defaults too, as do objects a default itself adds. When several
+// schemas that apply to an object have a default for the same property, the first
+// one found wins. The defaults are not validated.
+func ApplyDefaults(schema *jsonschema.Schema, document interface{}) interface{} {
+	result := document
+	for round := 0; round < maxDefaultRounds; round++ {
+		additions := make(map[string]interface{})
+		paths := make(map[string][]string)
+		walkApplicable(schema, result, func(s *jsonschema.Schema, path []string) {
+			value, _, _ := lookupPath(result, path)
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return
+			}
+			for name, propSchema := range s.Properties {
+				if _, ok := object[name]; ok {
+					continue
+				}
+				propPath := append(slices.Clone(path), name)
+				pointer := joinJSONPointer(propPath)
+				if _, ok := additions[pointer]; ok {
+					continue
+				}
+				if value, ok := schemaDefault(propSchema); ok {
+					additions[pointer] = value
+					paths[pointer] = propPath
+				}
+			}
+		})
+		if len(additions) == 0 {
+			break
+		}
+
+		// The first round copies document, so neither it nor the schema's defaults
+		// are modified
+		if round == 0 {
+			result = sortKeys(result)
+		}
+		pointers := make([]string, 0, len(additions))
+		for pointer := range additions {
+			pointers = append(pointers, pointer)
+		}
+		sort.Strings(pointers)
+		for _, pointer := range pointers {
+			result = setPath(result, paths[pointer], sortKeys(additions[pointer]))
+		}
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		want     string
+	}{
+		{
+			name:     "top-level defaults",
+			schema:   `{"properties": {"port": {"type": "integer", "default": 8080}, "host": {"default": "localhost"}, "tls": {"type": "boolean"}}}`,
+			document: `{"host": "example.com"}`,
+			want:     `{"host":"example.com","port":8080}`,
+		},
+		{
+			name:     "nested object defaults",
+			schema:   `{"properties": {"server": {"properties": {"timeout": {"default": "30s"}, "limits": {"properties": {"rps": {"default": 100}}}}}}}`,
+			document: `{"server": {"limits": {}}}`,
+			want:     `{"server":{"limits":{"rps":100},"timeout":"30s"}}`,
+		},
+		{
+			name:     "defaults inside a default",
+			schema:   `{"properties": {"logging": {"default": {}, "properties": {"level": {"default": "info"}, "format": {"default": "json"}}}}}`,
+			document: `{}`,
+			want:     `{"logging":{"format":"json","level":"info"}}`,
+		},
+		{
+			name:     "array items through $ref",
+			schema:   `{"properties": {"listeners": {"items": {"$ref": "#/$defs/listener"}}}, "$defs": {"listener": {"properties": {"protocol": {"default": "tcp"}}}}}`,
+			document: `{"listeners": [{"port": 80}, {"port": 53, "protocol": "udp"}]}`,
+			want:     `{"listeners":[{"port":80,"protocol":"tcp"},{"port":53,"protocol":"udp"}]}`,
+		},
+		{
+			name:     "default on the $ref target",
+			schema:   `{"properties": {"mode": {"$ref": "#/$defs/mode"}}, "$defs": {"mode": {"enum": ["a", "b"], "default": "a"}}}`,
+			document: `{}`,
+			want:     `{"mode":"a"}`,
+		},
+		{
+			name:     "null default and present values",
+			schema:   `{"properties": {"owner": {"default": null}, "count": {"default": 1}}}`,
+			document: `{"count": 0}`,
+			want:     `{"count":0,"owner":null}`,
+		},
+		{
+			name:     "non-object documents are unchanged",
+			schema:   `{"properties": {"a": {"default": 1}}}`,
+			document: `[1, 2]`,
+			want:     `[1,2]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaData, err := ParseJSON([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			document, err := ParseJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+			compiler := jsonschema.NewCompiler()
+			if err := compiler.AddResource("file:///config.schema.json", schemaData); err != nil {
+				t.Fatal(err)
+			}
+			schema, err := compiler.Compile("file:///config.schema.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			original, err := MarshalDeterministicString(document)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := MarshalDeterministicString(ApplyDefaults(schema, document))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ApplyDefaults() = %s, want %s", got, tt.want)
+			}
+			if after, _ := MarshalDeterministicString(document); after != original {
+				t.Errorf("ApplyDefaults() modified its input: %s, was %s", after, original)
+			}
+		})
+	}
+}
+
+func TestApplyDefaults_Recursive(t *testing.T) {
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("file:///tree.schema.json", map[string]interface{}{
+		"properties": map[string]interface{}{
+			"child": map[string]interface{}{"$ref": "#", "default": map[string]interface{}{}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("file:///tree.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every default adds an object that has a default, so only the round limit stops it
+	depth := 0
+	for node := ApplyDefaults(schema, map[string]interface{}{}); node != nil; depth++ {
+		node = node.(map[string]interface{})["child"]
+	}
+	if depth != maxDefaultRounds+1 {
+		t.Errorf("ApplyDefaults() nested %d objects, want %d", depth, maxDefaultRounds+1)
+	}
+}
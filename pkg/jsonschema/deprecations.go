@@ -0,0 +1,126 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DeprecatedKeyword marks a property schema as deprecated. The value is either
+// true or a string explaining what to use instead.
+const DeprecatedKeyword = "x-deprecated"
+
+// FindDeprecations walks document alongside schemaData and reports every property the
+// document sets whose schema is annotated with x-deprecated. Nested objects, array
+// items, allOf branches and local "#/..." $refs are followed. The result is sorted.
+func FindDeprecations(schemaData, document interface{}) []string {
+	w := &deprecationWalker{root: schemaData, seen: make(map[string]bool)}
+	w.walk(schemaData, document, nil)
+
+	sort.Strings(w.warnings)
+	return w.warnings
+}
+
+type deprecationWalker struct {
+	root     interface{}
+	warnings []string
+	seen     map[string]bool // de-duplicates warnings reached through several branches
+}
+
+func (w *deprecationWalker) walk(schemaNode, docNode interface{}, path []string) {
+	w.walkRefs(schemaNode, docNode, path, nil)
+}
+
+// walkRefs applies one schema object to one document value; refs holds the local
+// $refs already followed at this document location, so a recursive ref can't loop
+func (w *deprecationWalker) walkRefs(schemaNode, docNode interface{}, path []string, refs map[string]bool) {
+	s, ok := schemaNode.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if ref, ok := s["$ref"].(string); ok && len(ref) > 0 && ref[0] == '#' && !refs[ref] {
+		if target, err := resolvePointer(w.root, ref[1:]); err == nil {
+			followed := map[string]bool{ref: true}
+			for r := range refs {
+				followed[r] = true
+			}
+			w.walkRefs(target, docNode, path, followed)
+		}
+	}
+
+	if branches, ok := s["allOf"].([]interface{}); ok {
+		for _, branch := range branches {
+			w.walkRefs(branch, docNode, path, refs)
+		}
+	}
+
+	switch doc := docNode.(type) {
+	case map[string]interface{}:
+		properties, _ := s["properties"].(map[string]interface{})
+		for key, value := range doc {
+			propSchema, ok := properties[key]
+			if !ok {
+				continue
+			}
+			childPath := append(append([]string(nil), path...), key)
+			w.checkDeprecated(propSchema, key, childPath)
+			w.walk(propSchema, value, childPath)
+		}
+
+	case []interface{}:
+		prefixItems, _ := s["prefixItems"].([]interface{})
+		// Before draft 2020-12, an array-valued "items" is the tuple form
+		if tuple, ok := s["items"].([]interface{}); ok {
+			prefixItems = tuple
+		}
+		for i, item := range doc {
+			childPath := append(append([]string(nil), path...), fmt.Sprint(i))
+			if i < len(prefixItems) {
+				w.walk(prefixItems[i], item, childPath)
+			} else if items, ok := s["items"].(map[string]interface{}); ok {
+				w.walk(items, item, childPath)
+			}
+		}
+	}
+}
+
+// checkDeprecated records a warning if the property schema, or a local $ref it
+// points to, carries x-deprecated
+func (w *deprecationWalker) checkDeprecated(propSchema interface{}, key string, path []string) {
+	s, ok := propSchema.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for followed := map[string]bool{}; s[DeprecatedKeyword] == nil; {
+		ref, ok := s["$ref"].(string)
+		if !ok || len(ref) == 0 || ref[0] != '#' || followed[ref] {
+			return
+		}
+		followed[ref] = true
+		target, err := resolvePointer(w.root, ref[1:])
+		if err != nil {
+			return
+		}
+		if s, ok = target.(map[string]interface{}); !ok {
+			return
+		}
+	}
+
+	var warning string
+	switch v := s[DeprecatedKeyword].(type) {
+	case bool:
+		if !v {
+			return
+		}
+		warning = fmt.Sprintf("%s: property %q is deprecated", joinJSONPointer(path), key)
+	case string:
+		warning = fmt.Sprintf("%s: property %q is deprecated: %s", joinJSONPointer(path), key, v)
+	default:
+		return
+	}
+
+	if !w.seen[warning] {
+		w.seen[warning] = true
+		w.warnings = append(w.warnings, warning)
+	}
+}
@@ -0,0 +1,75 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindDeprecations(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		want     []string
+	}{
+		{
+			name:     "top-level property with message",
+			schema:   `{"properties": {"host": {"type": "string", "x-deprecated": "use endpoint"}, "endpoint": {"type": "string"}}}`,
+			document: `{"host": "a", "endpoint": "b"}`,
+			want:     []string{`/host: property "host" is deprecated: use endpoint`},
+		},
+		{
+			name:     "unused deprecated property is not reported",
+			schema:   `{"properties": {"host": {"x-deprecated": true}}}`,
+			document: `{"endpoint": "b"}`,
+			want:     nil,
+		},
+		{
+			name:     "x-deprecated false is ignored",
+			schema:   `{"properties": {"host": {"x-deprecated": false}}}`,
+			document: `{"host": "a"}`,
+			want:     nil,
+		},
+		{
+			name:     "nested objects and array items",
+			schema:   `{"properties": {"servers": {"type": "array", "items": {"properties": {"tls": {"properties": {"ciphers": {"x-deprecated": true}}}}}}}}`,
+			document: `{"servers": [{"tls": {}}, {"tls": {"ciphers": ["a"]}}]}`,
+			want:     []string{`/servers/1/tls/ciphers: property "ciphers" is deprecated`},
+		},
+		{
+			name:     "tuple items",
+			schema:   `{"prefixItems": [{"properties": {"a": {"x-deprecated": true}}}], "items": {"properties": {"b": {"x-deprecated": true}}}}`,
+			document: `[{"a": 1, "b": 1}, {"a": 1, "b": 1}]`,
+			want:     []string{`/0/a: property "a" is deprecated`, `/1/b: property "b" is deprecated`},
+		},
+		{
+			name:     "local refs and allOf",
+			schema:   `{"allOf": [{"$ref": "#/$defs/base"}], "properties": {"old": {"$ref": "#/$defs/legacy"}}, "$defs": {"base": {"properties": {"name": {"x-deprecated": "use id"}}}, "legacy": {"x-deprecated": true}}}`,
+			document: `{"name": "a", "old": 1}`,
+			want:     []string{`/name: property "name" is deprecated: use id`, `/old: property "old" is deprecated`},
+		},
+		{
+			name:     "recursive ref terminates",
+			schema:   `{"$ref": "#/$defs/node", "$defs": {"node": {"$ref": "#/$defs/node", "properties": {"child": {"$ref": "#/$defs/node"}, "legacy": {"x-deprecated": true}}}}}`,
+			document: `{"child": {"child": {"legacy": 1}}}`,
+			want:     []string{`/child/child/legacy: property "legacy" is deprecated`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaData, err := ParseJSON([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			document, err := ParseJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := FindDeprecations(schemaData, document); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindDeprecations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
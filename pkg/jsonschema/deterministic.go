@@ -4,8 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
+	"strconv"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
 )
 
 // MarshalDeterministic marshals data to JSON with deterministic key ordering
@@ -13,6 +18,198 @@ func MarshalDeterministic(data interface{}) ([]byte, error) {
 	return json.Marshal(sortKeys(data))
 }
 
+// MarshalDeterministicIndent marshals data to JSON with deterministic key ordering,
+// indenting nested values by two spaces
+func MarshalDeterministicIndent(data interface{}) ([]byte, error) {
+	return json.MarshalIndent(sortKeys(data), "", "  ")
+}
+
+// MarshalDeterministicYAML marshals data to YAML with deterministic key ordering and
+// two-space indentation. Whole-number floats, which JSON and JSON5 parse every number
+// into, are written as integers: 42.0 becomes 42, not 42.0 or 4.2e+01.
+func MarshalDeterministicYAML(data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(wholeFloatsToInts(sortKeys(data))); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalDeterministicTOML marshals data to TOML with deterministic key ordering.
+// TOML has no top-level arrays or scalars and no null, so the root must be an object
+// and null values are rejected. Whole-number floats are written as integers, as in
+// MarshalDeterministicYAML.
+func MarshalDeterministicTOML(data interface{}) ([]byte, error) {
+	sorted := sortKeys(data)
+	if _, ok := sorted.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("TOML requires a table at the document root, got %s", tomlKind(sorted))
+	}
+	if pointer, ok := findNull(sorted, nil); ok {
+		return nil, fmt.Errorf("TOML has no null value, found one at '%s'", pointer)
+	}
+
+	var buf bytes.Buffer
+	encoder := toml.NewEncoder(&buf)
+	encoder.SetIndentTables(false)
+	if err := encoder.Encode(wholeFloatsToInts(sorted)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// tomlKind names the JSON type of a root that cannot be a TOML document
+func tomlKind(data interface{}) string {
+	switch data.(type) {
+	case nil:
+		return "null"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	default:
+		return "a number"
+	}
+}
+
+// findNull returns the JSON Pointer of the first null value in the maps and slices
+// returned by sortKeys, visiting object keys in sorted order
+func findNull(data interface{}, path []string) (string, bool) {
+	switch v := data.(type) {
+	case nil:
+		return joinJSONPointer(path), true
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if pointer, ok := findNull(v[key], append(path, key)); ok {
+				return pointer, true
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			if pointer, ok := findNull(value, append(path, strconv.Itoa(i))); ok {
+				return pointer, true
+			}
+		}
+	}
+	return "", false
+}
+
+// NormalizeNumbers returns a copy of data (as returned by the parsers) with every number
+// as a float64, the type JSON and JSON5 documents parse numbers into, and -0 as 0. YAML
+// and TOML integers (int, int64, uint64) are converted too, so an equal number encodes
+// the same whichever format and notation it was written in: 1, 1.0, 1e0 and a YAML or
+// TOML 1 all marshal as 1. Integers beyond ±2^53 are rounded as a JSON parser would.
+func NormalizeNumbers(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			normalized[key] = NormalizeNumbers(value)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, value := range v {
+			normalized[i] = NormalizeNumbers(value)
+		}
+		return normalized
+	case float64:
+		if v == 0 {
+			return float64(0) // drops the sign of -0
+		}
+		return v
+	case float32:
+		return NormalizeNumbers(float64(v))
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		return data
+	}
+}
+
+// SortArraysAt returns a copy of data in which the array at each JSON Pointer is sorted
+// by the deterministic JSON of its elements, for arrays whose order carries no meaning.
+// A pointer that does not resolve is skipped; one that resolves to a non-array is an error.
+func SortArraysAt(data interface{}, pointers []string) (interface{}, error) {
+	sorted := sortKeys(data)
+	for _, pointer := range pointers {
+		tokens, err := ParseJSONPointer(pointer)
+		if err != nil {
+			return nil, err
+		}
+		value, _, ok := lookupPath(sorted, tokens)
+		if !ok {
+			continue
+		}
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("JSON pointer %q does not refer to an array", pointer)
+		}
+
+		type keyedElement struct {
+			key   string
+			value interface{}
+		}
+		elements := make([]keyedElement, len(array))
+		for i, element := range array {
+			key, err := MarshalDeterministicString(element)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = keyedElement{key: key, value: element}
+		}
+		sort.SliceStable(elements, func(i, j int) bool { return elements[i].key < elements[j].key })
+		// The array belongs to the copy made by sortKeys, so it is sorted in place
+		for i, element := range elements {
+			array[i] = element.value
+		}
+	}
+	return sorted, nil
+}
+
+// maxExactFloatInt bounds the integers float64 represents exactly (2^53)
+const maxExactFloatInt = 1 << 53
+
+// wholeFloatsToInts replaces float64 values without a fractional part by int64 in
+// the maps and slices returned by sortKeys. Floats beyond ±2^53 are kept: they may
+// not be the integer that was written.
+func wholeFloatsToInts(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = wholeFloatsToInts(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = wholeFloatsToInts(value)
+		}
+		return v
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= maxExactFloatInt {
+			return int64(v)
+		}
+		return v
+	default:
+		return data
+	}
+}
+
 // sortKeys recursively sorts all map keys in the data structure to ensure deterministic output
 func sortKeys(data interface{}) interface{} {
 	v := reflect.ValueOf(data)
@@ -41,6 +41,284 @@ func TestMarshalDeterministic(t *testing.T) {
 	}
 }
 
+func TestMarshalDeterministicIndent(t *testing.T) {
+	testData := map[string]interface{}{
+		"zebra": []interface{}{map[string]interface{}{"y": 2, "x": 1}},
+		"alpha": map[string]interface{}{"charlie": 3, "bravo": 2},
+	}
+
+	expected := `{
+  "alpha": {
+    "bravo": 2,
+    "charlie": 3
+  },
+  "zebra": [
+    {
+      "x": 1,
+      "y": 2
+    }
+  ]
+}`
+	for i := 0; i < 3; i++ {
+		result, err := MarshalDeterministicIndent(testData)
+		if err != nil {
+			t.Fatalf("MarshalDeterministicIndent() error = %v", err)
+		}
+		if string(result) != expected {
+			t.Fatalf("unexpected output:\nExpected:\n%s\nGot:\n%s", expected, result)
+		}
+	}
+}
+
+func TestMarshalDeterministicYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{
+			name: "sorted keys and two-space indentation",
+			input: map[string]interface{}{
+				"zebra": []interface{}{map[string]interface{}{"second": "b", "first": "a"}},
+				"alpha": map[string]interface{}{"charlie": true, "bravo": nil},
+			},
+			expected: "alpha:\n  bravo: null\n  charlie: true\nzebra:\n  - first: a\n    second: b\n",
+		},
+		{
+			name:     "whole floats become integers",
+			input:    map[string]interface{}{"port": 42.0, "big": 123456789.0, "negative": -3.0},
+			expected: "big: 123456789\nnegative: -3\nport: 42\n",
+		},
+		{
+			name:     "fractions and floats beyond 2^53 are kept",
+			input:    map[string]interface{}{"ratio": 1.5, "huge": 1e21},
+			expected: "huge: 1e+21\nratio: 1.5\n",
+		},
+		{
+			name:     "strings that look like other types stay quoted",
+			input:    map[string]interface{}{"a": "42", "b": "yes", "c": ""},
+			expected: "a: \"42\"\nb: \"yes\"\nc: \"\"\n",
+		},
+		{
+			name:     "top-level array",
+			input:    []interface{}{1.0, "two"},
+			expected: "- 1\n- two\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MarshalDeterministicYAML(tt.input)
+			if err != nil {
+				t.Fatalf("MarshalDeterministicYAML() error = %v", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("unexpected output:\nExpected:\n%s\nGot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMarshalDeterministicTOML(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         interface{}
+		expected      string
+		errorContains string
+	}{
+		{
+			name: "sorted keys, tables after values",
+			input: map[string]interface{}{
+				"zebra":  map[string]interface{}{"second": 2.0, "first": 1.0},
+				"alpha":  "a",
+				"ports":  []interface{}{80.0, 443.0},
+				"ratio":  0.5,
+				"enable": true,
+			},
+			expected: "alpha = 'a'\nenable = true\nports = [80, 443]\nratio = 0.5\n\n[zebra]\nfirst = 1\nsecond = 2\n",
+		},
+		{
+			name: "array of tables",
+			input: map[string]interface{}{
+				"servers": []interface{}{map[string]interface{}{"port": 8080.0}, map[string]interface{}{"port": 8081.0}},
+			},
+			expected: "[[servers]]\nport = 8080\n\n[[servers]]\nport = 8081\n",
+		},
+		{name: "array root", input: []interface{}{1.0}, errorContains: "TOML requires a table at the document root, got an array"},
+		{name: "string root", input: "x", errorContains: "got a string"},
+		{name: "null root", input: nil, errorContains: "got null"},
+		{
+			name:          "nested null",
+			input:         map[string]interface{}{"a": map[string]interface{}{"list": []interface{}{1.0, nil}}},
+			errorContains: "TOML has no null value, found one at '/a/list/1'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MarshalDeterministicTOML(tt.input)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MarshalDeterministicTOML() error = %v", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("unexpected output:\nExpected:\n%s\nGot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNormalizeNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   []string // documents in different formats or notations
+		types    []FileType
+		expected string
+	}{
+		{
+			name:     "integral float and integer",
+			inputs:   []string{`{"n":1}`, `{"n":1.0}`, `{n: 1.0}`, "n: 1\n", "n = 1"},
+			types:    []FileType{FileTypeJSON, FileTypeJSON, FileTypeJSON5, FileTypeYAML, FileTypeTOML},
+			expected: `{"n":1}`,
+		},
+		{
+			name:     "exponent notation",
+			inputs:   []string{`{"n":[100, 0.5]}`, `{"n":[1e2, 5e-1]}`, "n: [100, 0.5]\n", "n = [100, 0.5]"},
+			types:    []FileType{FileTypeJSON, FileTypeJSON, FileTypeYAML, FileTypeTOML},
+			expected: `{"n":[100,0.5]}`,
+		},
+		{
+			name:     "negative zero",
+			inputs:   []string{`{"n":-0.0}`, `{"n":0}`, "n: -0.0\n"},
+			types:    []FileType{FileTypeJSON, FileTypeJSON, FileTypeYAML},
+			expected: `{"n":0}`,
+		},
+		{
+			name:     "integer beyond 2^53",
+			inputs:   []string{`{"n":12345678901234567890}`, "n: 12345678901234567890\n"},
+			types:    []FileType{FileTypeJSON, FileTypeYAML},
+			expected: `{"n":12345678901234567000}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, input := range tt.inputs {
+				data, err := ParseBytes([]byte(input), tt.types[i], ParseOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				result, err := MarshalDeterministic(NormalizeNumbers(data))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(result) != tt.expected {
+					t.Errorf("%s %q normalized to %s, want %s", tt.types[i], input, result, tt.expected)
+				}
+			}
+		})
+	}
+
+	t.Run("input is not modified", func(t *testing.T) {
+		data := map[string]interface{}{"n": int64(1), "list": []interface{}{int64(2)}}
+		NormalizeNumbers(data)
+		if _, ok := data["n"].(int64); !ok {
+			t.Errorf("input map modified: %T", data["n"])
+		}
+		if _, ok := data["list"].([]interface{})[0].(int64); !ok {
+			t.Errorf("input slice modified")
+		}
+	})
+}
+
+func TestSortArraysAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		pointers []string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "strings",
+			input:    `{"tags":["web","api","db"],"ports":[443,80]}`,
+			pointers: []string{"/tags"},
+			expected: `{"ports":[443,80],"tags":["api","db","web"]}`,
+		},
+		{
+			name:     "objects by canonical JSON",
+			input:    `{"rules":[{"port":80,"name":"b"},{"name":"a","port":443}]}`,
+			pointers: []string{"/rules"},
+			expected: `{"rules":[{"name":"a","port":443},{"name":"b","port":80}]}`,
+		},
+		{
+			name:     "nested pointer and root array",
+			input:    `[{"ids":[3,1,2]}]`,
+			pointers: []string{"/0/ids"},
+			expected: `[{"ids":[1,2,3]}]`,
+		},
+		{
+			name:     "missing pointer is skipped",
+			input:    `{"tags":["b","a"]}`,
+			pointers: []string{"/labels"},
+			expected: `{"tags":["b","a"]}`,
+		},
+		{
+			name:     "not an array",
+			input:    `{"tags":"a"}`,
+			pointers: []string{"/tags"},
+			wantErr:  `JSON pointer "/tags" does not refer to an array`,
+		},
+		{
+			name:     "invalid pointer",
+			input:    `{"tags":[]}`,
+			pointers: []string{"tags"},
+			wantErr:  `invalid JSON pointer "tags"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ParseJSON([]byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sorted, err := SortArraysAt(data, tt.pointers)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SortArraysAt() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := MarshalDeterministicString(sorted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != tt.expected {
+				t.Errorf("SortArraysAt() = %s, want %s", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("input is not modified", func(t *testing.T) {
+		data := map[string]interface{}{"tags": []interface{}{"b", "a"}}
+		if _, err := SortArraysAt(data, []string{"/tags"}); err != nil {
+			t.Fatal(err)
+		}
+		if data["tags"].([]interface{})[0] != "b" {
+			t.Errorf("input slice modified: %v", data["tags"])
+		}
+	})
+}
+
 func TestMarshalDeterministicString(t *testing.T) {
 	tests := []struct {
 		name     string
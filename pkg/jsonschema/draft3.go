@@ -0,0 +1,108 @@
+package jsonschema
+
+import (
+	"sort"
+	"strings"
+)
+
+// Draft3SchemaURL and Draft4SchemaURL are the "$schema" values of draft-03 and draft-04
+const (
+	Draft3SchemaURL = "http://json-schema.org/draft-03/schema#"
+	Draft4SchemaURL = "http://json-schema.org/draft-04/schema#"
+)
+
+// ConvertDraft3 returns a draft-04 copy of a draft-03 schema, as the validator has no
+// draft-03 support of its own. It rewrites the keywords whose meaning changed:
+//
+//   - "required": true on a property schema becomes an entry in the parent's "required" array
+//   - "divisibleBy" becomes "multipleOf"
+//   - "extends" (a schema or an array of schemas) becomes "allOf"
+//   - a string "dependencies" value becomes a one-element array
+//   - a draft-03 "$schema" becomes the draft-04 one
+//
+// "disallow" and schemas inside a "type" array have no draft-04 equivalent and are left
+// as they are, so draft-04 ignores them. Schemas reached through $ref from other files
+// are not converted.
+func ConvertDraft3(schemaData interface{}) interface{} {
+	return convertDraft3(sortKeys(schemaData))
+}
+
+// convertDraft3 converts the schema object node in place; node is a copy made by sortKeys
+func convertDraft3(node interface{}) interface{} {
+	s, ok := node.(map[string]interface{})
+	if !ok {
+		return node
+	}
+
+	if schemaURL, ok := s["$schema"].(string); ok && strings.TrimSuffix(schemaURL, "#") == strings.TrimSuffix(Draft3SchemaURL, "#") {
+		s["$schema"] = Draft4SchemaURL
+	}
+	if divisor, ok := s["divisibleBy"]; ok {
+		if _, exists := s["multipleOf"]; !exists {
+			s["multipleOf"] = divisor
+		}
+		delete(s, "divisibleBy")
+	}
+	if extends, ok := s["extends"]; ok {
+		branches, isArray := extends.([]interface{})
+		if !isArray {
+			branches = []interface{}{extends}
+		}
+		allOf, _ := s["allOf"].([]interface{})
+		s["allOf"] = append(allOf, branches...)
+		delete(s, "extends")
+	}
+	if dependencies, ok := s["dependencies"].(map[string]interface{}); ok {
+		for key, dependency := range dependencies {
+			if name, ok := dependency.(string); ok {
+				dependencies[key] = []interface{}{name}
+			}
+		}
+	}
+
+	// A boolean "required" belongs to the property; draft-04 lists names on the parent
+	if properties, ok := s["properties"].(map[string]interface{}); ok {
+		var required []string
+		for name, property := range properties {
+			if p, ok := property.(map[string]interface{}); ok && p["required"] == true {
+				required = append(required, name)
+			}
+		}
+		if len(required) > 0 {
+			sort.Strings(required)
+			existing, _ := s["required"].([]interface{})
+			for _, name := range required {
+				existing = append(existing, name)
+			}
+			s["required"] = existing
+		}
+	}
+	if _, ok := s["required"].(bool); ok {
+		delete(s, "required")
+	}
+
+	// Subschemas
+	for _, keyword := range []string{"properties", "patternProperties", "definitions", "dependencies"} {
+		if children, ok := s[keyword].(map[string]interface{}); ok {
+			for key, child := range children {
+				children[key] = convertDraft3(child)
+			}
+		}
+	}
+	for _, keyword := range []string{"additionalProperties", "additionalItems", "not"} {
+		if child, ok := s[keyword]; ok {
+			s[keyword] = convertDraft3(child)
+		}
+	}
+	for _, keyword := range []string{"items", "allOf", "anyOf", "oneOf"} {
+		switch child := s[keyword].(type) {
+		case map[string]interface{}:
+			s[keyword] = convertDraft3(child)
+		case []interface{}:
+			for i, item := range child {
+				child[i] = convertDraft3(item)
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestConvertDraft3(t *testing.T) {
+	input := `{
+		"$schema": "http://json-schema.org/draft-03/schema#",
+		"type": "object",
+		"extends": {"$ref": "#/definitions/base"},
+		"properties": {
+			"name": {"type": "string", "required": true},
+			"port": {"type": "integer", "required": true, "divisibleBy": 2},
+			"tags": {"type": "array", "required": false, "items": {"type": "object", "properties": {"key": {"required": true}}}},
+			"tls": {"type": "boolean"}
+		},
+		"dependencies": {"tls": "tags"},
+		"definitions": {"base": {"properties": {"id": {"required": true}}}}
+	}`
+	want := `{"$schema":"http://json-schema.org/draft-04/schema#","allOf":[{"$ref":"#/definitions/base"}],` +
+		`"definitions":{"base":{"properties":{"id":{}},"required":["id"]}},"dependencies":{"tls":["tags"]},` +
+		`"properties":{"name":{"type":"string"},"port":{"multipleOf":2,"type":"integer"},` +
+		`"tags":{"items":{"properties":{"key":{}},"required":["key"],"type":"object"},"type":"array"},"tls":{"type":"boolean"}},` +
+		`"required":["name","port"],"type":"object"}`
+
+	schemaData, err := ParseJSON([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	converted := ConvertDraft3(schemaData)
+	got, err := MarshalDeterministicString(converted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("ConvertDraft3() =\n%s\nwant\n%s", got, want)
+	}
+
+	if source, _ := MarshalDeterministicString(schemaData); source == got {
+		t.Error("ConvertDraft3() modified its input")
+	}
+
+	// The converted schema compiles and enforces the converted keywords
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("file:///legacy.json", converted); err != nil {
+		t.Fatal(err)
+	}
+	compiled, err := compiler.Compile("file:///legacy.json")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	for document, valid := range map[string]bool{
+		`{"id": 1, "name": "a", "port": 80}`:               true,
+		`{"id": 1, "name": "a"}`:                           false, // port is required
+		`{"name": "a", "port": 80}`:                        false, // id is required by extends
+		`{"id": 1, "name": "a", "port": 81}`:               false, // divisibleBy
+		`{"id": 1, "name": "a", "port": 80, "tags": [{}]}`: false, // nested required
+		`{"id": 1, "name": "a", "port": 80, "tls": true}`:  false, // dependencies
+	} {
+		data, err := ParseJSON([]byte(document))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := compiled.Validate(data); (err == nil) != valid {
+			t.Errorf("Validate(%s) error = %v, want valid = %v", document, err, valid)
+		}
+	}
+}
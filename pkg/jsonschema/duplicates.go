@@ -0,0 +1,273 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DuplicateKeyError reports an object key that appears more than once in a JSON/JSON5 document
+type DuplicateKeyError struct {
+	Key    string // The repeated key (unescaped)
+	Path   string // JSON Pointer to the object containing the key ("" for root, per RFC 6901)
+	Line   int    // 1-based line of the repeated occurrence
+	Column int    // 1-based column of the repeated occurrence
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key %q in object at '%s' (line %d, column %d)", e.Key, e.Path, e.Line, e.Column)
+}
+
+// CheckDuplicateKeys scans JSON or JSON5 content and returns a *DuplicateKeyError for the
+// first object key that is repeated at any depth. Standard decoders silently keep the last
+// value, so this check runs as a separate pass over the raw content.
+//
+// The content is expected to be syntactically valid; parse it first so syntax errors are
+// reported by the real parser. Malformed input stops the scan without an error.
+func CheckDuplicateKeys(content []byte) error {
+	s := &keyScanner{data: content}
+	s.skipSpace()
+	if err := s.value(nil); err != nil && !errors.Is(err, errStopScan) {
+		return err
+	}
+	return nil
+}
+
+// errStopScan signals malformed input; the scan ends quietly
+var errStopScan = errors.New("stop scan")
+
+// keyScanner is a minimal JSON5-aware scanner that only tracks object keys
+type keyScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *keyScanner) value(path []string) error {
+	if s.pos >= len(s.data) {
+		return errStopScan
+	}
+
+	switch c := s.data[s.pos]; c {
+	case '{':
+		return s.object(path)
+	case '[':
+		return s.array(path)
+	case '"', '\'':
+		_, err := s.quoted()
+		return err
+	default:
+		s.literal()
+		return nil
+	}
+}
+
+func (s *keyScanner) object(path []string) error {
+	s.pos++ // consume '{'
+	seen := make(map[string]bool)
+
+	for {
+		s.skipSpace()
+		if s.pos >= len(s.data) {
+			return errStopScan
+		}
+		if s.data[s.pos] == '}' {
+			s.pos++
+			return nil
+		}
+
+		keyStart := s.pos
+		var key string
+		if c := s.data[s.pos]; c == '"' || c == '\'' {
+			k, err := s.quoted()
+			if err != nil {
+				return err
+			}
+			key = k
+		} else {
+			key = s.identifier()
+			if key == "" {
+				return errStopScan
+			}
+		}
+
+		if seen[key] {
+			line, col := s.lineCol(keyStart)
+			return &DuplicateKeyError{
+				Key:    key,
+				Path:   formatInstanceLocation(path),
+				Line:   line,
+				Column: col,
+			}
+		}
+		seen[key] = true
+
+		s.skipSpace()
+		if s.pos >= len(s.data) || s.data[s.pos] != ':' {
+			return errStopScan
+		}
+		s.pos++
+		s.skipSpace()
+
+		if err := s.value(append(path[:len(path):len(path)], key)); err != nil {
+			return err
+		}
+
+		s.skipSpace()
+		if s.pos >= len(s.data) {
+			return errStopScan
+		}
+		if s.data[s.pos] == ',' {
+			s.pos++
+		}
+	}
+}
+
+func (s *keyScanner) array(path []string) error {
+	s.pos++ // consume '['
+
+	for i := 0; ; i++ {
+		s.skipSpace()
+		if s.pos >= len(s.data) {
+			return errStopScan
+		}
+		if s.data[s.pos] == ']' {
+			s.pos++
+			return nil
+		}
+
+		if err := s.value(append(path[:len(path):len(path)], strconv.Itoa(i))); err != nil {
+			return err
+		}
+
+		s.skipSpace()
+		if s.pos >= len(s.data) {
+			return errStopScan
+		}
+		if s.data[s.pos] == ',' {
+			s.pos++
+		}
+	}
+}
+
+// quoted consumes a single- or double-quoted string and returns its unescaped value
+func (s *keyScanner) quoted() (string, error) {
+	quote := s.data[s.pos]
+	start := s.pos
+	s.pos++
+
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case '\\':
+			s.pos += 2
+		case quote:
+			s.pos++
+			return unescapeKey(string(s.data[start+1:s.pos-1]), quote), nil
+		default:
+			s.pos++
+		}
+	}
+
+	return "", errStopScan
+}
+
+// identifier consumes an unquoted JSON5 object key
+func (s *keyScanner) identifier() string {
+	start := s.pos
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		if c == ':' || isScanSpace(c) || c == '/' {
+			break
+		}
+		s.pos++
+	}
+	return string(s.data[start:s.pos])
+}
+
+// literal consumes a number, boolean, null, or other bare JSON5 value
+func (s *keyScanner) literal() {
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		if c == ',' || c == '}' || c == ']' || isScanSpace(c) || c == '/' {
+			return
+		}
+		s.pos++
+	}
+}
+
+// skipSpace skips whitespace and JSON5 comments
+func (s *keyScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		switch {
+		case isScanSpace(c):
+			s.pos++
+		case c == '/' && s.pos+1 < len(s.data) && s.data[s.pos+1] == '/':
+			for s.pos < len(s.data) && s.data[s.pos] != '\n' {
+				s.pos++
+			}
+		case c == '/' && s.pos+1 < len(s.data) && s.data[s.pos+1] == '*':
+			end := strings.Index(string(s.data[s.pos+2:]), "*/")
+			if end < 0 {
+				s.pos = len(s.data)
+				return
+			}
+			s.pos += end + 4
+		default:
+			return
+		}
+	}
+}
+
+// lineCol converts a byte offset into a 1-based line and column
+func (s *keyScanner) lineCol(offset int) (int, int) {
+	line, col := 1, 1
+	for i := 0; i < offset && i < len(s.data); i++ {
+		if s.data[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
+
+func isScanSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+// unescapeKey decodes escape sequences in a quoted key
+// Falls back to the raw text when the escapes can't be decoded
+func unescapeKey(raw string, quote byte) string {
+	if !strings.Contains(raw, `\`) {
+		return raw
+	}
+
+	if quote == '\'' {
+		// Rewrite as a double-quoted string body: \' becomes ', bare " gets escaped
+		var b strings.Builder
+		for i := 0; i < len(raw); i++ {
+			switch {
+			case raw[i] == '\\' && i+1 < len(raw) && raw[i+1] == '\'':
+				b.WriteByte('\'')
+				i++
+			case raw[i] == '\\' && i+1 < len(raw):
+				b.WriteString(raw[i : i+2])
+				i++
+			case raw[i] == '"':
+				b.WriteString(`\"`)
+			default:
+				b.WriteByte(raw[i])
+			}
+		}
+		raw = b.String()
+	}
+
+	var key string
+	if err := json.Unmarshal([]byte(`"`+raw+`"`), &key); err != nil {
+		return raw
+	}
+	return key
+}
@@ -0,0 +1,140 @@
+package jsonschema
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckDuplicateKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantKey  string
+		wantPath string
+		wantLine int
+	}{
+		{
+			name:  "no duplicates",
+			input: `{"a": 1, "b": {"a": 2}, "c": [{"a": 3}, {"a": 4}]}`,
+		},
+		{
+			name:     "top-level duplicate",
+			input:    `{"a":1,"a":2}`,
+			wantKey:  "a",
+			wantPath: "",
+			wantLine: 1,
+		},
+		{
+			name:     "nested object duplicate",
+			input:    "{\n  \"server\": {\n    \"port\": 80,\n    \"port\": 443\n  }\n}",
+			wantKey:  "port",
+			wantPath: "/server",
+			wantLine: 4,
+		},
+		{
+			name:     "duplicate inside array element",
+			input:    `{"items": [{"id": 1}, {"id": 2, "id": 3}]}`,
+			wantKey:  "id",
+			wantPath: "/items/1",
+			wantLine: 1,
+		},
+		{
+			name:     "JSON5 unquoted and quoted keys are the same key",
+			input:    `{name: "a", 'name': "b"}`,
+			wantKey:  "name",
+			wantPath: "",
+			wantLine: 1,
+		},
+		{
+			name:     "JSON5 comments and trailing commas",
+			input:    "{\n  // comment with \"a\": 1\n  a: 1, /* b: 2 */\n  b: [1, 2,],\n  a: 3,\n}",
+			wantKey:  "a",
+			wantPath: "",
+			wantLine: 5,
+		},
+		{
+			name:     "escaped keys are compared unescaped",
+			input:    `{"a": 1, "\u0061": 2}`,
+			wantKey:  "a",
+			wantPath: "",
+			wantLine: 1,
+		},
+		{
+			name:  "same key in sibling objects is allowed",
+			input: `[{"a": 1}, {"a": 2}]`,
+		},
+		{
+			name:  "strings that look like keys are ignored",
+			input: `{"a": "{\"a\": 1, \"a\": 2}", "b": 'x: 1, x: 2'}`,
+		},
+		{
+			name:  "scalar document",
+			input: `42`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckDuplicateKeys([]byte(tt.input))
+
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Errorf("CheckDuplicateKeys() unexpected error: %v", err)
+				}
+				return
+			}
+
+			var dupErr *DuplicateKeyError
+			if !errors.As(err, &dupErr) {
+				t.Fatalf("CheckDuplicateKeys() error = %v, want *DuplicateKeyError", err)
+			}
+			if dupErr.Key != tt.wantKey {
+				t.Errorf("Key = %q, want %q", dupErr.Key, tt.wantKey)
+			}
+			if dupErr.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", dupErr.Path, tt.wantPath)
+			}
+			if dupErr.Line != tt.wantLine {
+				t.Errorf("Line = %d, want %d", dupErr.Line, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestParseFileWithOptions_ForbidDuplicateKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"dup.json":   `{"a": 1, "a": 2}`,
+		"dup.json5":  `{a: 1, nested: {b: 1, b: 2}}`,
+		"clean.json": `{"a": 1, "b": 2}`,
+	}
+	for filename, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		opts     ParseOptions
+		wantErr  bool
+	}{
+		{"JSON duplicates allowed by default", "dup.json", ParseOptions{}, false},
+		{"JSON duplicates rejected", "dup.json", ParseOptions{ForbidDuplicateKeys: true}, true},
+		{"JSON5 duplicates rejected", "dup.json5", ParseOptions{ForbidDuplicateKeys: true}, true},
+		{"clean JSON passes", "clean.json", ParseOptions{ForbidDuplicateKeys: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFileWithOptions(filepath.Join(tmpDir, tt.filename), FileTypeAuto, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseFileWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package jsonschema
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+)
+
+// ValidateEach validates every element of elements against schema, which describes a
+// single element rather than the array. It returns nil when every element passes.
+// Otherwise the validation error has the errors of all failing elements as causes,
+// located under the element's index (e.g. /1/port), so it formats like the error of
+// an array schema whose items are schema.
+func ValidateEach(schema *jsonschema.Schema, elements []interface{}) error {
+	var causes []*jsonschema.ValidationError
+	for i, element := range elements {
+		err := schema.Validate(element)
+		if err == nil {
+			continue
+		}
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			return err
+		}
+		prefixed := prefixInstanceLocation(validationErr, strconv.Itoa(i))
+		if len(prefixed.Causes) == 0 {
+			causes = append(causes, prefixed)
+		} else {
+			causes = append(causes, prefixed.Causes...)
+		}
+	}
+	if len(causes) == 0 {
+		return nil
+	}
+
+	return &jsonschema.ValidationError{
+		SchemaURL:        schema.Location,
+		InstanceLocation: []string{},
+		ErrorKind:        &kind.Schema{Location: schema.Location},
+		Causes:           causes,
+	}
+}
+
+// prefixInstanceLocation returns a copy of err and its causes located under token
+func prefixInstanceLocation(err *jsonschema.ValidationError, token string) *jsonschema.ValidationError {
+	prefixed := *err
+	prefixed.InstanceLocation = append([]string{token}, err.InstanceLocation...)
+	prefixed.Causes = make([]*jsonschema.ValidationError, len(err.Causes))
+	for i, cause := range err.Causes {
+		prefixed.Causes[i] = prefixInstanceLocation(cause, token)
+	}
+	return &prefixed
+}
@@ -0,0 +1,36 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateEach(t *testing.T) {
+	schema := compileTestSchema(t, `{"type": "object", "required": ["name"], "properties": {"port": {"type": "integer"}}}`)
+
+	if err := ValidateEach(schema, []interface{}{}); err != nil {
+		t.Errorf("ValidateEach() on an empty array = %v, want nil", err)
+	}
+
+	elements, err := ParseJSON([]byte(`[{"name": "a", "port": 80}, {"port": "http"}, {"name": "c"}, "d"]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ValidateEach(schema, elements.([]interface{}))
+	if err == nil {
+		t.Fatal("ValidateEach() = nil, want an error")
+	}
+
+	var paths []string
+	for _, detail := range ExtractValidationErrors(err, elements) {
+		paths = append(paths, detail.DocumentPath)
+	}
+	want := []string{"/1", "/1/port", "/3"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("error paths = %v, want %v", paths, want)
+	}
+	if strings.Contains(err.Error(), "at '/0") || strings.Contains(err.Error(), "at '/2") {
+		t.Errorf("error reports a valid element:\n%s", err)
+	}
+}
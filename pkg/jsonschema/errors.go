@@ -10,14 +10,25 @@ import (
 	"text/template"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
 )
 
 // ValidationErrorDetail represents a single validation error with rich context
 type ValidationErrorDetail struct {
-	Message      string `json:"message"`      // Human-readable error message
-	DocumentPath string `json:"documentPath"` // JSON Pointer to location in document where error occurred
-	SchemaPath   string `json:"schemaPath"`   // JSON Pointer to schema constraint that failed
-	Value        string `json:"value"`        // The actual value that failed validation (if available)
+	Message      string `json:"message"`              // Human-readable error message
+	DocumentPath string `json:"documentPath"`         // JSON Pointer to location in document where error occurred
+	SchemaPath   string `json:"schemaPath"`           // Schema URL with a JSON Pointer fragment to the failing keyword (e.g. "...#/properties/port/type")
+	Value        string `json:"value"`                // The actual value that failed validation (if available)
+	ValueType    string `json:"valueType"`            // JSON type of Value: string, number, boolean, object, array or null ("" when there is no value)
+	SchemaFile   string `json:"schemaFile,omitempty"` // Schema file that reported the error (set when validating against several schemas)
+	Line         int    `json:"line,omitempty"`       // 1-based line of the record in a JSONL document (0 otherwise)
+	RawMessage   string `json:"rawMessage,omitempty"` // The library's untouched message, even when Message was rewritten (empty for other errors)
+}
+
+// SchemaValidationFailure is the validation error a document produced against one schema
+type SchemaValidationFailure struct {
+	SchemaFile string // Path to the schema file
+	Err        error  // Error returned by validation against that schema
 }
 
 // ErrorContext holds data available for error message templating
@@ -25,54 +36,188 @@ type ErrorContext struct {
 	SchemaFile  string                  `json:"schemaFile"`  // Path to the schema file
 	Document    string                  `json:"document"`    // The document content (truncated if too long)
 	Errors      []ValidationErrorDetail `json:"errors"`      // Individual validation errors with details
-	ErrorCount  int                     `json:"errorCount"`  // Number of individual errors
+	ErrorCount  int                     `json:"errorCount"`  // Number of individual errors, including those ErrorOptions.MaxErrors leaves out
 	FullMessage string                  `json:"fullMessage"` // Complete formatted error message from jsonschema
 }
 
+// ErrorOptions adjusts how validation errors are extracted and formatted
+type ErrorOptions struct {
+	// UnknownKeys reports every property rejected by "additionalProperties": false
+	// as its own error at the property's path, instead of one error on the object
+	UnknownKeys bool
+
+	// MaxErrors caps the errors passed to the template, and listed in FullMessage, at
+	// the first MaxErrors after sorting; a "... and N more" line notes the rest. 0 keeps all.
+	MaxErrors int
+
+	// RedactValues keeps document values out of the formatted error, for documents that
+	// hold secrets: every Value becomes RedactedValue, messages that quote the value
+	// (pattern, format, minimum, ...) show RedactedValue instead, and so does Document
+	RedactValues bool
+
+	// RedactPaths redacts like RedactValues, but only the values at or under the
+	// JSON Pointer globs it lists, e.g. "/credentials/*" or "/**/password": "*" matches
+	// any one reference token and "**" any number of them. Values elsewhere, including
+	// Document where it parses, stay visible with the matching parts masked.
+	RedactPaths []string
+}
+
 // FormatValidationError creates a formatted error message using the provided template
 func FormatValidationError(err error, schemaPath, document, errorTemplate string) error {
+	return FormatValidationErrorWithOptions(err, schemaPath, document, errorTemplate, ErrorOptions{})
+}
+
+// FormatValidationErrorWithOptions is FormatValidationError with extraction options
+func FormatValidationErrorWithOptions(err error, schemaPath, document, errorTemplate string, opts ErrorOptions) error {
 	if err == nil {
 		return nil
 	}
+	documentData := parseDocumentContent(document)
+	return formatValidationError(err, schemaPath, documentContext(document, documentData, opts), documentData, errorTemplate, opts)
+}
 
-	var errors []ValidationErrorDetail
-	var fullMessage string
+// FormatDocumentValidationError is FormatValidationErrorWithOptions for a document that
+// was already parsed: error values come from documentData, and documentLabel (e.g. its
+// path) is the Document of the template context
+func FormatDocumentValidationError(err error, schemaPath, documentLabel string, documentData interface{}, errorTemplate string, opts ErrorOptions) error {
+	if err == nil {
+		return nil
+	}
+	return formatValidationError(err, schemaPath, documentContext(documentLabel, nil, opts), documentData, errorTemplate, opts)
+}
 
-	var validationErr *jsonschema.ValidationError
-	if errors2.As(err, &validationErr) {
-		// Parse the document to extract actual values for errors
-		var documentData interface{}
-		if parseErr := json.Unmarshal([]byte(document), &documentData); parseErr != nil {
-			// If we can't parse, try JSON5
-			if data, err := ParseJSON5String(document); err == nil {
-				documentData = data
-			}
+// parseDocumentContent parses a JSON or JSON5 document for the values of its errors,
+// returning nil when it does not parse
+func parseDocumentContent(document string) interface{} {
+	var documentData interface{}
+	if parseErr := json.Unmarshal([]byte(document), &documentData); parseErr != nil {
+		// If we can't parse, try JSON5
+		if data, err := ParseJSON5String(document); err == nil {
+			return data
 		}
+		return nil
+	}
+	return documentData
+}
+
+func formatValidationError(err error, schemaPath, document string, documentData interface{}, errorTemplate string, opts ErrorOptions) error {
+	errors := ExtractValidationErrorsWithOptions(err, documentData, opts)
+	shown, omitted := limitErrors(errors, opts.MaxErrors)
 
-		errors = extractValidationErrors(validationErr, documentData)
+	var fullMessage string
+	var validationErr *jsonschema.ValidationError
+	if errors2.As(err, &validationErr) {
 		// Generate full message using sorted errors for consistency
-		fullMessage = generateSortedFullMessage(validationErr, errors)
+		fullMessage = generateSortedFullMessage(validationErr, shown)
 	} else {
-		// For non-validation errors, create a single error detail
-		errors = []ValidationErrorDetail{{
-			Message:      err.Error(),
-			DocumentPath: "",
-		}}
 		fullMessage = err.Error()
 	}
 
 	// Create clean template context
 	ctx := ErrorContext{
 		SchemaFile:  schemaPath,
-		Document:    truncateString(document, 500),
-		Errors:      errors,
+		Document:    document,
+		Errors:      shown,
 		ErrorCount:  len(errors),
 		FullMessage: fullMessage,
 	}
 
+	return executeErrorTemplate(errorTemplate, ctx, omitted)
+}
+
+// FormatMultiSchemaValidationError formats the errors a document produced against several schemas.
+// Errors from every schema are merged (in schema order) and labeled with their SchemaFile;
+// FullMessage lists the failures per schema. SchemaFile in the template context is the
+// comma-separated list of failing schema paths.
+func FormatMultiSchemaValidationError(failures []SchemaValidationFailure, document, errorTemplate string) error {
+	return FormatMultiSchemaValidationErrorWithOptions(failures, document, errorTemplate, ErrorOptions{})
+}
+
+// FormatMultiSchemaValidationErrorWithOptions is FormatMultiSchemaValidationError with extraction options
+func FormatMultiSchemaValidationErrorWithOptions(failures []SchemaValidationFailure, document, errorTemplate string, opts ErrorOptions) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	documentData := parseDocumentContent(document)
+	return formatMultiSchemaValidationError(failures, documentContext(document, documentData, opts), documentData, errorTemplate, opts)
+}
+
+// FormatDocumentMultiSchemaValidationError is FormatMultiSchemaValidationErrorWithOptions
+// for a document that was already parsed, like FormatDocumentValidationError
+func FormatDocumentMultiSchemaValidationError(failures []SchemaValidationFailure, documentLabel string, documentData interface{}, errorTemplate string, opts ErrorOptions) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return formatMultiSchemaValidationError(failures, documentContext(documentLabel, nil, opts), documentData, errorTemplate, opts)
+}
+
+func formatMultiSchemaValidationError(failures []SchemaValidationFailure, document string, documentData interface{}, errorTemplate string, opts ErrorOptions) error {
+	var (
+		allErrors    []ValidationErrorDetail
+		errorCount   int
+		omitted      int
+		schemaFiles  []string
+		messageParts []string
+	)
+	for _, failure := range failures {
+		errors := ExtractValidationErrorsWithOptions(failure.Err, documentData, opts)
+		errorCount += len(errors)
+		// The cap applies across schemas, in schema order
+		if opts.MaxErrors > 0 {
+			remaining := opts.MaxErrors - len(allErrors)
+			if remaining <= 0 {
+				omitted += len(errors)
+				schemaFiles = append(schemaFiles, failure.SchemaFile)
+				continue
+			}
+			var skipped int
+			errors, skipped = limitErrors(errors, remaining)
+			omitted += skipped
+		}
+		for i := range errors {
+			errors[i].SchemaFile = failure.SchemaFile
+		}
+		allErrors = append(allErrors, errors...)
+		schemaFiles = append(schemaFiles, failure.SchemaFile)
+
+		var validationErr *jsonschema.ValidationError
+		if errors2.As(failure.Err, &validationErr) {
+			messageParts = append(messageParts, fmt.Sprintf("schema %q: %s", failure.SchemaFile, generateSortedFullMessage(validationErr, errors)))
+		} else {
+			messageParts = append(messageParts, fmt.Sprintf("schema %q: %s", failure.SchemaFile, failure.Err.Error()))
+		}
+	}
+
+	ctx := ErrorContext{
+		SchemaFile:  strings.Join(schemaFiles, ", "),
+		Document:    document,
+		Errors:      allErrors,
+		ErrorCount:  errorCount,
+		FullMessage: strings.Join(messageParts, "\n"),
+	}
+
+	return executeErrorTemplate(errorTemplate, ctx, omitted)
+}
+
+// limitErrors returns the first max errors and the number left out; max 0 keeps all
+func limitErrors(errors []ValidationErrorDetail, max int) ([]ValidationErrorDetail, int) {
+	if max <= 0 || len(errors) <= max {
+		return errors, 0
+	}
+	return errors[:max], len(errors) - max
+}
+
+// executeErrorTemplate renders an error template against ctx and returns it as an error,
+// followed by a "... and N more" line when omitted errors were left out of ctx
+func executeErrorTemplate(errorTemplate string, ctx ErrorContext, omitted int) error {
 	// Execute Go template with helper functions
 	tmpl := template.New("error").Funcs(template.FuncMap{
-		"add": func(a, b int) int { return a + b },
+		"add":    func(a, b int) int { return a + b }, // {{add $i 1}} numbers errors from 1
+		"toJson": MarshalDeterministicString,          // {{toJson .Errors}} dumps any value as deterministic JSON
+		"upper":  strings.ToUpper,
+		"lower":  strings.ToLower,
+		"trim":   strings.TrimSpace, // Strips leading and trailing whitespace
+		"indent": indentLines,       // {{indent 2 .FullMessage}} prefixes every line with n spaces
 	})
 
 	parsed, err := tmpl.Parse(errorTemplate)
@@ -84,10 +229,19 @@ func FormatValidationError(err error, schemaPath, document, errorTemplate string
 	if err := parsed.Execute(&buf, ctx); err != nil {
 		return fmt.Errorf("template execution failed: %w", err)
 	}
+	if omitted > 0 {
+		return fmt.Errorf("%s\n... and %d more", strings.TrimRight(buf.String(), "\n"), omitted)
+	}
 
 	return fmt.Errorf("%s", buf.String())
 }
 
+// indentLines prefixes every line of s with n spaces (e.g. {{indent 2 .FullMessage}})
+func indentLines(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
 // Common error message templates that users can reference
 var CommonErrorTemplates = map[string]string{
 	"basic":       "{{range .Errors}}{{.Message}}\n{{end}}",
@@ -104,6 +258,26 @@ func GetCommonTemplate(name string) (string, bool) {
 	return template, exists
 }
 
+// ResolveErrorTemplate expands a "@name" reference (e.g. "@detailed") to the common template of
+// that name. Any other value is returned unchanged as a raw Go template.
+func ResolveErrorTemplate(errorTemplate string) (string, error) {
+	if !strings.HasPrefix(errorTemplate, "@") {
+		return errorTemplate, nil
+	}
+
+	name := strings.TrimPrefix(errorTemplate, "@")
+	if resolved, ok := GetCommonTemplate(name); ok {
+		return resolved, nil
+	}
+
+	names := make([]string, 0, len(CommonErrorTemplates))
+	for known := range CommonErrorTemplates {
+		names = append(names, "@"+known)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("unknown error template %q (available: %s)", errorTemplate, strings.Join(names, ", "))
+}
+
 // generateSortedFullMessage creates a full error message using sorted errors for consistency
 func generateSortedFullMessage(err *jsonschema.ValidationError, sortedErrors []ValidationErrorDetail) string {
 	// Use the main error prefix from the original error
@@ -143,75 +317,203 @@ func extractCleanMessage(message, path string) string {
 	return message
 }
 
+// ExtractValidationErrors returns the individual, sorted errors contained in err.
+// documentData is the parsed document used to populate each error's Value (may be nil).
+// Errors that are not schema validation errors yield a single detail with the error text.
+func ExtractValidationErrors(err error, documentData interface{}) []ValidationErrorDetail {
+	return ExtractValidationErrorsWithOptions(err, documentData, ErrorOptions{})
+}
+
+// ExtractValidationErrorsWithOptions is ExtractValidationErrors with extraction options
+func ExtractValidationErrorsWithOptions(err error, documentData interface{}, opts ErrorOptions) []ValidationErrorDetail {
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if errors2.As(err, &validationErr) {
+		errors := extractValidationErrors(validationErr, documentData, opts)
+		// Leaf-only errors skip the sort inside extractValidationErrors; sort here so callers
+		// serializing the result always get deterministic ordering
+		sortValidationErrors(errors)
+		if opts.RedactValues {
+			for i := range errors {
+				if errors[i].Value != "" {
+					errors[i].Value = RedactedValue
+				}
+			}
+		}
+		return errors
+	}
+
+	// For non-validation errors, create a single error detail
+	return []ValidationErrorDetail{{
+		Message:      err.Error(),
+		DocumentPath: "",
+	}}
+}
+
 // extractValidationErrors recursively extracts all validation errors from the error tree
-func extractValidationErrors(err *jsonschema.ValidationError, documentData interface{}) []ValidationErrorDetail {
+func extractValidationErrors(err *jsonschema.ValidationError, documentData interface{}, opts ErrorOptions) []ValidationErrorDetail {
 	var errors []ValidationErrorDetail
 
+	// The name's errors carry no document location of their own
+	if names, ok := err.ErrorKind.(*kind.PropertyNames); ok {
+		return propertyNameErrors(err, names.Property, documentData, opts)
+	}
+
 	// If there are child causes, extract them individually (they contain the specific errors)
 	if len(err.Causes) > 0 {
 		for _, child := range err.Causes {
-			errors = append(errors, extractValidationErrors(child, documentData)...)
+			errors = append(errors, extractValidationErrors(child, documentData, opts)...)
 		}
 		// Sort errors for consistent ordering
 		sortValidationErrors(errors)
 		return errors
 	}
 
+	if additional, ok := err.ErrorKind.(*kind.AdditionalProperties); ok && opts.UnknownKeys {
+		return unknownKeyErrors(err, additional.Properties, documentData, opts)
+	}
+
 	// If no child causes, this is a leaf error - use it directly
 	detail := ValidationErrorDetail{
 		Message:      err.Error(),
 		DocumentPath: formatInstanceLocation(err.InstanceLocation),
-		SchemaPath:   err.SchemaURL,
-		Value:        extractValueAtPath(documentData, err.InstanceLocation),
+		SchemaPath:   keywordLocation(err),
+		RawMessage:   err.Error(),
+	}
+	detail.Value, detail.ValueType = errorValue(documentData, err.InstanceLocation, opts)
+	if message, ok := friendlyMessage(err.ErrorKind); ok {
+		detail.Message = fmt.Sprintf("at '%s': %s", detail.DocumentPath, message)
+	}
+	if message, ok := redactedMessage(err.ErrorKind); ok && opts.redacts(err.InstanceLocation) {
+		detail.Message = fmt.Sprintf("at '%s': %s", detail.DocumentPath, message)
+		detail.RawMessage = detail.Message
 	}
 
 	errors = append(errors, detail)
 	return errors
 }
 
+// GroupErrorsByPath maps each DocumentPath to the messages reported there, without
+// the "at '<path>': " prefix, joined with "; ". Errors of a JSONL document are
+// prefixed with their line.
+func GroupErrorsByPath(details []ValidationErrorDetail) map[string]string {
+	messages := make(map[string][]string)
+	for _, detail := range details {
+		message := extractCleanMessage(detail.Message, detail.DocumentPath)
+		if detail.Line > 0 {
+			message = fmt.Sprintf("line %d: %s", detail.Line, message)
+		}
+		messages[detail.DocumentPath] = append(messages[detail.DocumentPath], message)
+	}
+
+	grouped := make(map[string]string, len(messages))
+	for path, list := range messages {
+		grouped[path] = strings.Join(list, "; ")
+	}
+	return grouped
+}
+
+// unknownKeyErrors splits an "additional properties not allowed" error into one
+// error per property, located at the property itself
+func unknownKeyErrors(err *jsonschema.ValidationError, properties []string, documentData interface{}, opts ErrorOptions) []ValidationErrorDetail {
+	errors := make([]ValidationErrorDetail, 0, len(properties))
+	for _, property := range properties {
+		location := append(append([]string(nil), err.InstanceLocation...), property)
+		path := formatInstanceLocation(location)
+		detail := ValidationErrorDetail{
+			Message:      fmt.Sprintf("at '%s': unexpected property '%s'", path, property),
+			DocumentPath: path,
+			SchemaPath:   keywordLocation(err),
+			RawMessage:   err.Error(),
+		}
+		detail.Value, detail.ValueType = errorValue(documentData, location, opts)
+		errors = append(errors, detail)
+	}
+	return errors
+}
+
+// keywordLocation returns the absolute location of the keyword that failed: the
+// schema URL with the keyword appended to its fragment (e.g. "...#/properties/port/type")
+func keywordLocation(err *jsonschema.ValidationError) string {
+	location := err.SchemaURL
+	if !strings.Contains(location, "#") {
+		location += "#"
+	}
+	return location + joinJSONPointer(err.ErrorKind.KeywordPath())
+}
+
+// friendlyMessage rewrites the library's terse message for some keywords, e.g.
+// "minProperties: got 2, want 3" becomes "object has 2 properties, want at least 3"
+func friendlyMessage(errorKind jsonschema.ErrorKind) (string, bool) {
+	switch k := errorKind.(type) {
+	case *kind.MinProperties:
+		return fmt.Sprintf("object has %s, want at least %d", pluralProperties(k.Got), k.Want), true
+	case *kind.MaxProperties:
+		return fmt.Sprintf("object has %s, want at most %d", pluralProperties(k.Got), k.Want), true
+	default:
+		return "", false
+	}
+}
+
+// pluralProperties formats a property count, e.g. "1 property" or "2 properties"
+func pluralProperties(n int) string {
+	if n == 1 {
+		return "1 property"
+	}
+	return fmt.Sprintf("%d properties", n)
+}
+
 // extractValueAtPath retrieves the value at the given JSON path from the document
-func extractValueAtPath(data interface{}, path []string) string {
+// as JSON, with its JSON type; both are empty when the path does not exist
+func extractValueAtPath(data interface{}, path []string) (string, string) {
 	if data == nil || len(path) == 0 {
 		// For root-level errors, try to show the whole document (truncated)
 		if jsonBytes, err := json.Marshal(data); err == nil {
 			valueStr := string(jsonBytes)
 			if len(valueStr) > 100 {
-				return valueStr[:100] + "..."
+				return valueStr[:100] + "...", jsonTypeName(data)
 			}
-			return valueStr
+			return valueStr, jsonTypeName(data)
 		}
-		return ""
+		return "", ""
 	}
 
 	// Navigate to the value at the path
-	current := data
-	for _, key := range path {
-		switch v := current.(type) {
-		case map[string]interface{}:
-			var ok bool
-			current, ok = v[key]
-			if !ok {
-				return "" // Path doesn't exist (e.g., missing required field)
-			}
-		case []interface{}:
-			// Try to parse key as array index
-			var idx int
-			if _, err := fmt.Sscanf(key, "%d", &idx); err == nil && idx >= 0 && idx < len(v) {
-				current = v[idx]
-			} else {
-				return ""
-			}
-		default:
-			return "" // Can't navigate further
-		}
+	current, _, ok := lookupPath(data, path)
+	if !ok {
+		return "", "" // Path doesn't exist (e.g., missing required field)
 	}
 
 	// Serialize the value to JSON
 	if jsonBytes, err := json.Marshal(current); err == nil {
-		return string(jsonBytes)
+		return string(jsonBytes), jsonTypeName(current)
 	}
 
-	return ""
+	return "", ""
+}
+
+// jsonTypeName returns the JSON type of a decoded value: "string", "number",
+// "boolean", "object", "array" or "null". Integers are numbers.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
+		return "number"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		return ""
+	}
 }
 
 // sortValidationErrors sorts validation errors for consistent ordering
@@ -188,6 +188,43 @@ func TestNilErrorHandling(t *testing.T) {
 	}
 }
 
+func TestResolveErrorTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      string
+		errorContains string
+	}{
+		{name: "named reference", input: "@detailed", expected: CommonErrorTemplates["detailed"]},
+		{name: "raw template", input: "{{.FullMessage}}", expected: "{{.FullMessage}}"},
+		{name: "raw template containing @", input: "mail admin@example.com: {{.FullMessage}}", expected: "mail admin@example.com: {{.FullMessage}}"},
+		{name: "empty", input: "", expected: ""},
+		{
+			name:          "unknown name lists available templates",
+			input:         "@fancy",
+			errorContains: `unknown error template "@fancy" (available: @basic, @detailed, @simple, @verbose, @with_path, @with_schema)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveErrorTemplate(tt.input)
+			if tt.errorContains != "" {
+				if err == nil || err.Error() != tt.errorContains {
+					t.Fatalf("expected error %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("ResolveErrorTemplate(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestGetCommonTemplate(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -586,6 +623,86 @@ func TestFormatValidationErrorTemplateAddFunction(t *testing.T) {
 	}
 }
 
+func TestFormatValidationErrorTemplateTextFunctions(t *testing.T) {
+	mockErr := fmt.Errorf("  Validation failed\n- at '/port': got string\n- at '/name': missing  ")
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "upper",
+			template: "{{upper .FullMessage}}",
+			expected: "  VALIDATION FAILED\n- AT '/PORT': GOT STRING\n- AT '/NAME': MISSING  ",
+		},
+		{
+			name:     "lower",
+			template: "{{lower .FullMessage}}",
+			expected: "  validation failed\n- at '/port': got string\n- at '/name': missing  ",
+		},
+		{
+			name:     "trim",
+			template: "[{{trim .FullMessage}}]",
+			expected: "[Validation failed\n- at '/port': got string\n- at '/name': missing]",
+		},
+		{
+			name:     "indent every line",
+			template: "Errors:\n{{indent 2 (trim .FullMessage)}}",
+			expected: "Errors:\n  Validation failed\n  - at '/port': got string\n  - at '/name': missing",
+		},
+		{
+			name:     "indent zero is a no-op",
+			template: "{{indent 0 .FullMessage}}",
+			expected: "  Validation failed\n- at '/port': got string\n- at '/name': missing  ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatValidationError(mockErr, "test.json", "{}", tt.template)
+			if result.Error() != tt.expected {
+				t.Errorf("Expected: %q\nGot:      %q", tt.expected, result.Error())
+			}
+		})
+	}
+}
+
+func TestFormatValidationErrorTemplateToJsonFunction(t *testing.T) {
+	compiler := jsonschema.NewCompiler()
+	schemaData := map[string]interface{}{
+		"type":       "object",
+		"required":   []interface{}{"name"},
+		"properties": map[string]interface{}{"port": map[string]interface{}{"type": "integer"}},
+	}
+	if err := compiler.AddResource("https://example.com/config.json", schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("https://example.com/config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	document := `{"port": "80"}`
+	var documentData interface{}
+	if err := json.Unmarshal([]byte(document), &documentData); err != nil {
+		t.Fatal(err)
+	}
+	validationErr := schema.Validate(documentData)
+
+	// Fields follow ValidationErrorDetail's declaration order; empty schemaFile and line are omitted
+	expected := `[{"message":"at '': missing property 'name'","documentPath":"","schemaPath":"https://example.com/config.json#/required","value":"{\"port\":\"80\"}","valueType":"object","rawMessage":"at '': missing property 'name'"},` +
+		`{"message":"at '/port': got string, want integer","documentPath":"/port","schemaPath":"https://example.com/config.json#/properties/port/type","value":"\"80\"","valueType":"string","rawMessage":"at '/port': got string, want integer"}]`
+
+	// Rendering twice must give byte-identical output
+	for i := 0; i < 2; i++ {
+		result := FormatValidationError(validationErr, "config.json", document, "{{toJson .Errors}}")
+		if result.Error() != expected {
+			t.Errorf("toJson output mismatch\nExpected: %s\nGot:      %s", expected, result.Error())
+		}
+	}
+}
+
 func TestFormatValidationErrorEdgeCaseTemplates(t *testing.T) {
 	// Test various template edge cases
 	mockErr := fmt.Errorf("test error")
@@ -833,7 +950,7 @@ func TestExtractValueAtPath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := extractValueAtPath(tt.data, tt.path)
+			result, _ := extractValueAtPath(tt.data, tt.path)
 
 			if tt.expected == "" && result != "" {
 				// Check if it's a truncation case
@@ -988,7 +1105,7 @@ func TestValueFieldPopulationIntegration(t *testing.T) {
 			}
 
 			// Extract errors
-			errors := extractValidationErrors(validationErr, doc)
+			errors := extractValidationErrors(validationErr, doc, ErrorOptions{})
 
 			// Verify Value field is populated correctly
 			for _, valErr := range errors {
@@ -1005,3 +1122,465 @@ func TestValueFieldPopulationIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractValidationErrors(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		if got := ExtractValidationErrors(nil, nil); got != nil {
+			t.Errorf("ExtractValidationErrors(nil) = %v, want nil", got)
+		}
+	})
+
+	t.Run("non-validation error", func(t *testing.T) {
+		got := ExtractValidationErrors(fmt.Errorf("reading file: not found"), nil)
+		if len(got) != 1 || got[0].Message != "reading file: not found" || got[0].DocumentPath != "" {
+			t.Errorf("ExtractValidationErrors() = %+v, want single detail with error text", got)
+		}
+	})
+
+	t.Run("validation errors are sorted", func(t *testing.T) {
+		compiler := jsonschema.NewCompiler()
+		var schemaData interface{}
+		if err := json.Unmarshal([]byte(`{
+			"type": "object",
+			"properties": {
+				"b": {"type": "string"},
+				"a": {"type": "integer"}
+			}
+		}`), &schemaData); err != nil {
+			t.Fatal(err)
+		}
+		if err := compiler.AddResource("test.schema.json", schemaData); err != nil {
+			t.Fatal(err)
+		}
+		schema, err := compiler.Compile("test.schema.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doc := map[string]interface{}{"b": 1.0, "a": "x"}
+		got := ExtractValidationErrors(schema.Validate(doc), doc)
+
+		if len(got) != 2 {
+			t.Fatalf("expected 2 errors, got %d: %+v", len(got), got)
+		}
+		if got[0].DocumentPath != "/a" || got[1].DocumentPath != "/b" {
+			t.Errorf("errors not sorted by path: %q, %q", got[0].DocumentPath, got[1].DocumentPath)
+		}
+		if got[0].Value != `"x"` || got[1].Value != "1" {
+			t.Errorf("values not populated: %q, %q", got[0].Value, got[1].Value)
+		}
+	})
+
+	t.Run("schema path points at the failing keyword", func(t *testing.T) {
+		const url = "file:///keywords.schema.json"
+		schemaData, err := ParseJSON([]byte(`{
+			"required": ["name"],
+			"properties": {
+				"a/b": {"type": "string"},
+				"port": {"$ref": "#/$defs/port"},
+				"tags": {"minItems": 2}
+			},
+			"$defs": {"port": {"maximum": 65535}}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		compiler := jsonschema.NewCompiler()
+		if err := compiler.AddResource(url, schemaData); err != nil {
+			t.Fatal(err)
+		}
+		schema, err := compiler.Compile(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doc := map[string]interface{}{"a/b": 1.0, "port": 70000.0, "tags": []interface{}{}}
+		var got []string
+		for _, detail := range ExtractValidationErrors(schema.Validate(doc), doc) {
+			got = append(got, detail.SchemaPath)
+			if detail.RawMessage == "" {
+				t.Errorf("RawMessage not populated for %q", detail.Message)
+			}
+		}
+		want := []string{
+			url + "#/required",
+			url + "#/properties/a~1b/type",
+			url + "#/$defs/port/maximum",
+			url + "#/properties/tags/minItems",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("schema paths =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	})
+}
+
+func TestFormatMultiSchemaValidationError(t *testing.T) {
+	compile := func(t *testing.T, url, schemaJSON string) *jsonschema.Schema {
+		t.Helper()
+		var schemaData interface{}
+		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
+			t.Fatal(err)
+		}
+		compiler := jsonschema.NewCompiler()
+		if err := compiler.AddResource(url, schemaData); err != nil {
+			t.Fatal(err)
+		}
+		schema, err := compiler.Compile(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schema
+	}
+
+	base := compile(t, "base.schema.json", `{"properties": {"name": {"type": "string"}}}`)
+	overlay := compile(t, "overlay.schema.json", `{"required": ["team"]}`)
+
+	doc := map[string]interface{}{"name": 42.0}
+	failures := []SchemaValidationFailure{
+		{SchemaFile: "base.schema.json", Err: base.Validate(doc)},
+		{SchemaFile: "overlay.schema.json", Err: overlay.Validate(doc)},
+	}
+
+	t.Run("no failures", func(t *testing.T) {
+		if err := FormatMultiSchemaValidationError(nil, "doc.json", "{{.FullMessage}}"); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("errors are labeled with their schema", func(t *testing.T) {
+		err := FormatMultiSchemaValidationError(failures, "doc.json", "{{.ErrorCount}}|{{.SchemaFile}}|{{range .Errors}}[{{.SchemaFile}} {{.DocumentPath}}]{{end}}")
+		want := "2|base.schema.json, overlay.schema.json|[base.schema.json /name][overlay.schema.json ]"
+		if err == nil || err.Error() != want {
+			t.Errorf("got %v, want %q", err, want)
+		}
+	})
+
+	t.Run("full message lists each schema", func(t *testing.T) {
+		err := FormatMultiSchemaValidationError(failures, "doc.json", "{{.FullMessage}}")
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		lines := strings.Split(err.Error(), "\n")
+		if !strings.HasPrefix(lines[0], `schema "base.schema.json": jsonschema validation failed`) {
+			t.Errorf("unexpected first line: %q", lines[0])
+		}
+		if !strings.Contains(err.Error(), `schema "overlay.schema.json": jsonschema validation failed`) {
+			t.Errorf("overlay failure missing from full message: %q", err.Error())
+		}
+	})
+}
+
+func TestExtractValidationErrors_UnknownKeys(t *testing.T) {
+	const url = "file:///strict.schema.json"
+	schemaData, err := ParseJSON([]byte(`{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"server": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {"host": {"type": "string"}}
+			},
+			"users": {
+				"type": "array",
+				"items": {"type": "object", "additionalProperties": false, "properties": {"name": {}}}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(url, schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := ParseJSON([]byte(`{
+		"server": {"host": "example.com", "prot": 80, "tls": true},
+		"users": [{"name": "a"}, {"name": "b", "admin": true}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validationErr := schema.Validate(doc)
+
+	t.Run("one error per unexpected property", func(t *testing.T) {
+		errors := ExtractValidationErrorsWithOptions(validationErr, doc, ErrorOptions{UnknownKeys: true})
+		var got []string
+		for _, e := range errors {
+			got = append(got, e.DocumentPath+" "+extractCleanMessage(e.Message, e.DocumentPath)+" "+e.Value)
+		}
+		want := []string{
+			"/server/prot unexpected property 'prot' 80",
+			"/server/tls unexpected property 'tls' true",
+			"/users/1/admin unexpected property 'admin' true",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	})
+
+	t.Run("full message names each property", func(t *testing.T) {
+		err := FormatValidationErrorWithOptions(validationErr, "strict.schema.json", "", "{{.FullMessage}}", ErrorOptions{UnknownKeys: true})
+		if err == nil || !strings.Contains(err.Error(), "- at '/server/tls': unexpected property 'tls'") {
+			t.Errorf("unexpected full message: %v", err)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		errors := ExtractValidationErrors(validationErr, doc)
+		if len(errors) != 2 || errors[0].DocumentPath != "/server" {
+			t.Errorf("expected one error per object, got %+v", errors)
+		}
+	})
+}
+
+func TestExtractValidationErrors_PropertyCounts(t *testing.T) {
+	const url = "file:///counts.schema.json"
+	schemaData, err := ParseJSON([]byte(`{
+		"properties": {
+			"labels": {"minProperties": 3},
+			"tags": {"maxProperties": 1},
+			"owner": {"minProperties": 2}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(url, schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := ParseJSON([]byte(`{"labels": {"a": 1, "b": 2}, "tags": {"x": 1, "y": 2}, "owner": {"name": "a"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validationErr := schema.Validate(doc)
+
+	errors := ExtractValidationErrors(validationErr, doc)
+	var got []string
+	for _, e := range errors {
+		got = append(got, e.Message+" | "+e.RawMessage)
+	}
+	want := []string{
+		"at '/labels': object has 2 properties, want at least 3 | at '/labels': minProperties: got 2, want 3",
+		"at '/owner': object has 1 property, want at least 2 | at '/owner': minProperties: got 1, want 2",
+		"at '/tags': object has 2 properties, want at most 1 | at '/tags': maxProperties: got 2, want 1",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	t.Run("full message uses the rewritten text", func(t *testing.T) {
+		err := FormatValidationError(validationErr, "counts.schema.json", "", "{{.FullMessage}}")
+		if err == nil || !strings.Contains(err.Error(), "- at '/labels': object has 2 properties, want at least 3") {
+			t.Errorf("unexpected full message: %v", err)
+		}
+	})
+}
+
+func TestGroupErrorsByPath(t *testing.T) {
+	got := GroupErrorsByPath([]ValidationErrorDetail{
+		{Message: "at '/name': minLength: got 2, want 5", DocumentPath: "/name"},
+		{Message: "at '/name': does not match pattern", DocumentPath: "/name"},
+		{Message: "missing property 'team'", DocumentPath: ""},
+		{Message: "at '/port': got string, want integer", DocumentPath: "/port", Line: 3},
+	})
+	want := map[string]string{
+		"":      "missing property 'team'",
+		"/name": "minLength: got 2, want 5; does not match pattern",
+		"/port": "line 3: got string, want integer",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("GroupErrorsByPath() = %v, want %v", got, want)
+	}
+	for path, message := range want {
+		if got[path] != message {
+			t.Errorf("GroupErrorsByPath()[%q] = %q, want %q", path, got[path], message)
+		}
+	}
+}
+
+func TestExtractValidationErrors_ValueType(t *testing.T) {
+	schemaData, err := ParseJSON([]byte(`{
+		"properties": {
+			"string": {"enum": ["a"]},
+			"number": {"const": 1},
+			"integer": {"enum": [1]},
+			"boolean": {"const": false},
+			"object": {"enum": [{}]},
+			"array": {"const": []},
+			"null": {"enum": ["a"]}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("types.schema.json", schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("types.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := ParseJSON([]byte(`{
+		"string": "b",
+		"number": 2.5,
+		"integer": 2,
+		"boolean": true,
+		"object": {"a": 1},
+		"array": [1],
+		"null": null
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][2]string{
+		"/string":  {`"b"`, "string"},
+		"/number":  {"2.5", "number"},
+		"/integer": {"2", "number"},
+		"/boolean": {"true", "boolean"},
+		"/object":  {`{"a":1}`, "object"},
+		"/array":   {"[1]", "array"},
+		"/null":    {"null", "null"},
+	}
+	got := ExtractValidationErrors(schema.Validate(doc), doc)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %+v", len(want), len(got), got)
+	}
+	for _, detail := range got {
+		expected, ok := want[detail.DocumentPath]
+		if !ok {
+			t.Errorf("unexpected error at %q", detail.DocumentPath)
+			continue
+		}
+		if detail.Value != expected[0] || detail.ValueType != expected[1] {
+			t.Errorf("%s: Value, ValueType = %q, %q, want %q, %q", detail.DocumentPath, detail.Value, detail.ValueType, expected[0], expected[1])
+		}
+	}
+
+	t.Run("root value", func(t *testing.T) {
+		rootSchema, err := ParseJSON([]byte(`{"type": "object"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		compiler := jsonschema.NewCompiler()
+		if err := compiler.AddResource("root.schema.json", rootSchema); err != nil {
+			t.Fatal(err)
+		}
+		root, err := compiler.Compile("root.schema.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		details := ExtractValidationErrors(root.Validate([]interface{}{"a"}), []interface{}{"a"})
+		if len(details) != 1 || details[0].Value != `["a"]` || details[0].ValueType != "array" {
+			t.Errorf("ExtractValidationErrors() = %+v, want the root array", details)
+		}
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		if value, valueType := extractValueAtPath(map[string]interface{}{}, []string{"missing"}); value != "" || valueType != "" {
+			t.Errorf("extractValueAtPath() = %q, %q, want empty", value, valueType)
+		}
+	})
+}
+
+func TestJSONTypeName(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, "null"},
+		{"x", "string"},
+		{true, "boolean"},
+		{float64(1.5), "number"},
+		{int(1), "number"},
+		{int64(-1), "number"},
+		{uint64(1), "number"},
+		{json.Number("1"), "number"},
+		{map[string]interface{}{}, "object"},
+		{[]interface{}{}, "array"},
+		{struct{}{}, ""},
+	}
+	for _, tt := range tests {
+		if got := jsonTypeName(tt.value); got != tt.want {
+			t.Errorf("jsonTypeName(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatValidationErrorWithOptions_MaxErrors(t *testing.T) {
+	schemaData, err := ParseJSON([]byte(`{
+		"properties": {
+			"a": {"type": "string"},
+			"b": {"type": "string"},
+			"c": {"type": "string"},
+			"d": {"type": "string"},
+			"e": {"type": "string"}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("limit.schema.json", schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("limit.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	validationErr := schema.Validate(map[string]interface{}{"e": 5.0, "d": 4.0, "c": 3.0, "b": 2.0, "a": 1.0})
+
+	t.Run("errors", func(t *testing.T) {
+		got := FormatValidationErrorWithOptions(validationErr, "limit.schema.json", "doc.json", "{{.ErrorCount}}:{{range .Errors}} {{.DocumentPath}}{{end}}", ErrorOptions{MaxErrors: 2})
+		if want := "5: /a /b\n... and 3 more"; got.Error() != want {
+			t.Errorf("FormatValidationErrorWithOptions() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("full message", func(t *testing.T) {
+		got := FormatValidationErrorWithOptions(validationErr, "limit.schema.json", "doc.json", "{{.FullMessage}}", ErrorOptions{MaxErrors: 2})
+		want := "\n- at '/a': got number, want string\n- at '/b': got number, want string\n... and 3 more"
+		if !strings.HasSuffix(got.Error(), want) {
+			t.Errorf("FormatValidationErrorWithOptions() = %q, want it to end with %q", got, want)
+		}
+	})
+
+	t.Run("no cap", func(t *testing.T) {
+		for _, max := range []int{0, 5, 10} {
+			got := FormatValidationErrorWithOptions(validationErr, "limit.schema.json", "doc.json", "{{len .Errors}}", ErrorOptions{MaxErrors: max})
+			if got.Error() != "5" {
+				t.Errorf("MaxErrors %d: FormatValidationErrorWithOptions() = %q, want 5", max, got)
+			}
+		}
+	})
+
+	t.Run("several schemas", func(t *testing.T) {
+		failures := []SchemaValidationFailure{
+			{SchemaFile: "first.json", Err: validationErr},
+			{SchemaFile: "second.json", Err: validationErr},
+		}
+		got := FormatMultiSchemaValidationErrorWithOptions(failures, "doc.json", "{{.ErrorCount}}:{{range .Errors}} {{.SchemaFile}}{{.DocumentPath}}{{end}}", ErrorOptions{MaxErrors: 7})
+		if want := "10: first.json/a first.json/b first.json/c first.json/d first.json/e second.json/a second.json/b\n... and 3 more"; got.Error() != want {
+			t.Errorf("FormatMultiSchemaValidationErrorWithOptions() = %q, want %q", got, want)
+		}
+
+		got = FormatMultiSchemaValidationErrorWithOptions(failures, "doc.json", "{{.SchemaFile}}:{{len .Errors}}", ErrorOptions{MaxErrors: 2})
+		if want := "first.json, second.json:2\n... and 8 more"; got.Error() != want {
+			t.Errorf("FormatMultiSchemaValidationErrorWithOptions() = %q, want %q", got, want)
+		}
+	})
+}
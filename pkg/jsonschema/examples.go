@@ -0,0 +1,56 @@
+package jsonschema
+
+import (
+	"sort"
+	"strconv"
+)
+
+// ExamplesKeyword annotates a schema with example documents that must validate
+// against it. The value is an array of examples.
+const ExamplesKeyword = "x-examples"
+
+// SchemaExamples are the x-examples of one subschema
+type SchemaExamples struct {
+	Pointer  string        // JSON Pointer of the subschema within the schema ("" for the root)
+	Examples []interface{} // The x-examples array of the subschema
+}
+
+// exampleValueKeywords hold values rather than subschemas; x-examples inside them
+// are data, not annotations
+var exampleValueKeywords = map[string]bool{
+	"const":         true,
+	"enum":          true,
+	"default":       true,
+	"examples":      true,
+	ExamplesKeyword: true,
+}
+
+// FindExamples returns every subschema of schemaData that has an x-examples array,
+// in the order of their pointers, so the nearest enclosing subschema of each example
+// can be compiled and the example validated against it
+func FindExamples(schemaData interface{}) []SchemaExamples {
+	var found []SchemaExamples
+	findExamples(schemaData, nil, &found)
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Pointer < found[j].Pointer
+	})
+	return found
+}
+
+func findExamples(node interface{}, path []string, found *[]SchemaExamples) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if examples, ok := n[ExamplesKeyword].([]interface{}); ok {
+			*found = append(*found, SchemaExamples{Pointer: joinJSONPointer(path), Examples: examples})
+		}
+		for key, value := range n {
+			if !exampleValueKeywords[key] {
+				findExamples(value, append(path[:len(path):len(path)], key), found)
+			}
+		}
+	case []interface{}:
+		for i, item := range n {
+			findExamples(item, append(path[:len(path):len(path)], strconv.Itoa(i)), found)
+		}
+	}
+}
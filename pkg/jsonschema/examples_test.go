@@ -0,0 +1,33 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindExamples(t *testing.T) {
+	schemaData, err := ParseJSON([]byte(`{
+		"x-examples": [{"port": 80}],
+		"properties": {
+			"port": {"type": "integer", "x-examples": [1, 2]},
+			"a/b": {"x-examples": ["slash"]},
+			"name": {"const": {"x-examples": ["data"]}, "default": {"x-examples": ["data"]}},
+			"tags": {"items": [{"x-examples": ["first"]}]},
+			"host": {"x-examples": "not an array"}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := FindExamples(schemaData)
+	want := []SchemaExamples{
+		{Pointer: "", Examples: []interface{}{map[string]interface{}{"port": float64(80)}}},
+		{Pointer: "/properties/a~1b", Examples: []interface{}{"slash"}},
+		{Pointer: "/properties/port", Examples: []interface{}{float64(1), float64(2)}},
+		{Pointer: "/properties/tags/items/0", Examples: []interface{}{"first"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindExamples() = %#v, want %#v", got, want)
+	}
+}
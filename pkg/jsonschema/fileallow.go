@@ -0,0 +1,33 @@
+package jsonschema
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// AllowlistFileLoader only passes file:// URLs whose path matches one of Patterns to
+// Next, so a $ref such as "../../etc/secrets.json" can't make the compiler read files
+// outside the schema directories
+type AllowlistFileLoader struct {
+	Patterns []string             // Glob patterns (filepath.Match syntax); relative ones are made absolute
+	Next     jsonschema.URLLoader // Loader for allowed files
+}
+
+// Load implements jsonschema.URLLoader
+func (l *AllowlistFileLoader) Load(url string) (interface{}, error) {
+	path, err := jsonschema.FileLoader{}.ToFile(url)
+	if err != nil {
+		return nil, err
+	}
+	// "a/../../b" is matched as the file it names
+	path = filepath.Clean(path)
+	for _, pattern := range l.Patterns {
+		if matchFilePattern(pattern, path) {
+			return l.Next.Load(url)
+		}
+	}
+	return nil, fmt.Errorf("$ref to %q is not allowed (allowed patterns: %s)", path, strings.Join(l.Patterns, ", "))
+}
@@ -0,0 +1,37 @@
+package jsonschema
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestAllowlistFileLoader(t *testing.T) {
+	dir := writeSchemaFiles(t, map[string]string{
+		"schemas/main.json":      `{"properties": {"port": {"$ref": "types.json#/$defs/port"}}}`,
+		"schemas/traversal.json": `{"properties": {"key": {"$ref": "../secrets/key.json"}}}`,
+		"schemas/types.json":     `{"$defs": {"port": {"type": "integer"}}}`,
+		"secrets/key.json":       `{"type": "string"}`,
+	})
+	compile := func(name string) error {
+		compiler := jsonschema.NewCompiler()
+		compiler.UseLoader(jsonschema.SchemeURLLoader{"file": &AllowlistFileLoader{
+			Patterns: []string{filepath.Join(dir, "schemas", "*.json")},
+			Next:     JSON5FileLoader{},
+		}})
+		_, err := compiler.Compile(filepath.Join(dir, "schemas", name))
+		return err
+	}
+
+	if err := compile("main.json"); err != nil {
+		t.Errorf("Compile(main.json) error = %v, want nil", err)
+	}
+
+	err := compile("traversal.json")
+	want := `$ref to "` + filepath.Join(dir, "secrets", "key.json") + `" is not allowed`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Compile(traversal.json) error = %v, want it to contain %q", err, want)
+	}
+}
@@ -0,0 +1,119 @@
+package jsonschema
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+)
+
+// PatchOperation is one JSON Patch (RFC 6902) operation
+type PatchOperation struct {
+	Op    string      // "add", "replace" or "remove"
+	Path  string      // JSON Pointer to the changed location
+	Value interface{} // The new value; unused for "remove"
+}
+
+// SuggestFixes returns the JSON Patch operations that resolve the failures in err that
+// have exactly one obvious fix, sorted by path:
+//
+//   - a missing required property whose schema has a "default" is added with it
+//   - a value of the wrong type is replaced with its schema's "default", if the default
+//     satisfies that schema
+//   - a value that is not the schema's "const" is replaced with it
+//   - a property "additionalProperties": false rejects is removed
+//
+// Other failures, and failures under anyOf/oneOf branches the value satisfies none of,
+// are left out, so applying the patch may not make the document valid.
+func SuggestFixes(schema *jsonschema.Schema, document interface{}, err error) []PatchOperation {
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+
+	// The schemas that apply to each document value, by "<schema location> <path>".
+	// A failure in a schema that is not among them, such as an anyOf branch, has no
+	// single fix.
+	applicable := make(map[string]*jsonschema.Schema)
+	walkApplicable(schema, document, func(s *jsonschema.Schema, path []string) {
+		applicable[s.Location+" "+joinJSONPointer(path)] = s
+	})
+
+	var fixes []PatchOperation
+	for _, leaf := range leafValidationErrors(validationErr) {
+		path := joinJSONPointer(leaf.InstanceLocation)
+		s, ok := applicable[leaf.SchemaURL+" "+path]
+		if !ok {
+			continue
+		}
+		switch k := leaf.ErrorKind.(type) {
+		case *kind.Required:
+			for _, name := range k.Missing {
+				if value, ok := schemaDefault(s.Properties[name]); ok {
+					fixes = append(fixes, PatchOperation{Op: "add", Path: joinJSONPointer(append(slices.Clone(leaf.InstanceLocation), name)), Value: value})
+				}
+			}
+		case *kind.Type:
+			if s.Default != nil && s.Validate(*s.Default) == nil {
+				fixes = append(fixes, PatchOperation{Op: "replace", Path: path, Value: *s.Default})
+			}
+		case *kind.Const:
+			fixes = append(fixes, PatchOperation{Op: "replace", Path: path, Value: k.Want})
+		case *kind.AdditionalProperties:
+			if allowed, ok := s.AdditionalProperties.(bool); ok && !allowed {
+				for _, name := range k.Properties {
+					fixes = append(fixes, PatchOperation{Op: "remove", Path: joinJSONPointer(append(slices.Clone(leaf.InstanceLocation), name))})
+				}
+			}
+		}
+	}
+	return normalizeFixes(fixes)
+}
+
+// schemaDefault returns the "default" of s or, failing that, of the schema its $ref
+// points to
+func schemaDefault(s *jsonschema.Schema) (interface{}, bool) {
+	for ; s != nil; s = s.Ref {
+		if s.Default != nil {
+			return *s.Default, true
+		}
+	}
+	return nil, false
+}
+
+// normalizeFixes sorts fixes by path and drops repeated paths (keeping the first) and
+// paths inside a value another fix replaces or removes, whose fix would not apply
+func normalizeFixes(fixes []PatchOperation) []PatchOperation {
+	sort.SliceStable(fixes, func(i, j int) bool { return fixes[i].Path < fixes[j].Path })
+
+	var normalized []PatchOperation
+	for _, fix := range fixes {
+		if n := len(normalized); n > 0 && normalized[n-1].Path == fix.Path {
+			continue
+		}
+		if slices.ContainsFunc(normalized, func(parent PatchOperation) bool {
+			return parent.Op != "add" && strings.HasPrefix(fix.Path, parent.Path+"/")
+		}) {
+			continue
+		}
+		normalized = append(normalized, fix)
+	}
+	return normalized
+}
+
+// MarshalFixPatch encodes fixes, possibly gathered from several schemas, as a JSON
+// Patch document with deterministic key order
+func MarshalFixPatch(fixes []PatchOperation) (string, error) {
+	operations := []interface{}{}
+	for _, fix := range normalizeFixes(slices.Clone(fixes)) {
+		operation := map[string]interface{}{"op": fix.Op, "path": fix.Path}
+		if fix.Op != "remove" {
+			operation["value"] = fix.Value
+		}
+		operations = append(operations, operation)
+	}
+	return MarshalDeterministicString(operations)
+}
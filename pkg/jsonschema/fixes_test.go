@@ -0,0 +1,92 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestSuggestFixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		want     string
+	}{
+		{
+			name:     "missing required with a default",
+			schema:   `{"required": ["name", "port", "tls"], "properties": {"port": {"default": 8080}, "tls": {"$ref": "#/$defs/tls"}}, "$defs": {"tls": {"type": "boolean", "default": false}}}`,
+			document: `{}`,
+			want:     `[{"op":"add","path":"/port","value":8080},{"op":"add","path":"/tls","value":false}]`,
+		},
+		{
+			name:     "wrong type with a default",
+			schema:   `{"properties": {"replicas": {"type": "integer", "default": 1}, "name": {"type": "string"}}}`,
+			document: `{"replicas": "three", "name": 5}`,
+			want:     `[{"op":"replace","path":"/replicas","value":1}]`,
+		},
+		{
+			name:     "default that does not satisfy its schema",
+			schema:   `{"properties": {"replicas": {"type": "integer", "minimum": 1, "default": 0}}}`,
+			document: `{"replicas": "three"}`,
+			want:     `[]`,
+		},
+		{
+			name:     "const and additional properties",
+			schema:   `{"properties": {"version": {"const": 2}, "spec": {"properties": {"a": {}}, "additionalProperties": false}}}`,
+			document: `{"version": 1, "spec": {"a": 1, "b": 2, "c/d": 3}}`,
+			want:     `[{"op":"remove","path":"/spec/b"},{"op":"remove","path":"/spec/c~1d"},{"op":"replace","path":"/version","value":2}]`,
+		},
+		{
+			name:     "fixes inside a replaced value are dropped",
+			schema:   `{"properties": {"server": {"type": "object", "default": {"port": 80}, "properties": {"tls": {"const": true}}}}}`,
+			document: `{"server": [1]}`,
+			want:     `[{"op":"replace","path":"/server","value":{"port":80}}]`,
+		},
+		{
+			name:     "no fix inside an unmatched anyOf branch",
+			schema:   `{"anyOf": [{"required": ["a"], "properties": {"a": {"default": 1}}}, {"required": ["b"], "properties": {"b": {"default": 2}}}]}`,
+			document: `{}`,
+			want:     `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaData, err := ParseJSON([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			compiler := jsonschema.NewCompiler()
+			if err := compiler.AddResource("fixes.schema.json", schemaData); err != nil {
+				t.Fatal(err)
+			}
+			schema, err := compiler.Compile("fixes.schema.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc, err := ParseJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			validationErr := schema.Validate(doc)
+			if validationErr == nil {
+				t.Fatal("Validate() error = nil, want failure")
+			}
+			got, err := MarshalFixPatch(SuggestFixes(schema, doc, validationErr))
+			if err != nil {
+				t.Fatalf("MarshalFixPatch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("fix patch = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestFixes_ValidDocument(t *testing.T) {
+	if fixes := SuggestFixes(nil, nil, nil); fixes != nil {
+		t.Errorf("SuggestFixes() = %v, want nil", fixes)
+	}
+}
@@ -0,0 +1,100 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// nonSchemaKeywords hold instance data rather than subschemas, so a "format" key inside them is not a keyword
+var nonSchemaKeywords = map[string]bool{
+	"const":    true,
+	"enum":     true,
+	"default":  true,
+	"examples": true,
+}
+
+// UnknownFormats returns the "format" values used in schemaData that the validator cannot assert.
+// With format assertion enabled, the library silently ignores formats it doesn't know;
+// callers use this to turn such typos (e.g. "e-mail") into a clear error.
+// customFormats are treated as known. The result is sorted and de-duplicated.
+func UnknownFormats(schemaData interface{}, customFormats []*jsonschema.Format) []string {
+	used := make(map[string]bool)
+	collectFormats(schemaData, used)
+	if len(used) == 0 {
+		return nil
+	}
+
+	var unknown []string
+	for name := range used {
+		if !isKnownFormat(name, customFormats) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// collectFormats records every string-valued "format" keyword in a schema tree
+func collectFormats(node interface{}, used map[string]bool) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if key == "format" {
+				if name, ok := child.(string); ok {
+					used[name] = true
+					continue
+				}
+			}
+			if nonSchemaKeywords[key] {
+				continue
+			}
+			collectFormats(child, used)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectFormats(child, used)
+		}
+	}
+}
+
+// isKnownFormat compiles a probe schema to ask the library whether it asserts the format
+func isKnownFormat(name string, customFormats []*jsonschema.Format) bool {
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	for _, f := range customFormats {
+		compiler.RegisterFormat(f)
+	}
+
+	const probeURL = "urn:jsonschema-validator:format-probe"
+	if err := compiler.AddResource(probeURL, map[string]interface{}{"format": name}); err != nil {
+		return false
+	}
+	probe, err := compiler.Compile(probeURL)
+	if err != nil {
+		return false
+	}
+	return probe.Format != nil
+}
+
+// NewRegexFormat creates a custom format whose string values must match pattern.
+// Non-string values pass, as with the built-in formats.
+func NewRegexFormat(name, pattern string) (*jsonschema.Format, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex for format %q: %w", name, err)
+	}
+
+	return &jsonschema.Format{
+		Name: name,
+		Validate: func(v interface{}) error {
+			s, ok := v.(string)
+			if !ok || re.MatchString(s) {
+				return nil
+			}
+			return fmt.Errorf("does not match pattern %q", pattern)
+		},
+	}, nil
+}
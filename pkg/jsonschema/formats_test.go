@@ -0,0 +1,84 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestUnknownFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		custom []*jsonschema.Format
+		want   []string
+	}{
+		{
+			name:   "built-in formats are known",
+			schema: `{"properties": {"email": {"format": "email"}, "site": {"format": "uri"}, "at": {"format": "date-time"}}}`,
+			want:   nil,
+		},
+		{
+			name:   "unknown formats are reported sorted and unique",
+			schema: `{"properties": {"a": {"format": "e-mail"}, "b": {"items": {"format": "zip"}}, "c": {"format": "e-mail"}}}`,
+			want:   []string{"e-mail", "zip"},
+		},
+		{
+			name:   "custom formats are known",
+			schema: `{"format": "employee-id"}`,
+			custom: []*jsonschema.Format{{Name: "employee-id", Validate: func(interface{}) error { return nil }}},
+			want:   nil,
+		},
+		{
+			name:   "property named format is not a keyword",
+			schema: `{"properties": {"format": {"type": "string"}}}`,
+			want:   nil,
+		},
+		{
+			name:   "instance data is ignored",
+			schema: `{"enum": [{"format": "bogus"}], "default": {"format": "bogus"}}`,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ParseJSON([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := UnknownFormats(data, tt.custom); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnknownFormats() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRegexFormat(t *testing.T) {
+	if _, err := NewRegexFormat("broken", "[a-"); err == nil {
+		t.Fatal("expected error for invalid regex")
+	}
+
+	format, err := NewRegexFormat("employee-id", `^E[0-9]{6}$`)
+	if err != nil {
+		t.Fatalf("NewRegexFormat() error = %v", err)
+	}
+	if format.Name != "employee-id" {
+		t.Errorf("Name = %q, want %q", format.Name, "employee-id")
+	}
+
+	tests := []struct {
+		value   interface{}
+		wantErr bool
+	}{
+		{"E123456", false},
+		{"E12", true},
+		{42.0, false}, // non-strings are not checked
+	}
+	for _, tt := range tests {
+		if err := format.Validate(tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+	}
+}
@@ -12,7 +12,7 @@ import (
 func ParseJSON5(content []byte) (interface{}, error) {
 	var result interface{}
 	if err := json5.Unmarshal(content, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON5: %w", err)
+		return nil, fmt.Errorf("failed to parse JSON5: %w", withPosition(err, content))
 	}
 	return result, nil
 }
@@ -37,10 +37,11 @@ func JSON5StringToJSON(content string) ([]byte, error) {
 	return JSON5ToJSON([]byte(content))
 }
 
-// JSON5FileLoader is a simple extension of the standard FileLoader that can parse JSON5 files
+// JSON5FileLoader is a simple extension of the standard FileLoader that can parse JSON5 files,
+// and YAML and TOML files by their extension
 type JSON5FileLoader struct{}
 
-// Load implements jsonschema.URLLoader interface for loading JSON5 files
+// Load implements jsonschema.URLLoader interface for loading JSON5, YAML and TOML files
 func (l JSON5FileLoader) Load(url string) (interface{}, error) {
 	// Use the standard file loader to get the file path and read the file
 	fileLoader := jsonschema.FileLoader{}
@@ -55,6 +56,17 @@ func (l JSON5FileLoader) Load(url string) (interface{}, error) {
 		return nil, fmt.Errorf("failed to read file %q: %w", filePath, err)
 	}
 
+	// A $ref such as "./types.yaml#/definitions/Foo" points into a YAML document;
+	// everything else is read as JSON5, a superset of JSON
+	switch fileType := DetectFileType(filePath); fileType {
+	case FileTypeYAML, FileTypeTOML:
+		data, err := ParseBytes(content, fileType, ParseOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse file %q: %w", filePath, err)
+		}
+		return data, nil
+	}
+
 	// Use our existing JSON5 parsing function
 	return ParseJSON5(content)
 }
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
 )
 
 func TestParseJSON5String(t *testing.T) {
@@ -548,3 +550,28 @@ func TestJSON5FileLoaderWithVariousFormats(t *testing.T) {
 		})
 	}
 }
+
+func TestJSON5FileLoader_YAMLRef(t *testing.T) {
+	dir := writeSchemaFiles(t, map[string]string{
+		"schema.json": `{"type": "object", "properties": {"foo": {"$ref": "./types.yaml#/definitions/Foo"}, "port": {"$ref": "limits.toml#/port"}}}`,
+		"types.yaml":  "definitions:\n  Foo:\n    type: string\n    pattern: ^[a-z]+$\n",
+		"limits.toml": "[port]\ntype = \"integer\"\nmaximum = 65535\n",
+	})
+
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(jsonschema.SchemeURLLoader{"file": JSON5FileLoader{}})
+	schema, err := compiler.Compile(filepath.Join(dir, "schema.json"))
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	if err := schema.Validate(map[string]interface{}{"foo": "bar", "port": 443}); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+	if err := schema.Validate(map[string]interface{}{"foo": "Bar"}); err == nil {
+		t.Error("Validate() error = nil, want pattern failure from the YAML definition")
+	}
+	if err := schema.Validate(map[string]interface{}{"port": 70000}); err == nil {
+		t.Error("Validate() error = nil, want maximum failure from the TOML definition")
+	}
+}
@@ -0,0 +1,74 @@
+package jsonschema
+
+import (
+	"fmt"
+)
+
+// ParseJSONC parses JSON with comments (JSONC): standard JSON plus "//" and "/* */"
+// comments and trailing commas. Unlike JSON5, unquoted keys, single-quoted strings
+// and other JSON5-only syntax are rejected.
+func ParseJSONC(content []byte) (interface{}, error) {
+	stripped, err := stripJSONC(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JSONC: %w", err)
+	}
+
+	result, err := ParseJSON(stripped)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JSONC: %w", err)
+	}
+	return result, nil
+}
+
+// stripJSONC blanks out comments and trailing commas with spaces, leaving strings
+// untouched. Byte offsets and line breaks are preserved, so errors from the JSON
+// decoder still point at the original content.
+func stripJSONC(content []byte) ([]byte, error) {
+	out := make([]byte, len(content))
+	copy(out, content)
+
+	// lastComma is the offset of a comma that may turn out to be trailing
+	lastComma := -1
+	for i := 0; i < len(out); i++ {
+		switch c := out[i]; {
+		case c == '"':
+			lastComma = -1
+			for i++; i < len(out) && out[i] != '"'; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			start := i
+			out[i], out[i+1] = ' ', ' '
+			for i += 2; ; i++ {
+				if i+1 >= len(out) {
+					return nil, fmt.Errorf("unterminated block comment at offset %d", start)
+				}
+				if out[i] == '*' && out[i+1] == '/' {
+					out[i], out[i+1] = ' ', ' '
+					i++
+					break
+				}
+				if out[i] != '\n' && out[i] != '\r' {
+					out[i] = ' '
+				}
+			}
+		case c == ',':
+			lastComma = i
+		case c == '}' || c == ']':
+			if lastComma >= 0 {
+				out[lastComma] = ' '
+			}
+			lastComma = -1
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			lastComma = -1
+		}
+	}
+	return out, nil
+}
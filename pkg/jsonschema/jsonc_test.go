@@ -0,0 +1,78 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseJSONC(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      interface{}
+		errorContains string
+	}{
+		{
+			name:     "plain JSON",
+			input:    `{"a": 1}`,
+			expected: map[string]interface{}{"a": float64(1)},
+		},
+		{
+			name: "line and block comments",
+			input: `{
+	// leading comment
+	"a": 1, /* inline */ "b": [true /* in array */]
+}`,
+			expected: map[string]interface{}{"a": float64(1), "b": []interface{}{true}},
+		},
+		{
+			name:     "trailing commas",
+			input:    `{"a": [1, 2, ], "b": {"c": 3, /* comment */ },}`,
+			expected: map[string]interface{}{"a": []interface{}{float64(1), float64(2)}, "b": map[string]interface{}{"c": float64(3)}},
+		},
+		{
+			name:     "comment markers inside strings are kept",
+			input:    `{"url": "https://example.com/*x*/", "s": "a,]\"//"}`,
+			expected: map[string]interface{}{"url": "https://example.com/*x*/", "s": "a,]\"//"},
+		},
+		{
+			name:          "unquoted key",
+			input:         `{a: 1}`,
+			errorContains: "parsing JSONC",
+		},
+		{
+			name:          "single-quoted string",
+			input:         `{"a": 'b'}`,
+			errorContains: "parsing JSONC",
+		},
+		{
+			name:          "leading comma is not a trailing comma",
+			input:         `[, 1]`,
+			errorContains: "parsing JSONC",
+		},
+		{
+			name:          "unterminated block comment",
+			input:         `{"a": 1} /* open`,
+			errorContains: "unterminated block comment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseJSONC([]byte(tt.input))
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseJSONC() error = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseJSONC() = %#v, want %#v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package jsonschema
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// JSONLRecord is one line of a newline-delimited JSON (JSONL) file
+type JSONLRecord struct {
+	Line int         // 1-based line number in the file
+	Data interface{} // Parsed value; nil when Err is set
+	Err  error       // Parse error for a malformed line
+}
+
+// ScanJSONL reads r one line at a time and calls fn for every non-blank line.
+// A malformed line is passed to fn with Err set instead of stopping the scan;
+// scanning stops early only if fn returns an error, which ScanJSONL returns.
+func ScanJSONL(r io.Reader, fn func(JSONLRecord) error) error {
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		raw, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("reading line %d: %w", line, readErr)
+		}
+
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 {
+			record := JSONLRecord{Line: line}
+			record.Data, record.Err = ParseJSON(trimmed)
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// ParseJSONL parses a JSONL file into an array with one element per non-blank line.
+// Unlike ValidateJSONL it fails on the first malformed line.
+func ParseJSONL(data []byte) (interface{}, error) {
+	records := []interface{}{}
+	err := ScanJSONL(bytes.NewReader(data), func(record JSONLRecord) error {
+		if record.Err != nil {
+			return fmt.Errorf("line %d: %w", record.Line, record.Err)
+		}
+		records = append(records, record.Data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ValidateJSONL validates every line of r independently against a compiled schema.
+// Malformed lines and validation failures are both reported, labeled with their Line,
+// and validation continues with the next line. It returns the number of records read;
+// the error is only set when r itself cannot be read.
+func ValidateJSONL(r io.Reader, schema *jsonschema.Schema) (int, []ValidationErrorDetail, error) {
+	return ValidateJSONLWithOptions(r, schema, ErrorOptions{})
+}
+
+// ValidateJSONLWithOptions is ValidateJSONL with extraction options
+func ValidateJSONLWithOptions(r io.Reader, schema *jsonschema.Schema, opts ErrorOptions) (int, []ValidationErrorDetail, error) {
+	var (
+		count   int
+		details []ValidationErrorDetail
+	)
+	err := ScanJSONL(r, func(record JSONLRecord) error {
+		count++
+		if record.Err != nil {
+			details = append(details, ValidationErrorDetail{
+				Message: fmt.Sprintf("invalid JSON: %v", record.Err),
+				Line:    record.Line,
+			})
+			return nil
+		}
+
+		if err := schema.Validate(record.Data); err != nil {
+			lineErrors := ExtractValidationErrorsWithOptions(err, record.Data, opts)
+			for i := range lineErrors {
+				lineErrors[i].Line = record.Line
+			}
+			details = append(details, lineErrors...)
+		}
+		return nil
+	})
+	return count, details, err
+}
+
+// FormatJSONLValidationError formats the per-line errors returned by ValidateJSONL using the
+// provided template. FullMessage lists every error prefixed with its line number, up to
+// opts.MaxErrors like FormatValidationErrorWithOptions; ErrorCount is always the total.
+func FormatJSONLValidationError(details []ValidationErrorDetail, schemaPath, document, errorTemplate string, opts ErrorOptions) error {
+	if len(details) == 0 {
+		return nil
+	}
+
+	lines := make(map[int]bool)
+	for _, detail := range details {
+		lines[detail.Line] = true
+	}
+
+	shown, omitted := limitErrors(details, opts.MaxErrors)
+	errorLines := make([]string, 0, len(shown))
+	for _, detail := range shown {
+		if detail.SchemaPath == "" {
+			// Malformed line: there is no document path to point at
+			errorLines = append(errorLines, fmt.Sprintf("- line %d: %s", detail.Line, detail.Message))
+			continue
+		}
+		message := extractCleanMessage(detail.Message, detail.DocumentPath)
+		errorLines = append(errorLines, fmt.Sprintf("- line %d at '%s': %s", detail.Line, detail.DocumentPath, message))
+	}
+
+	ctx := ErrorContext{
+		SchemaFile:  schemaPath,
+		Document:    documentContext(document, nil, opts),
+		Errors:      shown,
+		ErrorCount:  len(details),
+		FullMessage: fmt.Sprintf("jsonschema validation failed with '%s' on %d line(s)\n%s", schemaPath, len(lines), strings.Join(errorLines, "\n")),
+	}
+
+	return executeErrorTemplate(errorTemplate, ctx, omitted)
+}
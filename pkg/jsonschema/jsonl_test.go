@@ -0,0 +1,93 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func compileTestSchema(t *testing.T, schemaJSON string) *jsonschema.Schema {
+	t.Helper()
+	schemaData, err := ParseJSON([]byte(schemaJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("schema.json", schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestParseJSONL(t *testing.T) {
+	got, err := ParseJSONL([]byte("{\"id\": 1}\n\n  [1, 2]  \n\"last\""))
+	if err != nil {
+		t.Fatalf("ParseJSONL() error = %v", err)
+	}
+	want := []interface{}{
+		map[string]interface{}{"id": float64(1)},
+		[]interface{}{float64(1), float64(2)},
+		"last",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseJSONL() = %#v, want %#v", got, want)
+	}
+
+	if _, err := ParseJSONL([]byte("{\"id\": 1}\n{broken\n")); err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("expected error for line 2, got %v", err)
+	}
+}
+
+func TestValidateJSONL(t *testing.T) {
+	schema := compileTestSchema(t, `{"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}}`)
+
+	input := strings.Join([]string{
+		`{"id": 1}`,
+		`{"id": "two"}`,
+		``,
+		`{not json`,
+		`{}`,
+		`{"id": 6}`,
+	}, "\n")
+
+	count, details, err := ValidateJSONL(strings.NewReader(input), schema)
+	if err != nil {
+		t.Fatalf("ValidateJSONL() error = %v", err)
+	}
+	if count != 5 {
+		t.Errorf("count = %d, want 5 (blank lines are skipped)", count)
+	}
+
+	// A malformed line is reported and validation continues with the next one
+	wantLines := []int{2, 4, 5}
+	if len(details) != len(wantLines) {
+		t.Fatalf("got %d errors, want %d: %+v", len(details), len(wantLines), details)
+	}
+	for i, line := range wantLines {
+		if details[i].Line != line {
+			t.Errorf("errors[%d].Line = %d, want %d", i, details[i].Line, line)
+		}
+	}
+	if details[0].DocumentPath != "/id" || details[0].Value != `"two"` {
+		t.Errorf("unexpected detail for line 2: %+v", details[0])
+	}
+	if !strings.HasPrefix(details[1].Message, "invalid JSON:") {
+		t.Errorf("expected invalid JSON message for line 4, got %q", details[1].Message)
+	}
+
+	err = FormatJSONLValidationError(details, "events.schema.json", "events.jsonl", "{{.FullMessage}}", ErrorOptions{})
+	if err == nil {
+		t.Fatal("expected formatted error")
+	}
+	for _, want := range []string{"on 3 line(s)", "- line 2 at '/id':", "- line 4: invalid JSON:", "- line 5 at '':"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("formatted error missing %q:\n%s", want, err.Error())
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package jsonschema
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report.
+// Each schema becomes a <testsuite> and each validated document a <testcase>.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+
+	suiteIndex map[string]int
+	total      time.Duration
+}
+
+// JUnitTestSuite groups the documents validated against one schema
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+
+	total time.Duration
+}
+
+// JUnitTestCase is a single validated document
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitProblem `xml:"failure,omitempty"`
+	Error     *JUnitProblem `xml:"error,omitempty"`
+}
+
+// JUnitProblem is the body of a <failure> (document invalid) or <error> (document not validated)
+type JUnitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// NewJUnitTestSuites creates an empty JUnit report
+func NewJUnitTestSuites() *JUnitTestSuites {
+	return &JUnitTestSuites{
+		Suites:     []JUnitTestSuite{},
+		suiteIndex: make(map[string]int),
+		Time:       formatJUnitTime(0),
+	}
+}
+
+// AddPass records a document that validated successfully
+func (r *JUnitTestSuites) AddPass(schemaFile, document string, elapsed time.Duration) {
+	r.addCase(schemaFile, JUnitTestCase{Name: document}, elapsed)
+}
+
+// AddFailure records a document that failed validation; body is the formatted error message
+func (r *JUnitTestSuites) AddFailure(schemaFile, document string, elapsed time.Duration, errorCount int, body string) {
+	r.addCase(schemaFile, JUnitTestCase{
+		Name: document,
+		Failure: &JUnitProblem{
+			Message: fmt.Sprintf("%d validation error(s)", errorCount),
+			Type:    "ValidationError",
+			Body:    body,
+		},
+	}, elapsed)
+}
+
+// AddError records a document that could not be validated at all
+// (unreadable or unparseable file, or a glob pattern that matched nothing)
+func (r *JUnitTestSuites) AddError(schemaFile, document string, elapsed time.Duration, errType, message string) {
+	r.addCase(schemaFile, JUnitTestCase{
+		Name: document,
+		Error: &JUnitProblem{
+			Message: message,
+			Type:    errType,
+			Body:    message,
+		},
+	}, elapsed)
+}
+
+func (r *JUnitTestSuites) addCase(schemaFile string, tc JUnitTestCase, elapsed time.Duration) {
+	idx, ok := r.suiteIndex[schemaFile]
+	if !ok {
+		r.Suites = append(r.Suites, JUnitTestSuite{Name: schemaFile, TestCases: []JUnitTestCase{}})
+		idx = len(r.Suites) - 1
+		r.suiteIndex[schemaFile] = idx
+	}
+	suite := &r.Suites[idx]
+
+	tc.ClassName = schemaFile
+	tc.Time = formatJUnitTime(elapsed)
+	suite.TestCases = append(suite.TestCases, tc)
+
+	suite.Tests++
+	r.Tests++
+	if tc.Failure != nil {
+		suite.Failures++
+		r.Failures++
+	}
+	if tc.Error != nil {
+		suite.Errors++
+		r.Errors++
+	}
+
+	suite.total += elapsed
+	suite.Time = formatJUnitTime(suite.total)
+	r.total += elapsed
+	r.Time = formatJUnitTime(r.total)
+}
+
+// Marshal renders the report as indented XML with an XML declaration
+func (r *JUnitTestSuites) Marshal() ([]byte, error) {
+	body, err := xml.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding JUnit XML: %w", err)
+	}
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
+
+// formatJUnitTime renders a duration in seconds, as JUnit consumers expect
+func formatJUnitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
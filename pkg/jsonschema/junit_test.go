@@ -0,0 +1,82 @@
+package jsonschema
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJUnitTestSuites_AddCases(t *testing.T) {
+	report := NewJUnitTestSuites()
+
+	report.AddPass("schemas/config.schema.json", "configs/a.json", 1500*time.Millisecond)
+	report.AddFailure("schemas/config.schema.json", "configs/b.json", 250*time.Millisecond, 2, "document \"configs/b.json\": at '/port': got string, want integer")
+	report.AddError("schemas/other.schema.json", "configs/*.yaml", 0, "NoMatches", "glob pattern \"configs/*.yaml\" matched no documents")
+
+	if report.Tests != 3 || report.Failures != 1 || report.Errors != 1 {
+		t.Errorf("totals = tests:%d failures:%d errors:%d, want 3/1/1", report.Tests, report.Failures, report.Errors)
+	}
+	if report.Time != "1.750" {
+		t.Errorf("total time = %q, want %q", report.Time, "1.750")
+	}
+
+	if len(report.Suites) != 2 {
+		t.Fatalf("expected one testsuite per schema (2), got %d", len(report.Suites))
+	}
+
+	suite := report.Suites[0]
+	if suite.Name != "schemas/config.schema.json" || suite.Tests != 2 || suite.Failures != 1 || suite.Errors != 0 {
+		t.Errorf("unexpected first suite: %+v", suite)
+	}
+	if suite.TestCases[0].Time != "1.500" || suite.TestCases[1].Time != "0.250" {
+		t.Errorf("per-document times = %q, %q", suite.TestCases[0].Time, suite.TestCases[1].Time)
+	}
+	if suite.TestCases[0].Failure != nil || suite.TestCases[0].Error != nil {
+		t.Errorf("passing testcase must have no failure or error")
+	}
+	if f := suite.TestCases[1].Failure; f == nil || f.Message != "2 validation error(s)" || !strings.Contains(f.Body, "/port") {
+		t.Errorf("unexpected failure: %+v", f)
+	}
+
+	if e := report.Suites[1].TestCases[0].Error; e == nil || e.Type != "NoMatches" {
+		t.Errorf("unmatched glob must be reported as an error testcase, got %+v", e)
+	}
+}
+
+func TestJUnitTestSuites_Marshal(t *testing.T) {
+	report := NewJUnitTestSuites()
+	report.AddFailure("schema.json", "doc.json", time.Millisecond, 1, "value <must> be & \"quoted\"")
+
+	out, err := report.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if !strings.HasPrefix(string(out), xml.Header) {
+		t.Errorf("output must start with an XML declaration, got: %s", out)
+	}
+
+	var decoded struct {
+		XMLName xml.Name `xml:"testsuites"`
+		Suites  []struct {
+			Name  string `xml:"name,attr"`
+			Cases []struct {
+				Name    string `xml:"name,attr"`
+				Failure struct {
+					Body string `xml:",chardata"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, out)
+	}
+
+	if len(decoded.Suites) != 1 || len(decoded.Suites[0].Cases) != 1 {
+		t.Fatalf("unexpected structure: %s", out)
+	}
+	if got := decoded.Suites[0].Cases[0].Failure.Body; got != "value <must> be & \"quoted\"" {
+		t.Errorf("failure body was not escaped round-trip: %q", got)
+	}
+}
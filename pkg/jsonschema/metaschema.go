@@ -0,0 +1,167 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/message"
+)
+
+// ValidateAgainstMetaSchema validates schemaData, a parsed schema document, against the
+// meta-schema of the draft its "$schema" names, or of defaultDraft (Draft2020 when nil)
+// when it has no "$schema". A "$schema" naming a custom meta-schema is left to the
+// compiler, which fetches and checks it.
+//
+// It returns nil when the schema is valid. Otherwise the validation error locates each
+// violation at its path in the schema document, so it formats like any document
+// validation error. A failed alternative of a keyword (e.g. "type" is neither a type
+// name nor an array) is reported once, as "'type' must be ... or an array".
+func ValidateAgainstMetaSchema(schemaData interface{}, defaultDraft *jsonschema.Draft) error {
+	draft := defaultDraft
+	if draft == nil {
+		draft = jsonschema.Draft2020
+	}
+	if object, ok := schemaData.(map[string]interface{}); ok {
+		if schemaURL, ok := object["$schema"].(string); ok {
+			if draft = knownDraft(schemaURL); draft == nil {
+				return nil
+			}
+		}
+	}
+
+	metaSchema, err := jsonschema.NewCompiler().Compile(draft.String())
+	if err != nil {
+		return fmt.Errorf("failed to load meta-schema %q: %w", draft.String(), err)
+	}
+	err = metaSchema.Validate(schemaData)
+	if validationErr, ok := err.(*jsonschema.ValidationError); ok {
+		return simplifyAlternatives(validationErr)
+	}
+	return err
+}
+
+// knownDraft returns the draft whose meta-schema URL is schemaURL, ignoring an empty
+// fragment and the http/https scheme, or nil for any other URL
+func knownDraft(schemaURL string) *jsonschema.Draft {
+	normalize := func(url string) string {
+		url = strings.TrimSuffix(url, "#")
+		return strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	}
+	for _, draft := range []*jsonschema.Draft{jsonschema.Draft4, jsonschema.Draft6, jsonschema.Draft7, jsonschema.Draft2019, jsonschema.Draft2020} {
+		if normalize(draft.String()) == normalize(schemaURL) {
+			return draft
+		}
+	}
+	return nil
+}
+
+// simplifyAlternatives returns a copy of err in which every failed anyOf/oneOf whose
+// branches all failed on a type or enum at the same location becomes a single error
+// listing what the value may be
+func simplifyAlternatives(err *jsonschema.ValidationError) *jsonschema.ValidationError {
+	if alternatives := describeAlternatives(err); alternatives != nil {
+		keyword := "value"
+		if n := len(err.InstanceLocation); n > 0 {
+			keyword = "'" + err.InstanceLocation[n-1] + "'"
+		}
+		simplified := *err
+		simplified.ErrorKind = &metaSchemaAlternatives{
+			keywordPath:  err.ErrorKind.KeywordPath(),
+			keyword:      keyword,
+			alternatives: alternatives,
+		}
+		simplified.Causes = nil
+		return &simplified
+	}
+
+	simplified := *err
+	simplified.Causes = make([]*jsonschema.ValidationError, len(err.Causes))
+	for i, cause := range err.Causes {
+		simplified.Causes[i] = simplifyAlternatives(cause)
+	}
+	return &simplified
+}
+
+// describeAlternatives describes each failed branch of an anyOf/oneOf error, e.g.
+// "one of 'array', 'string'" or "an array", or returns nil if a branch failed on
+// anything but a type or enum of the value itself
+func describeAlternatives(err *jsonschema.ValidationError) []string {
+	switch err.ErrorKind.(type) {
+	case *kind.AnyOf, *kind.OneOf:
+	default:
+		return nil
+	}
+	if len(err.Causes) < 2 {
+		return nil
+	}
+
+	location := joinJSONPointer(err.InstanceLocation)
+	var alternatives []string
+	for _, cause := range err.Causes {
+		// A branch that is a $ref fails through one cause per reference
+		for len(cause.Causes) == 1 {
+			if _, ok := cause.ErrorKind.(*kind.Reference); !ok {
+				break
+			}
+			cause = cause.Causes[0]
+		}
+		if len(cause.Causes) > 0 || joinJSONPointer(cause.InstanceLocation) != location {
+			return nil
+		}
+		switch k := cause.ErrorKind.(type) {
+		case *kind.Type:
+			for _, want := range k.Want {
+				alternatives = append(alternatives, withArticle(want))
+			}
+		case *kind.Enum:
+			values := make([]string, len(k.Want))
+			for i, value := range k.Want {
+				values[i] = displayValue(value)
+			}
+			alternatives = append(alternatives, "one of "+strings.Join(values, ", "))
+		default:
+			return nil
+		}
+	}
+	return alternatives
+}
+
+// withArticle prefixes a JSON type name with "a" or "an"
+func withArticle(typeName string) string {
+	if strings.ContainsAny(typeName[:1], "aeiou") {
+		return "an " + typeName
+	}
+	return "a " + typeName
+}
+
+// displayValue quotes strings with single quotes, as the library's messages do, and
+// writes other values as JSON
+func displayValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return "'" + s + "'"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// metaSchemaAlternatives reports a schema keyword whose value matches none of the
+// alternatives its meta-schema allows
+type metaSchemaAlternatives struct {
+	keywordPath  []string
+	keyword      string
+	alternatives []string
+}
+
+func (k *metaSchemaAlternatives) KeywordPath() []string {
+	return k.keywordPath
+}
+
+func (k *metaSchemaAlternatives) LocalizedString(p *message.Printer) string {
+	return p.Sprintf("%s must be %s", k.keyword, strings.Join(k.alternatives, " or "))
+}
@@ -0,0 +1,68 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestValidateAgainstMetaSchema(t *testing.T) {
+	tests := []struct {
+		name         string
+		schema       string
+		defaultDraft *jsonschema.Draft
+		want         []string // "<document path> <schema path>: <message>" of each error
+	}{
+		{
+			name:   "valid schema",
+			schema: `{"type": "object", "properties": {"port": {"type": ["integer", "string"]}}}`,
+		},
+		{
+			name:   "invalid keyword values",
+			schema: `{"type": "object", "properties": {"port": {"type": 5, "minimum": "1"}}, "required": "name"}`,
+			want: []string{
+				"/properties/port/minimum https://json-schema.org/draft/2020-12/meta/validation#/properties/minimum/type: at '/properties/port/minimum': got string, want number",
+				"/properties/port/type https://json-schema.org/draft/2020-12/meta/validation#/properties/type/anyOf: at '/properties/port/type': 'type' must be one of 'array', 'boolean', 'integer', 'null', 'number', 'object', 'string' or an array",
+				"/required https://json-schema.org/draft/2020-12/meta/validation#/$defs/stringArray/type: at '/required': got string, want array",
+			},
+		},
+		{
+			name:   "draft from $schema",
+			schema: `{"$schema": "http://json-schema.org/draft-04/schema#", "minimum": 1, "exclusiveMinimum": 5}`,
+			want: []string{
+				"/exclusiveMinimum http://json-schema.org/draft-04/schema#/properties/exclusiveMinimum/type: at '/exclusiveMinimum': got number, want boolean",
+			},
+		},
+		{
+			name:         "default draft",
+			schema:       `{"minimum": 1, "exclusiveMinimum": true}`,
+			defaultDraft: jsonschema.Draft4,
+		},
+		{
+			name:   "custom meta-schema is left to the compiler",
+			schema: `{"$schema": "https://example.com/meta", "type": 5}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaData, err := ParseJSON([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			if err := ValidateAgainstMetaSchema(schemaData, tt.defaultDraft); err != nil {
+				for _, detail := range ExtractValidationErrors(err, schemaData) {
+					got = append(got, detail.DocumentPath+" "+detail.SchemaPath+": "+detail.Message)
+				}
+				if got == nil {
+					t.Fatalf("ValidateAgainstMetaSchema() = %v, want a validation error", err)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateAgainstMetaSchema() errors =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
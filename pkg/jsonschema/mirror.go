@@ -0,0 +1,82 @@
+package jsonschema
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// MirrorPatternSuffix marks a ref_overrides key as a URL prefix pattern, e.g.
+// "https://schemas.example.com/**", whose value is a local base directory
+const MirrorPatternSuffix = "**"
+
+// IsMirrorPattern reports whether a ref_overrides key is a prefix pattern
+func IsMirrorPattern(key string) bool {
+	return strings.HasSuffix(key, MirrorPatternSuffix)
+}
+
+// MirrorLoader serves remote URLs that start with a mirrored prefix from a local
+// directory: "https://schemas.example.com/**" -> "./mirror" loads
+// https://schemas.example.com/a/b.json from ./mirror/a/b.json. Other URLs are
+// passed to Next.
+type MirrorLoader struct {
+	Prefixes map[string]string    // URL prefix (without the "**") -> local base directory
+	Next     jsonschema.URLLoader // Loader for URLs outside every prefix; may be nil
+}
+
+// NewMirrorLoader builds a MirrorLoader from ref_overrides-style patterns
+func NewMirrorLoader(patterns map[string]string, next jsonschema.URLLoader) *MirrorLoader {
+	prefixes := make(map[string]string, len(patterns))
+	for pattern, dir := range patterns {
+		prefixes[strings.TrimSuffix(pattern, MirrorPatternSuffix)] = dir
+	}
+	return &MirrorLoader{Prefixes: prefixes, Next: next}
+}
+
+// Load implements jsonschema.URLLoader. The longest matching prefix wins.
+func (l *MirrorLoader) Load(rawURL string) (interface{}, error) {
+	var prefix string
+	for p := range l.Prefixes {
+		if strings.HasPrefix(rawURL, p) && len(p) > len(prefix) {
+			prefix = p
+		}
+	}
+	if prefix == "" {
+		if l.Next == nil {
+			return nil, fmt.Errorf("no URLLoader registered for %q", rawURL)
+		}
+		return l.Next.Load(rawURL)
+	}
+
+	dir := l.Prefixes[prefix]
+	path, err := mirrorPath(dir, strings.TrimPrefix(rawURL, prefix))
+	if err != nil {
+		return nil, fmt.Errorf("ref_override %q: %w", prefix+MirrorPatternSuffix, err)
+	}
+
+	data, err := ParseFile(path, FileTypeAuto)
+	if err != nil {
+		return nil, fmt.Errorf("ref_override %q: failed to load %q for URL %q: %w", prefix+MirrorPatternSuffix, path, rawURL, err)
+	}
+	return data, nil
+}
+
+// mirrorPath maps the URL path below a prefix onto dir, refusing paths that escape it
+func mirrorPath(dir, rest string) (string, error) {
+	if i := strings.IndexAny(rest, "?#"); i >= 0 {
+		rest = rest[:i]
+	}
+	rest, err := url.PathUnescape(rest)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL path %q: %w", rest, err)
+	}
+
+	rel := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(rest, "/")))
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return "", fmt.Errorf("URL path %q does not name a file under %q", rest, dir)
+	}
+	return filepath.Join(dir, rel), nil
+}
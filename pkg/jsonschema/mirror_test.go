@@ -0,0 +1,75 @@
+package jsonschema
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type stubLoader struct{ loaded []string }
+
+func (s *stubLoader) Load(url string) (interface{}, error) {
+	s.loaded = append(s.loaded, url)
+	return map[string]interface{}{"from": "next"}, nil
+}
+
+func TestMirrorLoader(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"all/a.json":          `{"from": "all"}`,
+		"v2/a.json":           `{"from": "v2"}`,
+		"all/with space.json": `{"from": "escaped"}`,
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	next := &stubLoader{}
+	loader := NewMirrorLoader(map[string]string{
+		"https://schemas.example.com/**":    filepath.Join(root, "all"),
+		"https://schemas.example.com/v2/**": filepath.Join(root, "v2"),
+	}, next)
+
+	tests := []struct {
+		name          string
+		url           string
+		want          interface{}
+		errorContains string
+	}{
+		{name: "prefix match", url: "https://schemas.example.com/a.json", want: map[string]interface{}{"from": "all"}},
+		{name: "longest prefix wins", url: "https://schemas.example.com/v2/a.json", want: map[string]interface{}{"from": "v2"}},
+		{name: "escaped path", url: "https://schemas.example.com/with%20space.json", want: map[string]interface{}{"from": "escaped"}},
+		{name: "other hosts go to next", url: "https://other.example.com/a.json", want: map[string]interface{}{"from": "next"}},
+		{name: "path traversal", url: "https://schemas.example.com/../v2/a.json", errorContains: "does not name a file"},
+		{name: "missing file", url: "https://schemas.example.com/missing.json", errorContains: "failed to load"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loader.Load(tt.url)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	if !reflect.DeepEqual(next.loaded, []string{"https://other.example.com/a.json"}) {
+		t.Errorf("next loader received %v", next.loaded)
+	}
+}
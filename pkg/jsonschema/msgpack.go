@@ -0,0 +1,161 @@
+package jsonschema
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ParseMsgpack parses a MessagePack document into the same values JSON decodes to.
+// Integers become int64 (uint64 above its range), timestamps RFC 3339 strings and
+// integer map keys their decimal form; binary values and NaN/Inf, which JSON cannot
+// represent, are rejected with the JSON Pointer of the value.
+func ParseMsgpack(data []byte) (interface{}, error) {
+	reader := bytes.NewReader(data)
+	decoder := msgpack.NewDecoder(reader)
+	decoder.SetMapDecoder(decodeMsgpackMap)
+
+	result, err := decoder.DecodeInterface()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing MessagePack: unexpected end of data")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing MessagePack: %w", err)
+	}
+	if reader.Len() > 0 {
+		return nil, fmt.Errorf("parsing MessagePack: %d byte(s) of unexpected data after the document", reader.Len())
+	}
+
+	normalized, err := normalizeBinary(result, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parsing MessagePack: %w", err)
+	}
+	return normalized, nil
+}
+
+// decodeMsgpackMap decodes a map keeping its keys as decoded; the default decoder
+// requires string keys. Keys that cannot be hashed (binary, arrays, maps) are rejected
+// here, before normalizeBinary sees them.
+func decodeMsgpackMap(d *msgpack.Decoder) (interface{}, error) {
+	n, err := d.DecodeMapLen()
+	if err != nil || n == -1 {
+		return nil, err
+	}
+	m := make(map[interface{}]interface{}, n)
+	for i := 0; i < n; i++ {
+		key, err := d.DecodeInterface()
+		if err != nil {
+			return nil, err
+		}
+		if !isScalarKey(key) {
+			return nil, fmt.Errorf("map key of type %T cannot be represented in JSON", key)
+		}
+		value, err := d.DecodeInterface()
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := m[key]; dup {
+			return nil, fmt.Errorf("duplicate map key %v", key)
+		}
+		m[key] = value
+	}
+	return m, nil
+}
+
+// isScalarKey reports whether a decoded map key has a JSON object key form
+func isScalarKey(key interface{}) bool {
+	switch key.(type) {
+	case string, bool, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+		return true
+	}
+	return false
+}
+
+// normalizeBinary converts a value decoded from MessagePack or CBOR into JSON-compatible
+// data. path holds the tokens leading to value, for the JSON Pointer in errors.
+func normalizeBinary(value interface{}, path []string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			name := fmt.Sprint(key)
+			if !isScalarKey(key) {
+				return nil, fmt.Errorf("map key of type %T at %q cannot be represented in JSON", key, joinJSONPointer(path))
+			}
+			if _, dup := out[name]; dup {
+				return nil, fmt.Errorf("duplicate map key %q at %q", name, joinJSONPointer(path))
+			}
+			normalized, err := normalizeBinary(child, append(path[:len(path):len(path)], name))
+			if err != nil {
+				return nil, err
+			}
+			out[name] = normalized
+		}
+		return out, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			normalized, err := normalizeBinary(child, append(path[:len(path):len(path)], key))
+			if err != nil {
+				return nil, err
+			}
+			out[key] = normalized
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			normalized, err := normalizeBinary(child, append(path[:len(path):len(path)], strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = normalized
+		}
+		return out, nil
+	case []byte:
+		return nil, fmt.Errorf("binary value (%d byte(s)) at %q cannot be represented in JSON", len(v), joinJSONPointer(path))
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano), nil
+	case float32:
+		return normalizeFloat(float64(v), path)
+	case float64:
+		return normalizeFloat(v, path)
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v), nil
+		}
+		return v, nil
+	case nil, bool, string, int64:
+		return v, nil
+	case cborUndefined:
+		return nil, fmt.Errorf("undefined value at %q cannot be represented in JSON", joinJSONPointer(path))
+	default:
+		return nil, fmt.Errorf("value of type %T at %q cannot be represented in JSON", value, joinJSONPointer(path))
+	}
+}
+
+// normalizeFloat rejects the floating-point values JSON has no spelling for
+func normalizeFloat(f float64, path []string) (interface{}, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("number %v at %q cannot be represented in JSON", f, joinJSONPointer(path))
+	}
+	return f, nil
+}
@@ -0,0 +1,149 @@
+package jsonschema
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func mustMarshalMsgpack(t *testing.T, value interface{}) []byte {
+	t.Helper()
+	data, err := msgpack.Marshal(value)
+	if err != nil {
+		t.Fatalf("msgpack.Marshal() error = %v", err)
+	}
+	return data
+}
+
+func TestParseMsgpack(t *testing.T) {
+	data := mustMarshalMsgpack(t, map[string]interface{}{
+		"name":    "api",
+		"port":    uint16(8080),
+		"offset":  int8(-3),
+		"ratio":   float32(0.5),
+		"enabled": true,
+		"tags":    []string{"a", "b"},
+		"owner":   nil,
+		"created": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"codes":   map[int]string{1: "one"},
+	})
+
+	got, err := ParseMsgpack(data)
+	if err != nil {
+		t.Fatalf("ParseMsgpack() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"name":    "api",
+		"port":    int64(8080),
+		"offset":  int64(-3),
+		"ratio":   float64(0.5),
+		"enabled": true,
+		"tags":    []interface{}{"a", "b"},
+		"owner":   nil,
+		"created": "2024-01-02T03:04:05Z",
+		"codes":   map[string]interface{}{"1": "one"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMsgpack() = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseMsgpack_Validates(t *testing.T) {
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("schema.json", map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"port"},
+		"properties": map[string]interface{}{
+			"port": map[string]interface{}{"type": "integer", "maximum": 65535},
+			"big":  map[string]interface{}{"type": "integer", "minimum": 0},
+		},
+	}); err != nil {
+		t.Fatalf("AddResource() error = %v", err)
+	}
+	schema, err := compiler.Compile("schema.json")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	valid, err := ParseMsgpack(mustMarshalMsgpack(t, map[string]interface{}{"port": 443, "big": uint64(math.MaxUint64)}))
+	if err != nil {
+		t.Fatalf("ParseMsgpack() error = %v", err)
+	}
+	if err := schema.Validate(valid); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	invalid, err := ParseMsgpack(mustMarshalMsgpack(t, map[string]interface{}{"port": 70000}))
+	if err != nil {
+		t.Fatalf("ParseMsgpack() error = %v", err)
+	}
+	if err := schema.Validate(invalid); err == nil {
+		t.Error("Validate() error = nil, want maximum violation")
+	}
+}
+
+func TestParseMsgpack_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{
+			name:    "binary value",
+			data:    mustMarshalMsgpack(t, map[string]interface{}{"files": []interface{}{[]byte{0xde, 0xad}}}),
+			wantErr: `binary value (2 byte(s)) at "/files/0" cannot be represented in JSON`,
+		},
+		{
+			name:    "binary map key",
+			data:    []byte{0x81, 0xc4, 0x01, 0x00, 0x01},
+			wantErr: "map key of type []uint8 cannot be represented in JSON",
+		},
+		{
+			name:    "NaN",
+			data:    mustMarshalMsgpack(t, map[string]interface{}{"ratio": math.NaN()}),
+			wantErr: `number NaN at "/ratio" cannot be represented in JSON`,
+		},
+		{
+			name:    "duplicate key",
+			data:    []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'a', 0x02},
+			wantErr: "duplicate map key a",
+		},
+		{
+			name:    "unknown extension",
+			data:    []byte{0xd4, 0x05, 0x00},
+			wantErr: "parsing MessagePack:",
+		},
+		{
+			name:    "truncated",
+			data:    []byte{0x92, 0x01},
+			wantErr: "unexpected end of data",
+		},
+		{
+			name:    "empty",
+			data:    nil,
+			wantErr: "unexpected end of data",
+		},
+		{
+			name:    "trailing data",
+			data:    []byte{0x01, 0x02},
+			wantErr: "1 byte(s) of unexpected data after the document",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMsgpack(tt.data)
+			if err == nil {
+				t.Fatal("ParseMsgpack() error = nil, want error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseMsgpack() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
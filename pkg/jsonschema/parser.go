@@ -1,11 +1,14 @@
 package jsonschema
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"mime"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pelletier/go-toml/v2"
 	"gopkg.in/yaml.v3"
@@ -15,16 +18,31 @@ import (
 type FileType string
 
 const (
-	FileTypeJSON  FileType = "json"
-	FileTypeJSON5 FileType = "json5"
-	FileTypeYAML  FileType = "yaml"
-	FileTypeTOML  FileType = "toml"
-	FileTypeAuto  FileType = "auto"
+	FileTypeJSON    FileType = "json"
+	FileTypeJSON5   FileType = "json5"
+	FileTypeJSONC   FileType = "jsonc"
+	FileTypeYAML    FileType = "yaml"
+	FileTypeTOML    FileType = "toml"
+	FileTypeJSONL   FileType = "jsonl"
+	FileTypeMsgpack FileType = "msgpack"
+	FileTypeCBOR    FileType = "cbor"
+	FileTypeAuto    FileType = "auto"
 )
 
+// ParseOptions controls optional strictness checks applied while parsing
+type ParseOptions struct {
+	// ForbidDuplicateKeys rejects JSON/JSONC/JSON5 documents whose objects repeat a key
+	ForbidDuplicateKeys bool
+}
+
 // ParseFile reads and parses a file based on its extension or forced type.
-// Supports JSON, JSON5, YAML, and TOML formats.
+// Supports JSON, JSONC, JSON5, YAML, TOML, JSONL, MessagePack and CBOR formats.
 func ParseFile(path string, forceType FileType) (interface{}, error) {
+	return ParseFileWithOptions(path, forceType, ParseOptions{})
+}
+
+// ParseFileWithOptions reads and parses a file like ParseFile, applying the given options
+func ParseFileWithOptions(path string, forceType FileType, opts ParseOptions) (interface{}, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading file: %w", err)
@@ -35,19 +53,47 @@ func ParseFile(path string, forceType FileType) (interface{}, error) {
 		fileType = DetectFileType(path)
 	}
 
+	return ParseBytes(data, fileType, opts)
+}
+
+// ParseBytes parses raw content of the given file type, applying the given options
+func ParseBytes(data []byte, fileType FileType, opts ParseOptions) (interface{}, error) {
+	var result interface{}
+	var err error
+
 	switch fileType {
 	case FileTypeJSON:
-		return ParseJSON(data)
+		result, err = ParseJSON(data)
 	case FileTypeJSON5:
-		return ParseJSON5(data)
+		result, err = ParseJSON5(data)
+	case FileTypeJSONC:
+		result, err = ParseJSONC(data)
 	case FileTypeYAML:
 		return ParseYAML(data)
 	case FileTypeTOML:
 		return ParseTOML(data)
+	case FileTypeJSONL:
+		return ParseJSONL(data)
+	case FileTypeMsgpack:
+		return ParseMsgpack(data)
+	case FileTypeCBOR:
+		return ParseCBOR(data)
 	default:
 		// Try JSON5 as fallback (most permissive)
-		return ParseJSON5(data)
+		result, err = ParseJSON5(data)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Duplicate detection only applies to the JSON family; the other parsers reject duplicates themselves
+	if opts.ForbidDuplicateKeys {
+		if err := CheckDuplicateKeys(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
 }
 
 // DetectFileType determines file type from extension.
@@ -60,38 +106,148 @@ func DetectFileType(path string) FileType {
 		return FileTypeJSON
 	case ".json5":
 		return FileTypeJSON5
+	case ".jsonc":
+		return FileTypeJSONC
 	case ".yaml", ".yml":
 		return FileTypeYAML
 	case ".toml":
 		return FileTypeTOML
+	case ".jsonl", ".ndjson":
+		return FileTypeJSONL
+	case ".msgpack", ".mpk":
+		return FileTypeMsgpack
+	case ".cbor":
+		return FileTypeCBOR
 	default:
 		return FileTypeJSON5 // Most permissive fallback
 	}
 }
 
+// FileTypeFromContentType returns the format of an HTTP Content-Type such as
+// "application/yaml; charset=utf-8", or "" when it names none (e.g. "text/plain").
+// Any "+json" media type is JSON.
+func FileTypeFromContentType(contentType string) FileType {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	switch mediaType {
+	case "application/json", "text/json":
+		return FileTypeJSON
+	case "application/json5":
+		return FileTypeJSON5
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return FileTypeYAML
+	case "application/toml":
+		return FileTypeTOML
+	case "application/jsonl", "application/x-ndjson", "application/x-jsonlines":
+		return FileTypeJSONL
+	case "application/msgpack", "application/x-msgpack", "application/vnd.msgpack":
+		return FileTypeMsgpack
+	case "application/cbor":
+		return FileTypeCBOR
+	}
+	if strings.HasSuffix(mediaType, "+json") {
+		return FileTypeJSON
+	}
+	return ""
+}
+
+// DetectContentType guesses the format of content that has no file name.
+// Content starting with "{", "[" or a comment is JSON5 (a superset of JSON); anything else is YAML.
+func DetectContentType(data []byte) FileType {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return FileTypeJSON5
+	}
+	if bytes.HasPrefix(trimmed, []byte("//")) || bytes.HasPrefix(trimmed, []byte("/*")) {
+		return FileTypeJSON5
+	}
+	return FileTypeYAML
+}
+
 // ParseJSON parses standard JSON data
 func ParseJSON(data []byte) (interface{}, error) {
 	var result interface{}
 	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("parsing JSON: %w", err)
+		return nil, fmt.Errorf("parsing JSON: %w", withPosition(err, data))
 	}
 	return result, nil
 }
 
-// ParseYAML parses YAML data
+// ParseYAML parses YAML data.
+// Anchors, aliases and merge keys ("<<: *defaults") are expanded by the decoder; the result is
+// then normalized so every mapping is a map[string]interface{} and aliased nodes are independent copies.
 func ParseYAML(data []byte) (interface{}, error) {
 	var result interface{}
 	if err := yaml.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("parsing YAML: %w", err)
 	}
-	return result, nil
+	return normalizeYAML(result), nil
+}
+
+// normalizeYAML converts decoded YAML into JSON-compatible data.
+// Mappings with non-string keys (e.g. "1: one") become map[string]interface{} with the key
+// formatted as text, and every map and slice is copied so an alias never shares state with its anchor.
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			out[key] = normalizeYAML(child)
+		}
+		return out
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			out[fmt.Sprint(key)] = normalizeYAML(child)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = normalizeYAML(child)
+		}
+		return out
+	default:
+		return value
+	}
 }
 
-// ParseTOML parses TOML data
+// ParseTOML parses TOML data. Datetimes are converted to strings so they validate
+// against "format": "date-time" (and "date" for local dates) and marshal stably.
 func ParseTOML(data []byte) (interface{}, error) {
 	var result interface{}
 	if err := toml.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("parsing TOML: %w", err)
+		return nil, fmt.Errorf("parsing TOML: %w", withPosition(err, data))
+	}
+	return normalizeTOML(result), nil
+}
+
+// normalizeTOML replaces TOML datetime values with their string form: offset
+// date-times become RFC 3339, local dates and times keep their TOML spelling
+func normalizeTOML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = normalizeTOML(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = normalizeTOML(child)
+		}
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case toml.LocalDateTime:
+		return v.String()
+	case toml.LocalDate:
+		return v.String()
+	case toml.LocalTime:
+		return v.String()
+	default:
+		return value
 	}
-	return result, nil
 }
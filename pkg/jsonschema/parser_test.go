@@ -3,7 +3,10 @@ package jsonschema
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
 )
 
 func TestDetectFileType(t *testing.T) {
@@ -14,9 +17,15 @@ func TestDetectFileType(t *testing.T) {
 	}{
 		{"JSON file", "config.json", FileTypeJSON},
 		{"JSON5 file", "config.json5", FileTypeJSON5},
+		{"JSONC file", "settings.jsonc", FileTypeJSONC},
 		{"YAML file", "config.yaml", FileTypeYAML},
 		{"YML file", "config.yml", FileTypeYAML},
 		{"TOML file", "config.toml", FileTypeTOML},
+		{"JSONL file", "events.jsonl", FileTypeJSONL},
+		{"NDJSON file", "events.ndjson", FileTypeJSONL},
+		{"MessagePack file", "payload.msgpack", FileTypeMsgpack},
+		{"MessagePack short extension", "payload.mpk", FileTypeMsgpack},
+		{"CBOR file", "payload.cbor", FileTypeCBOR},
 		{"Uppercase extension", "CONFIG.JSON", FileTypeJSON},
 		{"Mixed case YAML", "Config.YaML", FileTypeYAML},
 		{"No extension", "config", FileTypeJSON5},
@@ -34,6 +43,56 @@ func TestDetectFileType(t *testing.T) {
 	}
 }
 
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected FileType
+	}{
+		{"JSON object", `{"type": "string"}`, FileTypeJSON5},
+		{"JSON array with leading whitespace", "\n  [1, 2]", FileTypeJSON5},
+		{"JSON5 line comment", "// schema\n{type: 'string'}", FileTypeJSON5},
+		{"JSON5 block comment", "/* schema */ {type: 'string'}", FileTypeJSON5},
+		{"YAML mapping", "type: string\n", FileTypeYAML},
+		{"YAML document marker", "---\ntype: string\n", FileTypeYAML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DetectContentType([]byte(tt.content)); result != tt.expected {
+				t.Errorf("DetectContentType(%q) = %v, want %v", tt.content, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFileTypeFromContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    FileType
+	}{
+		{"application/json", FileTypeJSON},
+		{"application/json; charset=utf-8", FileTypeJSON},
+		{"application/problem+json", FileTypeJSON},
+		{"Application/YAML", FileTypeYAML},
+		{"text/yaml", FileTypeYAML},
+		{"application/toml", FileTypeTOML},
+		{"application/x-ndjson", FileTypeJSONL},
+		{"application/vnd.msgpack", FileTypeMsgpack},
+		{"application/cbor", FileTypeCBOR},
+		{"text/plain", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			if result := FileTypeFromContentType(tt.contentType); result != tt.expected {
+				t.Errorf("FileTypeFromContentType(%q) = %q, want %q", tt.contentType, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestParseJSON(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -142,6 +201,65 @@ count: 42
 	}
 }
 
+func TestParseYAML_AnchorsAndMergeKeys(t *testing.T) {
+	input := []byte(`
+defaults: &defaults
+  replicas: 2
+  image: nginx
+  labels:
+    tier: web
+primary:
+  <<: *defaults
+  replicas: 5
+secondary: *defaults
+1: numeric key
+`)
+
+	result, err := ParseYAML(input)
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+
+	root, ok := result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected map[string]interface{}, got %T", result)
+	}
+
+	primary, ok := root["primary"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected primary to be map[string]interface{}, got %T", root["primary"])
+	}
+	if primary["replicas"] != 5 || primary["image"] != "nginx" {
+		t.Errorf("merge key not applied with override: %v", primary)
+	}
+	if _, ok := primary["<<"]; ok {
+		t.Errorf("merge key left in output: %v", primary)
+	}
+
+	secondary, ok := root["secondary"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected secondary to be map[string]interface{}, got %T", root["secondary"])
+	}
+	if secondary["replicas"] != 2 || secondary["image"] != "nginx" {
+		t.Errorf("alias not expanded: %v", secondary)
+	}
+
+	// The anchor reused in two places must not share state
+	secondary["labels"].(map[string]interface{})["tier"] = "changed"
+	if got := root["defaults"].(map[string]interface{})["labels"].(map[string]interface{})["tier"]; got != "web" {
+		t.Errorf("alias shares state with its anchor: tier = %v", got)
+	}
+
+	if root["1"] != "numeric key" {
+		t.Errorf("non-string key not normalized: %v", root)
+	}
+
+	// The normalized result must be encodable as JSON
+	if _, err := MarshalDeterministic(result); err != nil {
+		t.Errorf("MarshalDeterministic() error = %v", err)
+	}
+}
+
 func TestParseTOML(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -196,6 +314,51 @@ name = test  # missing quotes
 	}
 }
 
+func TestParseTOML_Datetimes(t *testing.T) {
+	data, err := ParseTOML([]byte(`
+created = 1979-05-27T07:32:00-07:00
+precise = 1979-05-27T07:32:00.999999Z
+local = 1979-05-27T07:32:00
+day = 1979-05-27
+
+[[events]]
+at = 2024-01-02T03:04:05+01:00
+`))
+	if err != nil {
+		t.Fatalf("ParseTOML() error = %v", err)
+	}
+
+	got, err := MarshalDeterministic(data)
+	if err != nil {
+		t.Fatalf("MarshalDeterministic() error = %v", err)
+	}
+	want := `{"created":"1979-05-27T07:32:00-07:00","day":"1979-05-27","events":[{"at":"2024-01-02T03:04:05+01:00"}],"local":"1979-05-27T07:32:00","precise":"1979-05-27T07:32:00.999999Z"}`
+	if string(got) != want {
+		t.Errorf("MarshalDeterministic() = %s, want %s", got, want)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"created": map[string]interface{}{"type": "string", "format": "date-time"},
+			"precise": map[string]interface{}{"type": "string", "format": "date-time"},
+			"day":     map[string]interface{}{"type": "string", "format": "date"},
+		},
+	}
+	if err := compiler.AddResource("schema.json", schema); err != nil {
+		t.Fatal(err)
+	}
+	compiled, err := compiler.Compile("schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := compiled.Validate(data); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
 func TestParseFile(t *testing.T) {
 	// Create temporary directory for test files
 	tmpDir := t.TempDir()
@@ -336,3 +499,28 @@ func TestParseFile_ContentValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBytes_ErrorPosition(t *testing.T) {
+	tests := []struct {
+		name          string
+		fileType      FileType
+		input         string
+		errorContains string
+	}{
+		{name: "JSON", fileType: FileTypeJSON, input: "{\n  \"a\": 1,\n  \"b\": }\n", errorContains: "line 3, column 8: "},
+		{name: "JSON unexpected end", fileType: FileTypeJSON, input: "{\n  \"a\": [1,", errorContains: "line 2, column 10: "},
+		{name: "JSONC keeps original positions", fileType: FileTypeJSONC, input: "{\n  // comment\n  \"b\": }\n", errorContains: "line 3, column 8: "},
+		{name: "JSON5", fileType: FileTypeJSON5, input: "{\n  a: 1,\n  b: }\n", errorContains: "line 3, column 6: "},
+		{name: "YAML", fileType: FileTypeYAML, input: "a: 1\n b: 2\n", errorContains: "line 2: "},
+		{name: "TOML", fileType: FileTypeTOML, input: "a = 1\nb = \n", errorContains: "line 2, column 5: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBytes([]byte(tt.input), tt.fileType, ParseOptions{})
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
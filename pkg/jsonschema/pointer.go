@@ -0,0 +1,91 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseJSONPointer splits an RFC 6901 JSON Pointer into unescaped reference tokens.
+// The empty pointer refers to the whole document and yields no tokens.
+func ParseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must be empty or start with \"/\"", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		// "~1" must be decoded before "~0" so "~01" becomes "~1", not "/"
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// ResolveJSONPointer returns the value at an RFC 6901 JSON Pointer (e.g. "/servers/0/port")
+func ResolveJSONPointer(data interface{}, pointer string) (interface{}, error) {
+	tokens, err := ParseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	value, depth, ok := lookupPath(data, tokens)
+	if !ok {
+		return nil, fmt.Errorf("JSON pointer %q does not resolve: no value at %q", pointer, joinJSONPointer(tokens[:depth+1]))
+	}
+	return value, nil
+}
+
+// joinJSONPointer builds a JSON Pointer from tokens, escaping "~" and "/"
+func joinJSONPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// lookupPath navigates data along already-unescaped tokens. When a token cannot
+// be followed it returns ok = false and the index of that token.
+func lookupPath(data interface{}, tokens []string) (interface{}, int, bool) {
+	current := data
+	for i, token := range tokens {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[token]
+			if !ok {
+				return nil, i, false
+			}
+			current = next
+		case []interface{}:
+			idx, ok := arrayIndex(token, len(v))
+			if !ok {
+				return nil, i, false
+			}
+			current = v[idx]
+		default:
+			return nil, i, false
+		}
+	}
+	return current, 0, true
+}
+
+// arrayIndex parses an array index token: decimal digits without leading zeros, within bounds
+func arrayIndex(token string, length int) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	for _, c := range token {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx >= length {
+		return 0, false
+	}
+	return idx, true
+}
@@ -0,0 +1,58 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveJSONPointer(t *testing.T) {
+	doc := map[string]interface{}{
+		"config": map[string]interface{}{
+			"servers": []interface{}{
+				map[string]interface{}{"port": float64(8080)},
+			},
+		},
+		"a/b": "slash",
+		"m~n": "tilde",
+		"~1":  "literal",
+		"":    "empty key",
+	}
+
+	tests := []struct {
+		name          string
+		pointer       string
+		want          interface{}
+		errorContains string
+	}{
+		{name: "whole document", pointer: "", want: doc},
+		{name: "nested array element", pointer: "/config/servers/0/port", want: float64(8080)},
+		{name: "escaped slash", pointer: "/a~1b", want: "slash"},
+		{name: "escaped tilde", pointer: "/m~0n", want: "tilde"},
+		{name: "~01 decodes to ~1", pointer: "/~01", want: "literal"},
+		{name: "empty key", pointer: "/", want: "empty key"},
+		{name: "missing key", pointer: "/config/clients/0", errorContains: `no value at "/config/clients"`},
+		{name: "index out of range", pointer: "/config/servers/1", errorContains: `no value at "/config/servers/1"`},
+		{name: "leading zero index", pointer: "/config/servers/00", errorContains: "does not resolve"},
+		{name: "past a scalar", pointer: "/config/servers/0/port/x", errorContains: `no value at "/config/servers/0/port/x"`},
+		{name: "missing leading slash", pointer: "config", errorContains: "must be empty or start with"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveJSONPointer(doc, tt.pointer)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveJSONPointer() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveJSONPointer() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
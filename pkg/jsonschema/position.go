@@ -0,0 +1,67 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/titanous/json5"
+)
+
+// ParsePositionError is a parse error with the 1-based line and column it occurred at
+type ParsePositionError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ParsePositionError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *ParsePositionError) Unwrap() error {
+	return e.Err
+}
+
+// withPosition attaches the line and column of a JSON, JSON5 or TOML syntax error.
+// YAML errors already name their line and are returned unchanged, as is any error
+// without location information.
+func withPosition(err error, data []byte) error {
+	var (
+		jsonErr  *json.SyntaxError
+		json5Err *json5.SyntaxError
+		tomlErr  *toml.DecodeError
+	)
+	switch {
+	case errors.As(err, &jsonErr):
+		line, column := offsetPosition(data, jsonErr.Offset)
+		return &ParsePositionError{Line: line, Column: column, Err: err}
+	case errors.As(err, &json5Err):
+		line, column := offsetPosition(data, json5Err.Offset)
+		return &ParsePositionError{Line: line, Column: column, Err: err}
+	case errors.As(err, &tomlErr):
+		line, column := tomlErr.Position()
+		return &ParsePositionError{Line: line, Column: column, Err: err}
+	default:
+		return err
+	}
+}
+
+// offsetPosition converts a decoder offset ("error occurred after reading offset
+// bytes") into the 1-based line and column of the offending byte
+func offsetPosition(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	line, column := 1, 1
+	for _, c := range data[:max(offset-1, 0)] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
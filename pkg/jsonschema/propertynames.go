@@ -0,0 +1,198 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+)
+
+// propertyNameErrors reports a "propertyNames" failure once per failing keyword of the
+// name's schema, e.g. "property name 'foo bar' does not match pattern '^[a-z]+$'",
+// located at the object that has the name, with the name as the value. A name whose
+// property opts redacts is shown as RedactedValue.
+func propertyNameErrors(err *jsonschema.ValidationError, property string, documentData interface{}, opts ErrorOptions) []ValidationErrorDetail {
+	location := propertyNamesObject(err.SchemaURL, property, documentData)
+	path := formatInstanceLocation(location)
+	redacted := opts.redacts(append(location[:len(location):len(location)], property))
+	name := property
+	value, _ := json.Marshal(property)
+	if redacted {
+		name, value = RedactedValue, []byte(RedactedValue)
+	}
+
+	var errors []ValidationErrorDetail
+	for _, leaf := range leafValidationErrors(err) {
+		cause := extractCleanMessage(leaf.Error(), formatInstanceLocation(leaf.InstanceLocation))
+		if redactedCause, ok := redactedMessage(leaf.ErrorKind); ok && redacted {
+			cause = redactedCause
+		}
+		message := fmt.Sprintf("property name '%s': %s", name, cause)
+		switch k := leaf.ErrorKind.(type) {
+		case *kind.Pattern:
+			message = fmt.Sprintf("property name '%s' does not match pattern '%s'", name, k.Want)
+		case *kind.FalseSchema:
+			// "propertyNames": false allows no names at all
+			message = fmt.Sprintf("property name '%s' is not allowed", name)
+		}
+		detail := ValidationErrorDetail{
+			Message:      fmt.Sprintf("at '%s': %s", path, message),
+			DocumentPath: path,
+			SchemaPath:   keywordLocation(leaf),
+			Value:        string(value),
+			ValueType:    "string",
+			RawMessage:   leaf.Error(),
+		}
+		if redacted {
+			detail.RawMessage = detail.Message
+		}
+		errors = append(errors, detail)
+	}
+	return errors
+}
+
+// leafValidationErrors returns the errors without causes under err
+func leafValidationErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, leafValidationErrors(cause)...)
+	}
+	return leaves
+}
+
+// propertyNamesObject finds the object whose property name failed "propertyNames". The
+// validator reports name errors at the document root, so the object is found from the
+// schema location of the keyword ("...#/properties/inner/propertyNames" is the object
+// at /inner) or, when that does not lead to an object with the name, as the object in
+// the document that has it. Ties go to the lowest path.
+func propertyNamesObject(schemaURL, property string, documentData interface{}) []string {
+	var candidates [][]string
+	if steps, ok := propertyNamesSteps(schemaURL); ok {
+		candidates = objectsWithKey(documentData, steps, nil, property)
+	}
+	if len(candidates) == 0 {
+		candidates = allObjectsWithKey(documentData, nil, property)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return joinJSONPointer(candidates[i]) < joinJSONPointer(candidates[j])
+	})
+	return candidates[0]
+}
+
+// documentStep is one step from a schema to the document location it applies to:
+// a property name or array index, or any member when wildcard is set
+type documentStep struct {
+	token    string
+	wildcard bool
+}
+
+// propertyNamesSteps translates the schema location of a "propertyNames" keyword into
+// the document steps leading to the object it checks. Only locations made of
+// properties, items and the in-place applicators translate; a location under
+// "$defs", for example, does not.
+func propertyNamesSteps(schemaURL string) ([]documentStep, bool) {
+	_, fragment, found := strings.Cut(schemaURL, "#")
+	if !found {
+		return nil, false
+	}
+	fragment, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, false
+	}
+	tokens, err := ParseJSONPointer(fragment)
+	if err != nil || len(tokens) == 0 || tokens[len(tokens)-1] != "propertyNames" {
+		return nil, false
+	}
+	tokens = tokens[:len(tokens)-1]
+
+	var steps []documentStep
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "properties", "prefixItems":
+			if i+1 == len(tokens) {
+				return nil, false
+			}
+			steps = append(steps, documentStep{token: tokens[i+1]})
+			i++
+		case "items":
+			// Draft-04 to 2019-09 tuple items are followed by their index
+			if i+1 < len(tokens) {
+				if _, err := strconv.Atoi(tokens[i+1]); err == nil {
+					steps = append(steps, documentStep{token: tokens[i+1]})
+					i++
+					continue
+				}
+			}
+			steps = append(steps, documentStep{wildcard: true})
+		case "additionalProperties", "additionalItems", "unevaluatedProperties", "unevaluatedItems":
+			steps = append(steps, documentStep{wildcard: true})
+		case "patternProperties":
+			if i+1 == len(tokens) {
+				return nil, false
+			}
+			steps = append(steps, documentStep{wildcard: true})
+			i++
+		case "allOf", "anyOf", "oneOf":
+			i++ // The branch index applies to the same value
+		case "if", "then", "else", "not":
+		default:
+			return nil, false
+		}
+	}
+	return steps, true
+}
+
+// objectsWithKey returns the locations of the objects the steps lead to from data
+// that have key
+func objectsWithKey(data interface{}, steps []documentStep, location []string, key string) [][]string {
+	if len(steps) == 0 {
+		if object, ok := data.(map[string]interface{}); ok {
+			if _, ok := object[key]; ok {
+				return [][]string{location}
+			}
+		}
+		return nil
+	}
+	var found [][]string
+	forEachMember(data, func(token string, member interface{}) {
+		if steps[0].wildcard || token == steps[0].token {
+			found = append(found, objectsWithKey(member, steps[1:], append(location[:len(location):len(location)], token), key)...)
+		}
+	})
+	return found
+}
+
+// allObjectsWithKey returns the locations of every object in data that has key
+func allObjectsWithKey(data interface{}, location []string, key string) [][]string {
+	found := objectsWithKey(data, nil, location, key)
+	forEachMember(data, func(token string, member interface{}) {
+		found = append(found, allObjectsWithKey(member, append(location[:len(location):len(location)], token), key)...)
+	})
+	return found
+}
+
+// forEachMember calls fn with the key (or index) and value of every member of an
+// object or array
+func forEachMember(data interface{}, fn func(token string, member interface{})) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, member := range v {
+			fn(key, member)
+		}
+	case []interface{}:
+		for i, member := range v {
+			fn(strconv.Itoa(i), member)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestExtractValidationErrors_PropertyNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		want     []string // "<document path> <message> <value>"
+	}{
+		{
+			name:     "pattern on a nested object",
+			schema:   `{"properties": {"labels": {"propertyNames": {"pattern": "^[a-z]+$"}}}}`,
+			document: `{"labels": {"foo bar": 1, "ok": 2}}`,
+			want:     []string{`/labels property name 'foo bar' does not match pattern '^[a-z]+$' "foo bar"`},
+		},
+		{
+			name:     "several keywords and names at the root",
+			schema:   `{"propertyNames": {"pattern": "^[a-z]+$", "maxLength": 5}}`,
+			document: `{"Bad": 1, "toolong": 2, "ok": 3}`,
+			want: []string{
+				` property name 'Bad' does not match pattern '^[a-z]+$' "Bad"`,
+				` property name 'toolong': maxLength: got 7, want 5 "toolong"`,
+			},
+		},
+		{
+			name:     "objects in an array",
+			schema:   `{"items": {"propertyNames": {"enum": ["id", "name"]}}}`,
+			document: `[{"id": 1}, {"id": 2, "nmae": "x"}]`,
+			want:     []string{`/1 property name 'nmae': value must be one of 'id', 'name' "nmae"`},
+		},
+		{
+			name:     "false schema",
+			schema:   `{"properties": {"empty": {"propertyNames": false}}}`,
+			document: `{"empty": {"a": 1}}`,
+			want:     []string{`/empty property name 'a' is not allowed "a"`},
+		},
+		{
+			name:     "schema under $defs",
+			schema:   `{"properties": {"env": {"$ref": "#/$defs/env"}}, "$defs": {"env": {"propertyNames": {"pattern": "^[A-Z_]+$"}}}}`,
+			document: `{"env": {"PATH": "/bin", "home": "/root"}}`,
+			want:     []string{`/env property name 'home' does not match pattern '^[A-Z_]+$' "home"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaData, err := ParseJSON([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			compiler := jsonschema.NewCompiler()
+			if err := compiler.AddResource("names.schema.json", schemaData); err != nil {
+				t.Fatal(err)
+			}
+			schema, err := compiler.Compile("names.schema.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc, err := ParseJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, e := range ExtractValidationErrors(schema.Validate(doc), doc) {
+				if e.ValueType != "string" {
+					t.Errorf("%s: ValueType = %q, want string", e.DocumentPath, e.ValueType)
+				}
+				got = append(got, e.DocumentPath+" "+extractCleanMessage(e.Message, e.DocumentPath)+" "+e.Value)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+)
+
+// RedactedValue replaces the document values that ErrorOptions redacts
+const RedactedValue = "***"
+
+// redactedMessage rewrites the library's message of the error kinds that quote the
+// document value (a rejected string, number or $data value) with RedactedValue in its
+// place. Other kinds only quote the schema and are left alone.
+func redactedMessage(errorKind jsonschema.ErrorKind) (string, bool) {
+	switch k := errorKind.(type) {
+	case *kind.Pattern:
+		return fmt.Sprintf("%s does not match pattern '%s'", RedactedValue, k.Want), true
+	case *kind.Format:
+		// The format's own error can repeat parts of the value
+		return fmt.Sprintf("%s is not valid %s", RedactedValue, k.Want), true
+	case *kind.Minimum:
+		return fmt.Sprintf("minimum: got %s, want %v", RedactedValue, ratFloat(k.Want)), true
+	case *kind.Maximum:
+		return fmt.Sprintf("maximum: got %s, want %v", RedactedValue, ratFloat(k.Want)), true
+	case *kind.ExclusiveMinimum:
+		return fmt.Sprintf("exclusiveMinimum: got %s, want %v", RedactedValue, ratFloat(k.Want)), true
+	case *kind.ExclusiveMaximum:
+		return fmt.Sprintf("exclusiveMaximum: got %s, want %v", RedactedValue, ratFloat(k.Want)), true
+	case *kind.MultipleOf:
+		return fmt.Sprintf("multipleOf: got %s, want %v", RedactedValue, ratFloat(k.Want)), true
+	case *invalidDataValue:
+		return fmt.Sprintf("$data for %s at %s resolved to %s, which is not a valid %s value", k.keyword, k.pointer, RedactedValue, k.keyword), true
+	default:
+		return "", false
+	}
+}
+
+// ratFloat converts a schema number for formatting, as the library's messages do
+func ratFloat(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
+
+// redacts reports whether the value at location is redacted: every value with
+// RedactValues, else the values at or under a RedactPaths match
+func (opts ErrorOptions) redacts(location []string) bool {
+	if opts.RedactValues {
+		return true
+	}
+	for _, pattern := range opts.RedactPaths {
+		tokens, err := ParseJSONPointer(pattern)
+		if err == nil && matchPointerGlob(tokens, location) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPointerGlob reports whether location is at or under a path matching pattern,
+// whose "*" tokens match any one token and "**" tokens any number of tokens
+func matchPointerGlob(pattern, location []string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(location); i++ {
+			if matchPointerGlob(pattern[1:], location[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(location) == 0 || (pattern[0] != "*" && pattern[0] != location[0]) {
+		return false
+	}
+	return matchPointerGlob(pattern[1:], location[1:])
+}
+
+// redactPaths returns a copy of data with every value matching opts.RedactPaths
+// replaced by RedactedValue; data itself is not modified
+func redactPaths(data interface{}, location []string, opts ErrorOptions) interface{} {
+	if opts.redacts(location) {
+		return RedactedValue
+	}
+	switch v := data.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			redacted[key] = redactPaths(value, append(location[:len(location):len(location)], key), opts)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, value := range v {
+			redacted[i] = redactPaths(value, append(location[:len(location):len(location)], strconv.Itoa(i)), opts)
+		}
+		return redacted
+	default:
+		return data
+	}
+}
+
+// errorValue is extractValueAtPath for an error's Value: RedactedValue when the value
+// at location is redacted by path, else the value with its redacted parts masked
+func errorValue(documentData interface{}, location []string, opts ErrorOptions) (string, string) {
+	value, valueType := extractValueAtPath(documentData, location)
+	if value == "" || len(opts.RedactPaths) == 0 {
+		return value, valueType
+	}
+	if opts.redacts(location) {
+		return RedactedValue, valueType
+	}
+	redacted, _ := extractValueAtPath(redactPaths(documentData, nil, opts), location)
+	return redacted, valueType
+}
+
+// documentContext is the Document of the template context: the document truncated
+// to 500 bytes, RedactedValue when opts redacts every value, or with the values
+// matching opts.RedactPaths masked when the document parsed (documentData non-nil)
+func documentContext(document string, documentData interface{}, opts ErrorOptions) string {
+	if opts.RedactValues {
+		return RedactedValue
+	}
+	if len(opts.RedactPaths) > 0 && documentData != nil {
+		if redacted, err := json.Marshal(redactPaths(documentData, nil, opts)); err == nil {
+			document = string(redacted)
+		}
+	}
+	return truncateString(document, 500)
+}
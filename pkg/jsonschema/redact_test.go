@@ -0,0 +1,150 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestMatchPointerGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		location []string
+		want     bool
+	}{
+		{pattern: "/db/password", location: []string{"db", "password"}, want: true},
+		{pattern: "/db/password", location: []string{"db", "host"}, want: false},
+		{pattern: "/db/password", location: []string{"db"}, want: false},
+		{pattern: "/credentials", location: []string{"credentials", "key"}, want: true},
+		{pattern: "/credentials/*", location: []string{"credentials", "key"}, want: true},
+		{pattern: "/credentials/*", location: []string{"credentials"}, want: false},
+		{pattern: "/users/*/token", location: []string{"users", "0", "token"}, want: true},
+		{pattern: "/users/*/token", location: []string{"users", "0", "name"}, want: false},
+		{pattern: "/**/password", location: []string{"password"}, want: true},
+		{pattern: "/**/password", location: []string{"a", "b", "password"}, want: true},
+		{pattern: "/**/password", location: []string{"a", "passwords"}, want: false},
+		{pattern: "/a~1b", location: []string{"a/b"}, want: true},
+		{pattern: "", location: []string{"anything"}, want: true},
+	}
+	for _, tt := range tests {
+		tokens, err := ParseJSONPointer(tt.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := matchPointerGlob(tokens, tt.location); got != tt.want {
+			t.Errorf("matchPointerGlob(%q, %q) = %v, want %v", tt.pattern, tt.location, got, tt.want)
+		}
+	}
+}
+
+func TestFormatValidationErrorWithOptions_RedactPaths(t *testing.T) {
+	schemaData, err := ParseJSON([]byte(`{
+		"properties": {
+			"db": {
+				"maxProperties": 1,
+				"properties": {
+					"host": {"pattern": "^[a-z]+$"},
+					"password": {"pattern": "^[a-f0-9]{32}$"}
+				}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("db.schema.json", schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("db.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	document := `{"db": {"host": "db.internal", "password": "hunter2"}}`
+	documentData, err := ParseJSON([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validationErr := schema.Validate(documentData)
+
+	opts := ErrorOptions{RedactPaths: []string{"/**/password"}}
+	got := FormatValidationErrorWithOptions(validationErr, "db.schema.json", document, "{{.Document}}\n{{range .Errors}}{{.DocumentPath}} {{.Value}} {{.Message}}\n{{end}}", opts).Error()
+	if strings.Contains(got, "hunter2") {
+		t.Errorf("FormatValidationErrorWithOptions() shows the redacted password:\n%s", got)
+	}
+	for _, want := range []string{
+		`{"db":{"host":"db.internal","password":"***"}}`,
+		`/db {"host":"db.internal","password":"***"} at '/db': object has 2 properties, want at most 1`,
+		`/db/host "db.internal" at '/db/host': 'db.internal' does not match pattern '^[a-z]+$'`,
+		`/db/password *** at '/db/password': *** does not match pattern '^[a-f0-9]{32}$'`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatValidationErrorWithOptions() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	// A document that does not parse is shown as is
+	if got := FormatValidationErrorWithOptions(validationErr, "db.schema.json", "db.json", "{{.Document}}", opts).Error(); got != "db.json" {
+		t.Errorf("Document = %q, want the unparsed document", got)
+	}
+}
+
+func TestExtractValidationErrorsWithOptions_RedactPropertyNames(t *testing.T) {
+	schemaData, err := ParseJSON([]byte(`{
+		"properties": {
+			"credentials": {"propertyNames": {"pattern": "^[a-z]+$", "format": "email"}},
+			"labels": {"propertyNames": {"pattern": "^[a-z]+$"}}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	if err := compiler.AddResource("names.schema.json", schemaData); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile("names.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	documentData := map[string]interface{}{
+		"credentials": map[string]interface{}{"sk-live-123": "x"},
+		"labels":      map[string]interface{}{"Team": "x"},
+	}
+	validationErr := schema.Validate(documentData)
+
+	for _, opts := range []ErrorOptions{
+		{RedactPaths: []string{"/credentials/*"}},
+		{RedactValues: true},
+	} {
+		var credentials, labels []ValidationErrorDetail
+		for _, detail := range ExtractValidationErrorsWithOptions(validationErr, documentData, opts) {
+			if strings.Contains(detail.Message+detail.RawMessage+detail.Value, "sk-live-123") {
+				t.Errorf("%+v: error shows the redacted property name: %+v", opts, detail)
+			}
+			switch detail.DocumentPath {
+			case "/credentials":
+				credentials = append(credentials, detail)
+			case "/labels":
+				labels = append(labels, detail)
+			}
+		}
+		if len(credentials) == 0 {
+			t.Errorf("%+v: no /credentials errors, want the property name's", opts)
+		}
+		for _, detail := range credentials {
+			if detail.Value != RedactedValue || !strings.Contains(detail.Message, "property name '***'") {
+				t.Errorf("%+v: error = %+v, want the name redacted", opts, detail)
+			}
+		}
+		// Names outside the redacted paths stay visible
+		if len(labels) != 1 {
+			t.Fatalf("%+v: /labels errors = %+v, want one", opts, labels)
+		}
+		if wantShown := len(opts.RedactPaths) > 0; strings.Contains(labels[0].Message, "'Team'") != wantShown {
+			t.Errorf("%+v: /labels message = %q, want the name shown: %v", opts, labels[0].Message, wantShown)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package jsonschema
+
+import (
+	"container/list"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// RefResolver inlines "$ref"s so a schema spread over several files becomes self-contained.
+// References are resolved relative to the file (or URL) that contains them; fragments
+// must be JSON Pointers (e.g. "#/$defs/port").
+type RefResolver struct {
+	// AllowPatterns restricts which files or URLs a $ref may point to (glob syntax).
+	// Relative patterns are matched against absolute paths. Empty allows every local file.
+	AllowPatterns []string
+
+	// Fetcher loads http:// and https:// references; remote refs fail when nil
+	Fetcher *RemoteFetcher
+
+	// KeepRemoteRefs leaves http:// and https:// references in place, e.g. for a
+	// compiler that maps them to local files, instead of fetching them
+	KeepRemoteRefs bool
+
+	// MaxCachedFiles bounds how many parsed documents are kept, evicting the least
+	// recently used. Documents on the active resolution chain are never evicted.
+	// Zero keeps every document.
+	MaxCachedFiles int
+
+	loadedFiles map[string]*list.Element // cache entries keyed by absolute path or URL
+	lru         *list.List               // *cachedFile values, most recently used first
+	stats       RefResolverStats
+	stack       []string // active resolution chain (location#fragment), for cycle detection
+	rootDir     string   // directory of the root schema, used to shorten cycle reports
+}
+
+// RefResolverStats reports how a RefResolver's document cache was used
+type RefResolverStats struct {
+	Hits        int // loads served from the cache
+	Misses      int // loads that had to read a file or fetch a URL
+	FilesLoaded int // documents read and parsed successfully
+	Evictions   int // documents dropped to stay within MaxCachedFiles
+	Cached      int // documents currently cached
+}
+
+type cachedFile struct {
+	location string
+	doc      interface{}
+}
+
+// NewRefResolver creates a resolver restricted to the given glob patterns
+func NewRefResolver(allowPatterns []string) *RefResolver {
+	return &RefResolver{AllowPatterns: allowPatterns}
+}
+
+// Stats returns cache counters accumulated over every ResolveRefs call
+func (r *RefResolver) Stats() RefResolverStats {
+	stats := r.stats
+	stats.Cached = len(r.loadedFiles)
+	return stats
+}
+
+// ResolveRefs loads the schema at schemaPath and returns it with every $ref inlined
+func (r *RefResolver) ResolveRefs(schemaPath string) (interface{}, error) {
+	location := schemaPath
+	if !IsRemoteURL(schemaPath) {
+		abs, err := filepath.Abs(schemaPath)
+		if err != nil {
+			return nil, fmt.Errorf("resolving path %q: %w", schemaPath, err)
+		}
+		location = abs
+	}
+
+	doc, err := r.load(location)
+	if err != nil {
+		return nil, err
+	}
+
+	r.rootDir = filepath.Dir(location)
+	r.stack = []string{refKey(location, "")}
+	defer func() {
+		r.stack = nil
+		r.evict("")
+	}()
+
+	return r.resolveRefsRecursive(doc, location, doc)
+}
+
+// resolveRefsRecursive walks node (part of the document root loaded from location)
+// and replaces every {"$ref": ...} object with the referenced content
+func (r *RefResolver) resolveRefsRecursive(node interface{}, location string, root interface{}) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			return r.resolveRef(v, ref, location, root)
+		}
+
+		resolved := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if nonSchemaKeywords[key] {
+				resolved[key] = child
+				continue
+			}
+			out, err := r.resolveRefsRecursive(child, location, root)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = out
+		}
+		return resolved, nil
+
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, child := range v {
+			out, err := r.resolveRefsRecursive(child, location, root)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = out
+		}
+		return resolved, nil
+
+	default:
+		return node, nil
+	}
+}
+
+// resolveRef inlines a single $ref. Sibling keywords next to $ref are kept by
+// combining both with allOf, matching their meaning in draft 2019-09 and later.
+func (r *RefResolver) resolveRef(obj map[string]interface{}, ref, location string, root interface{}) (interface{}, error) {
+	target, fragment := splitRef(ref)
+
+	targetLocation, targetRoot := location, root
+	if target != "" {
+		var err error
+		targetLocation, err = resolveLocation(location, target)
+		if err != nil {
+			return nil, fmt.Errorf("$ref %q in %s: %w", ref, location, err)
+		}
+		if r.KeepRemoteRefs && IsRemoteURL(targetLocation) {
+			return r.keepRef(obj, location, root)
+		}
+		if err := r.checkAllowed(targetLocation); err != nil {
+			return nil, err
+		}
+		targetRoot, err = r.load(targetLocation)
+		if err != nil {
+			return nil, fmt.Errorf("$ref %q in %s: %w", ref, location, err)
+		}
+	}
+
+	targetNode, err := resolvePointer(targetRoot, fragment)
+	if err != nil {
+		return nil, fmt.Errorf("$ref %q in %s: %w", ref, location, err)
+	}
+
+	// A target already being resolved (including an ancestor reached via "#")
+	// would be inlined into itself forever
+	key := refKey(targetLocation, fragment)
+	if r.isActive(key) {
+		return nil, r.cycleError(key)
+	}
+	r.stack = append(r.stack, key)
+	resolved, err := r.resolveRefsRecursive(targetNode, targetLocation, targetRoot)
+	r.stack = r.stack[:len(r.stack)-1]
+	if err != nil {
+		return nil, err
+	}
+
+	if len(obj) == 1 {
+		return resolved, nil
+	}
+
+	siblings := make(map[string]interface{}, len(obj)-1)
+	for key, value := range obj {
+		if key != "$ref" {
+			siblings[key] = value
+		}
+	}
+	siblingsResolved, err := r.resolveRefsRecursive(siblings, location, root)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"allOf": []interface{}{resolved, siblingsResolved}}, nil
+}
+
+// keepRef returns obj with its $ref unchanged and its sibling keywords resolved
+func (r *RefResolver) keepRef(obj map[string]interface{}, location string, root interface{}) (interface{}, error) {
+	kept := make(map[string]interface{}, len(obj))
+	for key, value := range obj {
+		if key == "$ref" || nonSchemaKeywords[key] {
+			kept[key] = value
+			continue
+		}
+		out, err := r.resolveRefsRecursive(value, location, root)
+		if err != nil {
+			return nil, err
+		}
+		kept[key] = out
+	}
+	return kept, nil
+}
+
+// refKey identifies a resolution target by absolute location plus JSON Pointer fragment
+func refKey(location, fragment string) string {
+	return location + "#" + fragment
+}
+
+// isActive reports whether key is on the active resolution chain, or is an
+// ancestor (pointer prefix) of an active target in the same document
+func (r *RefResolver) isActive(key string) bool {
+	for _, active := range r.stack {
+		if active == key || strings.HasPrefix(active, key+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleError describes the active chain that leads back to key
+func (r *RefResolver) cycleError(key string) error {
+	chain := make([]string, 0, len(r.stack)+1)
+	for _, active := range r.stack {
+		chain = append(chain, r.displayKey(active))
+	}
+	chain = append(chain, r.displayKey(key))
+	return fmt.Errorf("circular $ref detected: %s", strings.Join(chain, " -> "))
+}
+
+// displayKey shortens a key to a path relative to the root schema directory
+func (r *RefResolver) displayKey(key string) string {
+	location, fragment := splitRef(key)
+	if !IsRemoteURL(location) && r.rootDir != "" {
+		if rel, err := filepath.Rel(r.rootDir, location); err == nil && !strings.HasPrefix(rel, "..") {
+			location = filepath.ToSlash(rel)
+		}
+	}
+	if fragment != "" {
+		return location + "#" + fragment
+	}
+	return location
+}
+
+// checkAllowed rejects targets that don't match any allow pattern
+func (r *RefResolver) checkAllowed(location string) error {
+	if len(r.AllowPatterns) == 0 {
+		if IsRemoteURL(location) {
+			return fmt.Errorf("$ref to %q is not allowed: remote references must match an allow pattern", location)
+		}
+		return nil
+	}
+
+	for _, pattern := range r.AllowPatterns {
+		if IsRemoteURL(location) {
+			if ok, _ := path.Match(pattern, location); ok {
+				return nil
+			}
+			continue
+		}
+		if !IsRemoteURL(pattern) && matchFilePattern(pattern, location) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("$ref to %q is not allowed (allowed patterns: %s)", location, strings.Join(r.AllowPatterns, ", "))
+}
+
+// matchFilePattern reports whether the absolute path matches a glob pattern; a relative
+// pattern is made absolute first
+func matchFilePattern(pattern, path string) bool {
+	if !filepath.IsAbs(pattern) {
+		if abs, err := filepath.Abs(pattern); err == nil {
+			pattern = abs
+		}
+	}
+	ok, _ := filepath.Match(pattern, path)
+	return ok
+}
+
+// load parses a local file or remote URL once and caches the result
+func (r *RefResolver) load(location string) (interface{}, error) {
+	if r.loadedFiles == nil {
+		r.loadedFiles = make(map[string]*list.Element)
+		r.lru = list.New()
+	}
+	if elem, ok := r.loadedFiles[location]; ok {
+		r.stats.Hits++
+		r.lru.MoveToFront(elem)
+		return elem.Value.(*cachedFile).doc, nil
+	}
+	r.stats.Misses++
+
+	var (
+		doc interface{}
+		err error
+	)
+	if IsRemoteURL(location) {
+		if r.Fetcher == nil {
+			return nil, fmt.Errorf("remote reference %q cannot be fetched", location)
+		}
+		doc, err = r.Fetcher.ParseURL(location)
+	} else {
+		doc, err = ParseFile(location, FileTypeAuto)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading %q: %w", location, err)
+	}
+	r.stats.FilesLoaded++
+
+	r.loadedFiles[location] = r.lru.PushFront(&cachedFile{location: location, doc: doc})
+	r.evict(location)
+	return doc, nil
+}
+
+// evict drops least recently used documents beyond MaxCachedFiles, skipping keep
+// and those on the active chain. The cache may exceed the bound while the chain
+// alone is longer than MaxCachedFiles; ResolveRefs trims it once done.
+func (r *RefResolver) evict(keep string) {
+	if r.MaxCachedFiles <= 0 || r.lru == nil {
+		return
+	}
+	for elem := r.lru.Back(); elem != nil && r.lru.Len() > r.MaxCachedFiles; {
+		prev := elem.Prev()
+		entry := elem.Value.(*cachedFile)
+		if entry.location != keep && !r.onStack(entry.location) {
+			r.lru.Remove(elem)
+			delete(r.loadedFiles, entry.location)
+			r.stats.Evictions++
+		}
+		elem = prev
+	}
+}
+
+// onStack reports whether any target on the active resolution chain is in location
+func (r *RefResolver) onStack(location string) bool {
+	for _, active := range r.stack {
+		if activeLocation, _ := splitRef(active); activeLocation == location {
+			return true
+		}
+	}
+	return false
+}
+
+// splitRef separates a $ref into its document part and JSON Pointer fragment
+func splitRef(ref string) (string, string) {
+	if i := strings.Index(ref, "#"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// resolveLocation resolves a $ref target relative to the document that contains it
+func resolveLocation(base, target string) (string, error) {
+	if IsRemoteURL(target) {
+		return target, nil
+	}
+	if strings.HasPrefix(target, "file://") {
+		u, err := url.Parse(target)
+		if err != nil {
+			return "", err
+		}
+		return filepath.FromSlash(u.Path), nil
+	}
+	if IsRemoteURL(base) {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return "", err
+		}
+		refURL, err := url.Parse(target)
+		if err != nil {
+			return "", err
+		}
+		return baseURL.ResolveReference(refURL).String(), nil
+	}
+	if filepath.IsAbs(target) {
+		return filepath.Clean(target), nil
+	}
+	return filepath.Join(filepath.Dir(base), filepath.FromSlash(target)), nil
+}
+
+// resolvePointer returns the value at a JSON Pointer (RFC 6901) fragment
+func resolvePointer(doc interface{}, fragment string) (interface{}, error) {
+	if fragment == "" {
+		return doc, nil
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, fmt.Errorf("unsupported fragment %q: only JSON Pointers are supported", fragment)
+	}
+
+	decoded, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fragment %q: %w", fragment, err)
+	}
+
+	tokens, err := ParseJSONPointer(decoded)
+	if err != nil {
+		return nil, err
+	}
+	value, _, ok := lookupPath(doc, tokens)
+	if !ok {
+		return nil, fmt.Errorf("pointer %q not found", fragment)
+	}
+	return value, nil
+}
@@ -0,0 +1,331 @@
+package jsonschema
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSchemaFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRefResolver_ResolveRefs(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		allow         []string
+		want          string
+		errorContains string
+	}{
+		{
+			name: "local fragment",
+			files: map[string]string{
+				"main.json": `{"properties": {"name": {"$ref": "#/$defs/name"}}, "$defs": {"name": {"type": "string"}}}`,
+			},
+			want: `{"$defs":{"name":{"type":"string"}},"properties":{"name":{"type":"string"}}}`,
+		},
+		{
+			name: "relative file with nested ref",
+			files: map[string]string{
+				"main.json":         `{"properties": {"port": {"$ref": "types/port.json"}}}`,
+				"types/port.json":   `{"$ref": "common.json#/$defs/int"}`,
+				"types/common.json": `{"$defs": {"int": {"type": "integer"}}}`,
+			},
+			want: `{"properties":{"port":{"type":"integer"}}}`,
+		},
+		{
+			name: "YAML definitions file",
+			files: map[string]string{
+				"main.json":  `{"properties": {"foo": {"$ref": "./types.yaml#/definitions/Foo"}}}`,
+				"types.yaml": "definitions:\n  Foo:\n    type: string\n    minLength: 1\n",
+			},
+			want: `{"properties":{"foo":{"minLength":1,"type":"string"}}}`,
+		},
+		{
+			name: "sibling keywords are kept with allOf",
+			files: map[string]string{
+				"main.json": `{"$ref": "#/$defs/base", "required": ["id"], "$defs": {"base": {"type": "object"}}}`,
+			},
+			want: `{"allOf":[{"type":"object"},{"$defs":{"base":{"type":"object"}},"required":["id"]}]}`,
+		},
+		{
+			name: "instance data is not resolved",
+			files: map[string]string{
+				"main.json": `{"const": {"$ref": "missing.json"}}`,
+			},
+			want: `{"const":{"$ref":"missing.json"}}`,
+		},
+		{
+			name: "dynamic references are passed through",
+			files: map[string]string{
+				"main.json": `{"$dynamicAnchor": "node", "properties": {"children": {"items": {"$dynamicRef": "#node"}}}}`,
+			},
+			want: `{"$dynamicAnchor":"node","properties":{"children":{"items":{"$dynamicRef":"#node"}}}}`,
+		},
+		{
+			name: "allowed by pattern",
+			files: map[string]string{
+				"main.json":   `{"$ref": "defs/a.json"}`,
+				"defs/a.json": `{"type": "string"}`,
+			},
+			allow: []string{"defs/*.json"},
+			want:  `{"type":"string"}`,
+		},
+		{
+			name: "denied by pattern",
+			files: map[string]string{
+				"main.json":    `{"$ref": "other/a.json"}`,
+				"other/a.json": `{"type": "string"}`,
+			},
+			allow:         []string{"defs/*.json"},
+			errorContains: "is not allowed",
+		},
+		{
+			name: "missing pointer",
+			files: map[string]string{
+				"main.json": `{"$ref": "#/$defs/missing"}`,
+			},
+			errorContains: `pointer "/$defs/missing" not found`,
+		},
+		{
+			name: "anchor fragments are unsupported",
+			files: map[string]string{
+				"main.json": `{"$ref": "#name"}`,
+			},
+			errorContains: "only JSON Pointers are supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeSchemaFiles(t, tt.files)
+
+			allow := make([]string, len(tt.allow))
+			for i, pattern := range tt.allow {
+				allow[i] = filepath.Join(dir, pattern)
+			}
+
+			resolved, err := NewRefResolver(allow).ResolveRefs(filepath.Join(dir, "main.json"))
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveRefs() error = %v", err)
+			}
+
+			got, err := MarshalDeterministicString(resolved)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveRefs() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefResolver_RemoteRefs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"type": "integer"}`))
+	}))
+	defer server.Close()
+
+	dir := writeSchemaFiles(t, map[string]string{
+		"main.json": `{"$ref": "` + server.URL + `/port.json"}`,
+	})
+	schemaPath := filepath.Join(dir, "main.json")
+
+	t.Run("denied without allow pattern", func(t *testing.T) {
+		resolver := NewRefResolver(nil)
+		resolver.Fetcher = &RemoteFetcher{}
+		if _, err := resolver.ResolveRefs(schemaPath); err == nil || !strings.Contains(err.Error(), "is not allowed") {
+			t.Fatalf("expected not allowed error, got %v", err)
+		}
+	})
+
+	t.Run("kept for the compiler", func(t *testing.T) {
+		dir := writeSchemaFiles(t, map[string]string{
+			"main.json":  `{"properties": {"port": {"$ref": "` + server.URL + `/port.json", "not": {"$ref": "local.json"}}, "name": {"$ref": "local.json"}}}`,
+			"local.json": `{"type": "string"}`,
+		})
+		resolver := NewRefResolver(nil)
+		resolver.KeepRemoteRefs = true
+		resolved, err := resolver.ResolveRefs(filepath.Join(dir, "main.json"))
+		if err != nil {
+			t.Fatalf("ResolveRefs() error = %v", err)
+		}
+		got, _ := MarshalDeterministicString(resolved)
+		want := `{"properties":{"name":{"type":"string"},"port":{"$ref":"` + server.URL + `/port.json","not":{"type":"string"}}}}`
+		if got != want {
+			t.Errorf("ResolveRefs() = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("fetched when allowed", func(t *testing.T) {
+		resolver := NewRefResolver([]string{server.URL + "/*"})
+		resolver.Fetcher = &RemoteFetcher{}
+		resolved, err := resolver.ResolveRefs(schemaPath)
+		if err != nil {
+			t.Fatalf("ResolveRefs() error = %v", err)
+		}
+		if got, _ := MarshalDeterministicString(resolved); got != `{"type":"integer"}` {
+			t.Errorf("ResolveRefs() = %s", got)
+		}
+	})
+}
+
+func TestRefResolver_CircularRefs(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "cyclic pair of files",
+			files: map[string]string{
+				"main.json": `{"$ref": "a.json"}`,
+				"a.json":    `{"properties": {"b": {"$ref": "b.json"}}}`,
+				"b.json":    `{"properties": {"a": {"$ref": "a.json"}}}`,
+			},
+			want: "circular $ref detected: main.json -> a.json -> b.json -> a.json",
+		},
+		{
+			name: "file referencing the root schema",
+			files: map[string]string{
+				"main.json":  `{"properties": {"child": {"$ref": "child.json"}}}`,
+				"child.json": `{"properties": {"parent": {"$ref": "main.json"}}}`,
+			},
+			want: "circular $ref detected: main.json -> child.json -> main.json",
+		},
+		{
+			name: "self-referential definition",
+			files: map[string]string{
+				"main.json": `{"$ref": "#/$defs/node", "$defs": {"node": {"properties": {"next": {"$ref": "#/$defs/node"}}}}}`,
+			},
+			want: "circular $ref detected: main.json -> main.json#/$defs/node -> main.json#/$defs/node",
+		},
+		{
+			name: "fragment-only ref back to the document root",
+			files: map[string]string{
+				"main.json": `{"properties": {"children": {"items": {"$ref": "#"}}}}`,
+			},
+			want: "circular $ref detected: main.json -> main.json",
+		},
+		{
+			name: "ref to an ancestor definition",
+			files: map[string]string{
+				"main.json": `{"$ref": "#/$defs/tree/properties/leaf", "$defs": {"tree": {"properties": {"leaf": {"items": {"$ref": "#/$defs/tree"}}}}}}`,
+			},
+			want: "circular $ref detected: main.json -> main.json#/$defs/tree/properties/leaf -> main.json#/$defs/tree",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeSchemaFiles(t, tt.files)
+
+			_, err := NewRefResolver(nil).ResolveRefs(filepath.Join(dir, "main.json"))
+			if err == nil {
+				t.Fatal("expected circular reference error, got nil")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("error = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRefResolver_SharedRefIsNotACycle(t *testing.T) {
+	dir := writeSchemaFiles(t, map[string]string{
+		"main.json":  `{"properties": {"a": {"$ref": "types.json#/$defs/id"}, "b": {"$ref": "types.json#/$defs/id"}}}`,
+		"types.json": `{"$defs": {"id": {"type": "string"}}}`,
+	})
+
+	resolved, err := NewRefResolver(nil).ResolveRefs(filepath.Join(dir, "main.json"))
+	if err != nil {
+		t.Fatalf("ResolveRefs() error = %v", err)
+	}
+	if got, _ := MarshalDeterministicString(resolved); got != `{"properties":{"a":{"type":"string"},"b":{"type":"string"}}}` {
+		t.Errorf("ResolveRefs() = %s", got)
+	}
+}
+
+func TestRefResolver_Stats(t *testing.T) {
+	shared := map[string]string{
+		"main.json": `{"anyOf": [{"$ref": "a.json"}, {"$ref": "b.json"}, {"$ref": "a.json"}]}`,
+		"a.json":    `{"type": "string"}`,
+		"b.json":    `{"type": "integer"}`,
+	}
+	chain := map[string]string{
+		"main.json":  `{"items": {"$ref": "chain.json"}}`,
+		"chain.json": `{"items": {"$ref": "leaf.json"}}`,
+		"leaf.json":  `{"type": "string"}`,
+	}
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		maxFiles int
+		want     RefResolverStats
+		resolved string
+	}{
+		{
+			name:     "unbounded",
+			files:    shared,
+			want:     RefResolverStats{Hits: 1, Misses: 3, FilesLoaded: 3, Cached: 3},
+			resolved: `{"anyOf":[{"type":"string"},{"type":"integer"},{"type":"string"}]}`,
+		},
+		{
+			name:     "least recently used file is evicted",
+			files:    shared,
+			maxFiles: 2,
+			want:     RefResolverStats{Misses: 4, FilesLoaded: 4, Evictions: 2, Cached: 2},
+			resolved: `{"anyOf":[{"type":"string"},{"type":"integer"},{"type":"string"}]}`,
+		},
+		{
+			// Every file is on the active chain until leaf.json is resolved
+			name:     "active chain outlives the bound",
+			files:    chain,
+			maxFiles: 1,
+			want:     RefResolverStats{Misses: 3, FilesLoaded: 3, Evictions: 2, Cached: 1},
+			resolved: `{"items":{"items":{"type":"string"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeSchemaFiles(t, tt.files)
+			resolver := NewRefResolver(nil)
+			resolver.MaxCachedFiles = tt.maxFiles
+
+			resolved, err := resolver.ResolveRefs(filepath.Join(dir, "main.json"))
+			if err != nil {
+				t.Fatalf("ResolveRefs() error = %v", err)
+			}
+			if got, _ := MarshalDeterministicString(resolved); got != tt.resolved {
+				t.Errorf("ResolveRefs() = %s, want %s", got, tt.resolved)
+			}
+			if got := resolver.Stats(); got != tt.want {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
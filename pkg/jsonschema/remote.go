@@ -0,0 +1,220 @@
+package jsonschema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFetchTimeout bounds a single remote schema request
+const DefaultFetchTimeout = 30 * time.Second
+
+// IsRemoteURL reports whether a schema location is an http:// or https:// URL
+func IsRemoteURL(location string) bool {
+	lower := strings.ToLower(location)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// RemoteFetcher downloads remote schemas over HTTP(S) and caches them on disk.
+// Cached entries honour Cache-Control (max-age, no-cache, no-store) and are
+// revalidated with ETag / Last-Modified once stale.
+type RemoteFetcher struct {
+	CacheDir string        // Directory for cached responses; caching is disabled when empty
+	Timeout  time.Duration // Per-request timeout; DefaultFetchTimeout when zero
+	Client   *http.Client  // HTTP client; a client with Timeout is created when nil
+}
+
+// cacheEntry is the metadata stored next to a cached response body
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	ContentType  string    `json:"contentType,omitempty"`
+	Expires      time.Time `json:"expires"`
+}
+
+// Fetch returns the content at rawURL, serving it from the cache while fresh
+func (f *RemoteFetcher) Fetch(rawURL string) ([]byte, error) {
+	body, _, err := f.FetchWithContentType(rawURL)
+	return body, err
+}
+
+// FetchWithContentType is Fetch that also returns the Content-Type of the response
+// (of the cached response when served from the cache), "" when the server sent none
+func (f *RemoteFetcher) FetchWithContentType(rawURL string) ([]byte, string, error) {
+	bodyPath, metaPath := f.cachePaths(rawURL)
+	cached, entry := f.readCache(bodyPath, metaPath)
+
+	if cached != nil && time.Now().Before(entry.Expires) {
+		return cached, entry.ContentType, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating request for %q: %w", rawURL, err)
+	}
+	if cached != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := f.client().Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching %q: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		entry.Expires = cacheExpiry(resp.Header)
+		f.writeCache(bodyPath, metaPath, cached, entry, resp.Header)
+		return cached, entry.ContentType, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("fetching %q: unexpected status %s", rawURL, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading response from %q: %w", rawURL, err)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	f.writeCache(bodyPath, metaPath, body, cacheEntry{
+		URL:          rawURL,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		ContentType:  contentType,
+		Expires:      cacheExpiry(resp.Header),
+	}, resp.Header)
+
+	return body, contentType, nil
+}
+
+// Load implements jsonschema.URLLoader so remote $refs are fetched through the same cache
+func (f *RemoteFetcher) Load(rawURL string) (interface{}, error) {
+	return f.ParseURL(rawURL)
+}
+
+// ParseURL fetches a remote document and parses it, detecting the format from the URL path
+func (f *RemoteFetcher) ParseURL(rawURL string) (interface{}, error) {
+	data, err := f.Fetch(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fileType := FileTypeAuto
+	if u, err := url.Parse(rawURL); err == nil {
+		fileType = DetectFileType(path.Base(u.Path))
+	}
+
+	return ParseBytes(data, fileType, ParseOptions{})
+}
+
+func (f *RemoteFetcher) client() *http.Client {
+	if f.Client != nil {
+		return f.Client
+	}
+	timeout := f.Timeout
+	if timeout <= 0 {
+		timeout = DefaultFetchTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// cachePaths returns the body and metadata file paths for a URL ("" when caching is disabled)
+func (f *RemoteFetcher) cachePaths(rawURL string) (string, string) {
+	if f.CacheDir == "" {
+		return "", ""
+	}
+	sum := sha256.Sum256([]byte(rawURL))
+	base := filepath.Join(f.CacheDir, hex.EncodeToString(sum[:]))
+	return base + ".body", base + ".meta.json"
+}
+
+// readCache returns the cached body and metadata, or nil when there is no usable entry
+func (f *RemoteFetcher) readCache(bodyPath, metaPath string) ([]byte, cacheEntry) {
+	var entry cacheEntry
+	if bodyPath == "" {
+		return nil, entry
+	}
+
+	meta, err := os.ReadFile(metaPath)
+	if err != nil || json.Unmarshal(meta, &entry) != nil {
+		return nil, entry
+	}
+
+	body, err := os.ReadFile(bodyPath)
+	if err != nil {
+		return nil, entry
+	}
+	return body, entry
+}
+
+// writeCache stores a response; failures are ignored since the cache is only an optimisation
+func (f *RemoteFetcher) writeCache(bodyPath, metaPath string, body []byte, entry cacheEntry, header http.Header) {
+	if bodyPath == "" || hasCacheDirective(header, "no-store") {
+		return
+	}
+	if err := os.MkdirAll(f.CacheDir, 0o755); err != nil {
+		return
+	}
+
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(bodyPath, body, 0o644); err != nil {
+		return
+	}
+	_ = os.WriteFile(metaPath, meta, 0o644)
+}
+
+// cacheExpiry computes when a response becomes stale from Cache-Control max-age or Expires.
+// Responses without freshness information (or with no-cache) are revalidated on next use.
+func cacheExpiry(header http.Header) time.Time {
+	now := time.Now()
+	if hasCacheDirective(header, "no-cache") {
+		return now
+	}
+
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(directive), "=")
+		if found && strings.EqualFold(name, "max-age") {
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil && seconds > 0 {
+				return now.Add(time.Duration(seconds) * time.Second)
+			}
+			return now
+		}
+	}
+
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		return expires
+	}
+
+	return now
+}
+
+// hasCacheDirective reports whether Cache-Control contains the given directive
+func hasCacheDirective(header http.Header, name string) bool {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(directive)
+		if strings.EqualFold(directive, name) || strings.HasPrefix(strings.ToLower(directive), name+"=") {
+			return true
+		}
+	}
+	return false
+}
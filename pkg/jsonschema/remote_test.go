@@ -0,0 +1,167 @@
+package jsonschema
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsRemoteURL(t *testing.T) {
+	tests := []struct {
+		location string
+		want     bool
+	}{
+		{"https://example.com/schema.json", true},
+		{"HTTP://example.com/schema.json", true},
+		{"file:///tmp/schema.json", false},
+		{"schemas/http.json", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRemoteURL(tt.location); got != tt.want {
+			t.Errorf("IsRemoteURL(%q) = %v, want %v", tt.location, got, tt.want)
+		}
+	}
+}
+
+func TestRemoteFetcher_Caching(t *testing.T) {
+	tests := []struct {
+		name         string
+		cacheControl string
+		etag         string
+		wantRequests int32 // requests made after two fetches
+		wantFull     int32 // of which returned a full body
+	}{
+		{
+			name:         "fresh entry served from cache",
+			cacheControl: "max-age=3600",
+			wantRequests: 1,
+			wantFull:     1,
+		},
+		{
+			name:         "stale entry revalidated with etag",
+			cacheControl: "no-cache",
+			etag:         `"v1"`,
+			wantRequests: 2,
+			wantFull:     1,
+		},
+		{
+			name:         "no-store is never cached",
+			cacheControl: "no-store",
+			etag:         `"v1"`,
+			wantRequests: 2,
+			wantFull:     2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests, full atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				if tt.etag != "" && r.Header.Get("If-None-Match") == tt.etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				full.Add(1)
+				w.Header().Set("Cache-Control", tt.cacheControl)
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+				}
+				_, _ = w.Write([]byte(`{"type": "object"}`))
+			}))
+			defer server.Close()
+
+			fetcher := &RemoteFetcher{CacheDir: t.TempDir()}
+			for i := 0; i < 2; i++ {
+				body, err := fetcher.Fetch(server.URL + "/schema.json")
+				if err != nil {
+					t.Fatalf("Fetch() error = %v", err)
+				}
+				if string(body) != `{"type": "object"}` {
+					t.Fatalf("Fetch() body = %q", body)
+				}
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+			if got := full.Load(); got != tt.wantFull {
+				t.Errorf("full responses = %d, want %d", got, tt.wantFull)
+			}
+		})
+	}
+}
+
+func TestRemoteFetcher_FetchWithContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=3600")
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write([]byte("type: object\n"))
+	}))
+	defer server.Close()
+
+	fetcher := &RemoteFetcher{CacheDir: t.TempDir()}
+	for i := 0; i < 2; i++ {
+		body, contentType, err := fetcher.FetchWithContentType(server.URL + "/config")
+		if err != nil {
+			t.Fatalf("FetchWithContentType() error = %v", err)
+		}
+		if string(body) != "type: object\n" || contentType != "application/yaml" {
+			t.Errorf("fetch %d: FetchWithContentType() = %q, %q", i+1, body, contentType)
+		}
+	}
+}
+
+func TestRemoteFetcher_Errors(t *testing.T) {
+	t.Run("non-200 status", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		_, err := (&RemoteFetcher{}).Fetch(server.URL + "/missing.json")
+		if err == nil || !strings.Contains(err.Error(), "unexpected status 404") {
+			t.Errorf("expected status error, got %v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+
+		_, err := (&RemoteFetcher{Timeout: 50 * time.Millisecond}).Fetch(server.URL + "/slow.json")
+		if err == nil {
+			t.Error("expected timeout error, got nil")
+		}
+	})
+}
+
+func TestRemoteFetcher_ParseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".yaml") {
+			_, _ = w.Write([]byte("type: object\nrequired: [name]\n"))
+			return
+		}
+		_, _ = w.Write([]byte(`{type: "object", /* JSON5 */ required: ["name"]}`))
+	}))
+	defer server.Close()
+
+	fetcher := &RemoteFetcher{}
+	for _, name := range []string{"schema.yaml", "schema.json5", "schema"} {
+		data, err := fetcher.ParseURL(server.URL + "/" + name + "?v=1")
+		if err != nil {
+			t.Fatalf("ParseURL(%s) error = %v", name, err)
+		}
+		obj, ok := data.(map[string]interface{})
+		if !ok || obj["type"] != "object" {
+			t.Errorf("ParseURL(%s) = %#v", name, data)
+		}
+	}
+}
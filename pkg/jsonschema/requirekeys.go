@@ -0,0 +1,41 @@
+package jsonschema
+
+import (
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+)
+
+// CheckRequiredKeys reports the keys missing from the top-level object of document,
+// without a schema, as a cheap preflight before full validation. It returns nil when
+// every key is present. Otherwise the validation error, whose schema location is
+// source, has a "required" cause at the document root (or a "type" cause when the
+// document is not an object), so it formats like any other validation error.
+func CheckRequiredKeys(document interface{}, keys []string, source string) *jsonschema.ValidationError {
+	var errorKind jsonschema.ErrorKind
+	object, ok := document.(map[string]interface{})
+	if !ok {
+		errorKind = &kind.Type{Got: jsonTypeName(document), Want: []string{"object"}}
+	} else {
+		var missing []string
+		for _, key := range keys {
+			if _, ok := object[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		errorKind = &kind.Required{Missing: missing}
+	}
+
+	return &jsonschema.ValidationError{
+		SchemaURL:        source,
+		InstanceLocation: []string{},
+		ErrorKind:        &kind.Schema{Location: source},
+		Causes: []*jsonschema.ValidationError{{
+			SchemaURL:        source,
+			InstanceLocation: []string{},
+			ErrorKind:        errorKind,
+		}},
+	}
+}
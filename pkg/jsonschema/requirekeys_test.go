@@ -0,0 +1,56 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckRequiredKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     string // Substring of the error, "" for no error
+	}{
+		{
+			name:     "all keys present",
+			document: `{"apiVersion": "v1", "kind": "Service", "spec": {}}`,
+		},
+		{
+			name:     "missing key",
+			document: `{"apiVersion": "v1", "spec": {"kind": "nested"}}`,
+			want:     "at '': missing property 'kind'",
+		},
+		{
+			name:     "several missing keys",
+			document: `{}`,
+			want:     "missing properties 'apiVersion', 'kind'",
+		},
+		{
+			name:     "not an object",
+			document: `["apiVersion", "kind"]`,
+			want:     "got array, want object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := ParseJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+			validationErr := CheckRequiredKeys(document, []string{"apiVersion", "kind"}, "require_keys")
+			if tt.want == "" {
+				if validationErr != nil {
+					t.Errorf("CheckRequiredKeys() = %v, want nil", validationErr)
+				}
+				return
+			}
+			if validationErr == nil {
+				t.Fatalf("CheckRequiredKeys() = nil, want %q", tt.want)
+			}
+			if message := validationErr.Error(); !strings.Contains(message, tt.want) {
+				t.Errorf("CheckRequiredKeys() = %q, want it to contain %q", message, tt.want)
+			}
+		})
+	}
+}
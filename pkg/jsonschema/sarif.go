@@ -0,0 +1,174 @@
+package jsonschema
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// SARIF 2.1.0 constants
+const (
+	SARIFVersion   = "2.1.0"
+	SARIFSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is the root object of a SARIF 2.1.0 report.
+// Each schema becomes its own run, with the schema file reported as the tool driver,
+// so code scanning UIs group findings by the schema that produced them.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+
+	toolVersion string
+	runIndex    map[string]int
+}
+
+// SARIFRun holds the results produced by validating documents against one schema
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+
+	ruleIndex map[string]bool
+}
+
+// SARIFTool describes the analysis tool of a run
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver identifies the schema used as the "tool" and the rules it reported
+type SARIFDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes a schema constraint that produced at least one result
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+}
+
+// SARIFResult is a single validation error
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+// SARIFMessage is a plain-text SARIF message
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation points at the document (physical) and the JSON Pointer inside it (logical)
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// SARIFPhysicalLocation wraps the artifact (document file) location
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFRegion is the line of a JSONL record that produced the result
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// SARIFArtifactLocation is the URI of the validated document
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFLogicalLocation is the JSON Pointer of the failing value within the document
+type SARIFLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// NewSARIFLog creates an empty SARIF report; toolVersion is reported on every run's driver
+func NewSARIFLog(toolVersion string) *SARIFLog {
+	return &SARIFLog{
+		Schema:      SARIFSchemaURI,
+		Version:     SARIFVersion,
+		Runs:        []SARIFRun{},
+		toolVersion: toolVersion,
+		runIndex:    make(map[string]int),
+	}
+}
+
+// AddDocument records the validation outcome of one document.
+// Every error becomes a separate result; a valid document (no errors) only ensures
+// the schema's run exists so the report lists every schema that was checked.
+func (l *SARIFLog) AddDocument(schemaFile, documentPath string, errors []ValidationErrorDetail) {
+	idx, ok := l.runIndex[schemaFile]
+	if !ok {
+		l.Runs = append(l.Runs, SARIFRun{
+			Tool: SARIFTool{Driver: SARIFDriver{
+				Name:    schemaFile,
+				Version: l.toolVersion,
+				Rules:   []SARIFRule{},
+			}},
+			Results:   []SARIFResult{},
+			ruleIndex: make(map[string]bool),
+		})
+		idx = len(l.Runs) - 1
+		l.runIndex[schemaFile] = idx
+	}
+	run := &l.Runs[idx]
+
+	uri := filepath.ToSlash(documentPath)
+	for _, detail := range errors {
+		ruleID := sarifRuleID(detail.SchemaPath)
+		if !run.ruleIndex[ruleID] {
+			run.ruleIndex[ruleID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, SARIFRule{
+				ID:               ruleID,
+				ShortDescription: SARIFMessage{Text: "Schema constraint " + ruleID},
+			})
+		}
+
+		location := SARIFLocation{
+			PhysicalLocation: SARIFPhysicalLocation{
+				ArtifactLocation: SARIFArtifactLocation{URI: uri},
+			},
+		}
+		if detail.Line > 0 {
+			location.PhysicalLocation.Region = &SARIFRegion{StartLine: detail.Line}
+		}
+		if detail.DocumentPath != "" {
+			location.LogicalLocations = []SARIFLogicalLocation{{
+				FullyQualifiedName: detail.DocumentPath,
+				Kind:               "member",
+			}}
+		}
+
+		run.Results = append(run.Results, SARIFResult{
+			RuleID:    ruleID,
+			Level:     "error",
+			Message:   SARIFMessage{Text: detail.Message},
+			Locations: []SARIFLocation{location},
+		})
+	}
+}
+
+// sarifRuleID derives a stable rule identifier from a schema location.
+// The machine-specific schema URL is dropped and only the JSON Pointer fragment is kept
+// (e.g. "file:///repo/schema.json#/properties/port" -> "/properties/port").
+// Errors without a schema location (e.g. unparseable documents) share one rule.
+func sarifRuleID(schemaPath string) string {
+	if schemaPath == "" {
+		return "document-error"
+	}
+	if i := strings.Index(schemaPath, "#"); i >= 0 {
+		schemaPath = schemaPath[i+1:]
+	}
+	if schemaPath == "" {
+		return "/"
+	}
+	return schemaPath
+}
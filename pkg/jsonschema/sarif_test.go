@@ -0,0 +1,112 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSARIFLog_AddDocument(t *testing.T) {
+	log := NewSARIFLog("1.2.3")
+
+	log.AddDocument("schemas/config.schema.json", "configs/a.json", []ValidationErrorDetail{
+		{
+			Message:      "at '/name': missing property 'name'",
+			DocumentPath: "",
+			SchemaPath:   "file:///repo/schemas/config.schema.json#/required",
+		},
+		{
+			Message:      "at '/port': got string, want integer",
+			DocumentPath: "/port",
+			SchemaPath:   "file:///repo/schemas/config.schema.json#/properties/port/type",
+		},
+		{
+			Message:      "at '/tags/1': got number, want string",
+			DocumentPath: "/tags/1",
+			SchemaPath:   "file:///repo/schemas/config.schema.json#/properties/tags/items/type",
+		},
+	})
+	log.AddDocument("schemas/config.schema.json", "configs/b.json", nil)
+	log.AddDocument("schemas/other.schema.json", "configs/c.json", []ValidationErrorDetail{
+		{Message: "parsing JSON: unexpected end of JSON input"},
+	})
+
+	if log.Version != SARIFVersion || log.Schema != SARIFSchemaURI {
+		t.Errorf("unexpected SARIF header: version=%q schema=%q", log.Version, log.Schema)
+	}
+
+	if len(log.Runs) != 2 {
+		t.Fatalf("expected one run per schema (2), got %d", len(log.Runs))
+	}
+
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "schemas/config.schema.json" {
+		t.Errorf("driver name = %q, want schema path", run.Tool.Driver.Name)
+	}
+	if run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("driver version = %q, want %q", run.Tool.Driver.Version, "1.2.3")
+	}
+
+	// Each error of a multi-error document must be its own result
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(run.Results))
+	}
+
+	wantRules := []string{"/required", "/properties/port/type", "/properties/tags/items/type"}
+	for i, want := range wantRules {
+		if run.Results[i].RuleID != want {
+			t.Errorf("result[%d].ruleId = %q, want %q", i, run.Results[i].RuleID, want)
+		}
+		if run.Tool.Driver.Rules[i].ID != want {
+			t.Errorf("rules[%d].id = %q, want %q", i, run.Tool.Driver.Rules[i].ID, want)
+		}
+		if uri := run.Results[i].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "configs/a.json" {
+			t.Errorf("result[%d] uri = %q, want %q", i, uri, "configs/a.json")
+		}
+	}
+
+	if run.Results[1].Message.Text != "at '/port': got string, want integer" {
+		t.Errorf("message text = %q", run.Results[1].Message.Text)
+	}
+	if ll := run.Results[1].Locations[0].LogicalLocations; len(ll) != 1 || ll[0].FullyQualifiedName != "/port" {
+		t.Errorf("logical location = %+v, want /port", ll)
+	}
+	if ll := run.Results[0].Locations[0].LogicalLocations; len(ll) != 0 {
+		t.Errorf("root error should have no logical location, got %+v", ll)
+	}
+
+	other := log.Runs[1]
+	if len(other.Results) != 1 || other.Results[0].RuleID != "document-error" {
+		t.Errorf("parse error result = %+v, want ruleId document-error", other.Results)
+	}
+}
+
+func TestSARIFLog_JSONLRegion(t *testing.T) {
+	log := NewSARIFLog("dev")
+	log.AddDocument("schema.json", "events.jsonl", []ValidationErrorDetail{
+		{Message: "at '/id': got string, want integer", DocumentPath: "/id", SchemaPath: "schema.json#/properties/id/type", Line: 7},
+		{Message: "at '': missing property 'id'", SchemaPath: "schema.json#/required"},
+	})
+
+	results := log.Runs[0].Results
+	if region := results[0].Locations[0].PhysicalLocation.Region; region == nil || region.StartLine != 7 {
+		t.Errorf("region = %+v, want startLine 7", region)
+	}
+	if region := results[1].Locations[0].PhysicalLocation.Region; region != nil {
+		t.Errorf("errors without a line should have no region, got %+v", region)
+	}
+}
+
+func TestSARIFLog_JSONShape(t *testing.T) {
+	log := NewSARIFLog("dev")
+	log.AddDocument("schema.json", "doc.json", nil)
+
+	data, err := json.Marshal(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"$schema":"https://json.schemastore.org/sarif-2.1.0.json","version":"2.1.0","runs":[{"tool":{"driver":{"name":"schema.json","version":"dev","rules":[]}},"results":[]}]}`
+	if string(data) != expected {
+		t.Errorf("SARIF JSON mismatch\nExpected: %s\nGot:      %s", expected, data)
+	}
+}
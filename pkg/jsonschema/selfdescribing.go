@@ -0,0 +1,53 @@
+package jsonschema
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SelfDescribingSchema returns a schema for a document whose "$schema" is a fragment
+// pointing into the document itself, e.g. "#/schemas/config". The result is a root
+// schema that $refs the fragment and carries the top-level property the fragment
+// lives under, so local $refs between schemas bundled there keep resolving. The
+// rest of the document is left out, as it is data rather than schema.
+func SelfDescribingSchema(document interface{}) (interface{}, error) {
+	obj, ok := document.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("document has no \"$schema\" keyword: document is not an object")
+	}
+	ref, ok := obj["$schema"].(string)
+	if !ok {
+		return nil, fmt.Errorf("document has no \"$schema\" keyword")
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("\"$schema\" %q must be a fragment within the document, e.g. \"#/schemas/config\"", ref)
+	}
+
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, fmt.Errorf("\"$schema\" %q: invalid fragment: %w", ref, err)
+	}
+	target, err := ResolveJSONPointer(obj, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("\"$schema\" %q does not resolve within the document: %w", ref, err)
+	}
+	if _, isObject := target.(map[string]interface{}); !isObject {
+		if _, isBool := target.(bool); !isBool {
+			return nil, fmt.Errorf("\"$schema\" %q does not point to a schema (got %T)", ref, target)
+		}
+	}
+
+	tokens, err := ParseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if tokens[0] == "$schema" {
+		return nil, fmt.Errorf("\"$schema\" %q must not point to itself", ref)
+	}
+
+	return map[string]interface{}{
+		"$ref":    ref,
+		tokens[0]: obj[tokens[0]],
+	}, nil
+}
@@ -0,0 +1,74 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelfDescribingSchema(t *testing.T) {
+	tests := []struct {
+		name          string
+		document      string
+		want          string
+		errorContains string
+	}{
+		{
+			name:     "bundled schema",
+			document: `{"$schema": "#/schemas/user", "name": "John", "schemas": {"user": {"required": ["name"]}}}`,
+			want:     `{"$ref":"#/schemas/user","schemas":{"user":{"required":["name"]}}}`,
+		},
+		{
+			name:     "escaped pointer",
+			document: `{"$schema": "#/schemas/a~1b%20c", "schemas": {"a/b c": true}}`,
+			want:     `{"$ref":"#/schemas/a~1b%20c","schemas":{"a/b c":true}}`,
+		},
+		{
+			name:          "missing $schema",
+			document:      `{"name": "John"}`,
+			errorContains: `document has no "$schema" keyword`,
+		},
+		{
+			name:          "not an object",
+			document:      `["a"]`,
+			errorContains: `document has no "$schema" keyword`,
+		},
+		{
+			name:          "external $schema",
+			document:      `{"$schema": "https://json-schema.org/draft/2020-12/schema"}`,
+			errorContains: "must be a fragment within the document",
+		},
+		{
+			name:          "unresolved pointer",
+			document:      `{"$schema": "#/schemas/user", "schemas": {}}`,
+			errorContains: "does not resolve within the document",
+		},
+		{
+			name:          "pointer to a non-schema value",
+			document:      `{"$schema": "#/name", "name": "John"}`,
+			errorContains: "does not point to a schema",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := ParseJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := SelfDescribingSchema(document)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelfDescribingSchema() error = %v", err)
+			}
+			if encoded, _ := MarshalDeterministicString(got); encoded != tt.want {
+				t.Errorf("SelfDescribingSchema() = %s, want %s", encoded, tt.want)
+			}
+		})
+	}
+}
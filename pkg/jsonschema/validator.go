@@ -0,0 +1,131 @@
+package jsonschema
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ValidatorOptions configures NewValidator
+type ValidatorOptions struct {
+	// SchemaPath is the schema file (JSON, JSON5, YAML or TOML, detected from the extension)
+	SchemaPath string
+	// Draft is used for schemas without "$schema"; Draft2020 when nil
+	Draft *jsonschema.Draft
+	// ErrorTemplate formats validation errors ("{{.FullMessage}}" when empty).
+	// A "@name" value selects one of CommonErrorTemplates.
+	ErrorTemplate string
+	// RefOverrides maps remote $ref URLs to local schema files. Remote $refs are
+	// never fetched, so every one the schema uses must be listed here.
+	RefOverrides map[string]string
+	// FileType is the document format for ValidateBytes; detected from the content when empty
+	FileType FileType
+}
+
+// Validator validates documents against a schema compiled once by NewValidator
+type Validator struct {
+	schema        *jsonschema.Schema
+	schemaPath    string
+	errorTemplate string
+	fileType      FileType
+}
+
+// NewValidator parses and compiles the schema described by opts
+func NewValidator(opts ValidatorOptions) (*Validator, error) {
+	errorTemplate := opts.ErrorTemplate
+	if errorTemplate == "" {
+		errorTemplate = "{{.FullMessage}}"
+	}
+	errorTemplate, err := ResolveErrorTemplate(errorTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaData, err := ParseFile(opts.SchemaPath, FileTypeAuto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema file %q: %w", opts.SchemaPath, err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(jsonschema.SchemeURLLoader{
+		"file": JSON5FileLoader{},
+	})
+
+	draft := opts.Draft
+	if draft == nil {
+		draft = jsonschema.Draft2020
+	}
+	compiler.DefaultDraft(draft)
+
+	for remoteURL, localPath := range opts.RefOverrides {
+		overrideData, err := ParseFile(localPath, FileTypeAuto)
+		if err != nil {
+			return nil, fmt.Errorf("ref_override: failed to parse local file %q for URL %q: %w", localPath, remoteURL, err)
+		}
+		if err := compiler.AddResource(remoteURL, overrideData); err != nil {
+			return nil, fmt.Errorf("ref_override: failed to register %q -> %q: %w", remoteURL, localPath, err)
+		}
+	}
+
+	schemaAbsPath, err := filepath.Abs(opts.SchemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path for schema: %w", err)
+	}
+	schemaURL := fmt.Sprintf("file://%s", schemaAbsPath)
+
+	if err := compiler.AddResource(schemaURL, schemaData); err != nil {
+		return nil, fmt.Errorf("failed to add schema resource: %w", err)
+	}
+
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+
+	return &Validator{
+		schema:        compiled,
+		schemaPath:    opts.SchemaPath,
+		errorTemplate: errorTemplate,
+		fileType:      opts.FileType,
+	}, nil
+}
+
+// Schema returns the compiled schema
+func (v *Validator) Schema() *jsonschema.Schema {
+	return v.schema
+}
+
+// ValidateBytes parses a document and validates it, returning the parse error or
+// the validation error formatted with the configured template. A JSONL document
+// is validated line by line, as in ValidateJSONL.
+func (v *Validator) ValidateBytes(data []byte) error {
+	fileType := v.fileType
+	if fileType == "" || fileType == FileTypeAuto {
+		fileType = DetectContentType(data)
+	}
+
+	if fileType == FileTypeJSONL {
+		_, details, err := ValidateJSONL(bytes.NewReader(data), v.schema)
+		if err != nil {
+			return fmt.Errorf("failed to read document: %w", err)
+		}
+		return FormatJSONLValidationError(details, v.schemaPath, "", v.errorTemplate, ErrorOptions{})
+	}
+
+	value, err := ParseBytes(data, fileType, ParseOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to parse document: %w", err)
+	}
+	return v.ValidateValue(value)
+}
+
+// ValidateValue validates already-parsed JSON data (maps, slices, strings,
+// float64/json.Number, bools and nil), returning the formatted validation error
+func (v *Validator) ValidateValue(value interface{}) error {
+	if err := v.schema.Validate(value); err != nil {
+		return FormatValidationError(err, v.schemaPath, "", v.errorTemplate)
+	}
+	return nil
+}
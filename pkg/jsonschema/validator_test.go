@@ -0,0 +1,123 @@
+package jsonschema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func TestValidator(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	const userURL = "https://example.com/schemas/user.json"
+	schemaPath := writeFile("schema.yaml", `
+type: object
+required: [user]
+properties:
+  user:
+    $ref: "`+userURL+`"
+`)
+	userPath := writeFile("user.json5", `{type: "object", required: ["name"]}`)
+
+	v, err := NewValidator(ValidatorOptions{
+		SchemaPath:    schemaPath,
+		ErrorTemplate: "{{.ErrorCount}} error(s): {{range .Errors}}{{.DocumentPath}} {{end}}",
+		RefOverrides:  map[string]string{userURL: userPath},
+	})
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		validate      func() error
+		errorContains string
+	}{
+		{
+			name:     "valid JSON bytes",
+			validate: func() error { return v.ValidateBytes([]byte(`{"user": {"name": "John"}}`)) },
+		},
+		{
+			name:     "valid YAML bytes",
+			validate: func() error { return v.ValidateBytes([]byte("user:\n  name: John\n")) },
+		},
+		{
+			name:          "invalid bytes use the template",
+			validate:      func() error { return v.ValidateBytes([]byte(`{"user": {}}`)) },
+			errorContains: "1 error(s): /user ",
+		},
+		{
+			name:          "malformed bytes",
+			validate:      func() error { return v.ValidateBytes([]byte(`{"user": `)) },
+			errorContains: "failed to parse document",
+		},
+		{
+			name: "valid value",
+			validate: func() error {
+				return v.ValidateValue(map[string]interface{}{"user": map[string]interface{}{"name": "John"}})
+			},
+		},
+		{
+			name:          "invalid value",
+			validate:      func() error { return v.ValidateValue(map[string]interface{}{}) },
+			errorContains: "1 error(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate()
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewValidator_Options(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.json")
+	// Array-form items is a tuple in draft-07 but invalid in draft 2020-12
+	if err := os.WriteFile(schemaPath, []byte(`{"items": [{"type": "string"}], "additionalItems": false}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewValidator(ValidatorOptions{SchemaPath: schemaPath}); err == nil {
+		t.Error("expected draft 2020-12 compile error for array-form items")
+	}
+
+	v, err := NewValidator(ValidatorOptions{SchemaPath: schemaPath, Draft: jsonschema.Draft7, FileType: FileTypeJSONL})
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+	if v.Schema().DraftVersion != 7 {
+		t.Errorf("DraftVersion = %d, want 7", v.Schema().DraftVersion)
+	}
+	err = v.ValidateBytes([]byte("[\"a\"]\n[\"a\", \"b\"]\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected JSONL error on line 2, got %v", err)
+	}
+
+	if _, err := NewValidator(ValidatorOptions{SchemaPath: schemaPath, ErrorTemplate: "@missing"}); err == nil {
+		t.Error("expected unknown template error")
+	}
+	if _, err := NewValidator(ValidatorOptions{SchemaPath: filepath.Join(dir, "missing.json")}); err == nil {
+		t.Error("expected missing schema error")
+	}
+}
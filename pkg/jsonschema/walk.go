@@ -0,0 +1,106 @@
+package jsonschema
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// walkApplicable walks document alongside the compiled schema and calls visit once for
+// every subschema that applies to a document value, with the value's path.
+// Properties, patternProperties, additionalProperties, dependentSchemas, array items,
+// $ref and allOf are followed, as are the anyOf, oneOf and if/then/else branches the
+// value satisfies. Object keys are visited in sorted order.
+func walkApplicable(schema *jsonschema.Schema, document interface{}, visit func(s *jsonschema.Schema, path []string)) {
+	w := &schemaWalker{visit: visit, visited: make(map[string]bool)}
+	w.walk(schema, document, nil)
+}
+
+type schemaWalker struct {
+	visit   func(s *jsonschema.Schema, path []string)
+	visited map[string]bool // "<schema location> <document path>", so a recursive $ref can't loop
+}
+
+func (w *schemaWalker) walk(s *jsonschema.Schema, docNode interface{}, path []string) {
+	if s == nil {
+		return
+	}
+	visitKey := s.Location + " " + joinJSONPointer(path)
+	if w.visited[visitKey] {
+		return
+	}
+	w.visited[visitKey] = true
+	w.visit(s, path)
+
+	// Subschemas applied to the same value
+	w.walk(s.Ref, docNode, path)
+	w.walk(s.RecursiveRef, docNode, path)
+	if s.DynamicRef != nil {
+		w.walk(s.DynamicRef.Ref, docNode, path)
+	}
+	for _, branch := range s.AllOf {
+		w.walk(branch, docNode, path)
+	}
+	for _, branch := range slices.Concat(s.AnyOf, s.OneOf) {
+		if branch.Validate(docNode) == nil {
+			w.walk(branch, docNode, path)
+		}
+	}
+	if s.If != nil {
+		if s.If.Validate(docNode) == nil {
+			w.walk(s.Then, docNode, path)
+		} else {
+			w.walk(s.Else, docNode, path)
+		}
+	}
+
+	switch doc := docNode.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(doc))
+		for key := range doc {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := append(slices.Clone(path), key)
+			matched := false
+			if propSchema, ok := s.Properties[key]; ok {
+				w.walk(propSchema, doc[key], childPath)
+				matched = true
+			}
+			for pattern, patternSchema := range s.PatternProperties {
+				if pattern.MatchString(key) {
+					w.walk(patternSchema, doc[key], childPath)
+					matched = true
+				}
+			}
+			if additional, ok := s.AdditionalProperties.(*jsonschema.Schema); ok && !matched {
+				w.walk(additional, doc[key], childPath)
+			}
+			w.walk(s.DependentSchemas[key], doc, path)
+		}
+
+	case []interface{}:
+		prefixItems := s.PrefixItems
+		rest := s.Items2020
+		// Before draft 2020-12, an array-valued "items" is the tuple form
+		switch items := s.Items.(type) {
+		case []*jsonschema.Schema:
+			prefixItems = items
+			rest, _ = s.AdditionalItems.(*jsonschema.Schema)
+		case *jsonschema.Schema:
+			rest = items
+		}
+		for i, item := range doc {
+			childPath := append(slices.Clone(path), strconv.Itoa(i))
+			if i < len(prefixItems) {
+				w.walk(prefixItems[i], item, childPath)
+			} else {
+				w.walk(rest, item, childPath)
+			}
+		}
+	}
+}